@@ -4,11 +4,17 @@ import (
 	"fmt"
 
 	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/ratelimit"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
+// TelegramBotAPI обертка над Bot API. Временные ошибки повторяет, остальные возвращает как *Error,
+// вид ошибки проверять через errors.Is(err, ErrNotFound) и т.п.
 type TelegramBotAPI struct {
-	tg *tgbotapi.BotAPI
+	tg    *tgbotapi.BotAPI
+	retry *retrier
+	// limit общий на все отправки и редактирования, каждая попытка тоже проходит через него
+	limit *ratelimit.Limiter
 }
 
 func NewTelegramBotAPI(conf config.Config) (*TelegramBotAPI, error) {
@@ -18,11 +24,14 @@ func NewTelegramBotAPI(conf config.Config) (*TelegramBotAPI, error) {
 	}
 
 	return &TelegramBotAPI{
-		tg: tg,
+		tg:    tg,
+		retry: newRetrier(),
+		limit: ratelimit.New(conf.RateLimit),
 	}, nil
 }
 
-func (t *TelegramBotAPI) SendAnimation(chatID int64, fileID string, caption string) (int, error) {
+// SendAnimation parseMode подписи tgbotapi.ModeHTML, tgbotapi.ModeMarkdownV2 или пусто для простого текста
+func (t *TelegramBotAPI) SendAnimation(chatID int64, fileID string, caption string, parseMode string) (int, error) {
 	animationMsgConf := tgbotapi.AnimationConfig{
 		BaseFile: tgbotapi.BaseFile{
 			BaseChat: tgbotapi.BaseChat{
@@ -31,10 +40,17 @@ func (t *TelegramBotAPI) SendAnimation(chatID int64, fileID string, caption stri
 			FileID:      fileID,
 			UseExisting: true,
 		},
-		Caption: caption,
+		Caption:   caption,
+		ParseMode: parseMode,
 	}
 
-	msg, err := t.tg.Send(animationMsgConf)
+	var msg tgbotapi.Message
+	err := t.retry.send("send animation", func() (err error) {
+		t.limit.Wait(chatID)
+		msg, err = t.tg.Send(animationMsgConf)
+
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("send animation: %w", err)
 	}
@@ -42,47 +58,150 @@ func (t *TelegramBotAPI) SendAnimation(chatID int64, fileID string, caption stri
 	return msg.MessageID, nil
 }
 
-func (t *TelegramBotAPI) EditMessage(chatID int64, messageID int, text string) error {
+// EditMessage parseMode tgbotapi.ModeHTML, tgbotapi.ModeMarkdownV2 или пусто для простого текста
+func (t *TelegramBotAPI) EditMessage(chatID int64, messageID int, text string, parseMode string) error {
 	msg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	msg.ParseMode = parseMode
 
-	if _, err := t.tg.Send(msg); err != nil {
+	err := t.retry.do("send edited message", func() error {
+		t.limit.Wait(chatID)
+		_, err := t.tg.Send(msg)
+
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("send edited message: %w", err)
 	}
 
 	return nil
 }
 
-func (t *TelegramBotAPI) GetUpdates() ([]tgbotapi.Update, error) {
-	updConf := tgbotapi.NewUpdate(0)
+// EditCaption поменяет подпись сообщения с гифкой, parseMode как в SendAnimation
+func (t *TelegramBotAPI) EditCaption(chatID int64, messageID int, caption string, parseMode string) error {
+	msg := tgbotapi.NewEditMessageCaption(chatID, messageID, caption)
+	msg.ParseMode = parseMode
+
+	err := t.retry.do("send edited caption", func() error {
+		t.limit.Wait(chatID)
+		_, err := t.tg.Send(msg)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("send edited caption: %w", err)
+	}
+
+	return nil
+}
+
+// GetUpdates обновления начиная с offset. Все обновления до offset телега считает подтвержденными
+// и больше не отдаст
+func (t *TelegramBotAPI) GetUpdates(offset int) ([]tgbotapi.Update, error) {
+	updConf := tgbotapi.NewUpdate(offset)
 	updConf.Timeout = 60
 
-	return t.tg.GetUpdates(updConf)
+	var updates []tgbotapi.Update
+	err := t.retry.do("get updates", func() (err error) {
+		updates, err = t.tg.GetUpdates(updConf)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get updates: %w", err)
+	}
+
+	return updates, nil
+}
+
+// SendMessage parseMode tgbotapi.ModeHTML, tgbotapi.ModeMarkdownV2 или пусто для простого текста
+func (t *TelegramBotAPI) SendMessage(chatID int64, text string, parseMode string) (int, error) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = parseMode
+
+	var sentMsg tgbotapi.Message
+	err := t.retry.send("send message", func() (err error) {
+		t.limit.Wait(chatID)
+		sentMsg, err = t.tg.Send(msg)
+
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("send message: %w", err)
+	}
+
+	return sentMsg.MessageID, nil
 }
 
-func (t *TelegramBotAPI) SendMessage(chatID int64, text string) (int, error) {
+// SendReply ответ на сообщение replyToID простым текстом
+func (t *TelegramBotAPI) SendReply(chatID int64, replyToID int, text string) (int, error) {
 	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyToMessageID = replyToID
+
+	var sentMsg tgbotapi.Message
+	err := t.retry.send("send reply", func() (err error) {
+		t.limit.Wait(chatID)
+		sentMsg, err = t.tg.Send(msg)
 
-	sentMsg, err := t.tg.Send(msg)
+		return err
+	})
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("send reply: %w", err)
+	}
+
+	return sentMsg.MessageID, nil
+}
+
+// SendReplyKeyboard ответ на сообщение replyToID с инлайн кнопками
+func (t *TelegramBotAPI) SendReplyKeyboard(
+	chatID int64,
+	replyToID int,
+	text string,
+	keyboard tgbotapi.InlineKeyboardMarkup,
+) (int, error) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyToMessageID = replyToID
+	msg.ReplyMarkup = keyboard
+
+	var sentMsg tgbotapi.Message
+	err := t.retry.send("send reply keyboard", func() (err error) {
+		t.limit.Wait(chatID)
+		sentMsg, err = t.tg.Send(msg)
+
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("send reply keyboard: %w", err)
 	}
 
 	return sentMsg.MessageID, nil
 }
 
 func (t *TelegramBotAPI) PinMessage(chatID int64, messageID int) error {
-	_, err := t.tg.PinChatMessage(tgbotapi.PinChatMessageConfig{
-		ChatID:              chatID,
-		MessageID:           messageID,
-		DisableNotification: true,
+	err := t.retry.do("pin message", func() error {
+		t.limit.Wait(chatID)
+		_, err := t.tg.PinChatMessage(tgbotapi.PinChatMessageConfig{
+			ChatID:              chatID,
+			MessageID:           messageID,
+			DisableNotification: true,
+		})
+
+		return err
 	})
+	if err != nil {
+		return fmt.Errorf("pin message: %w", err)
+	}
 
-	return err
+	return nil
 }
 
 func (t *TelegramBotAPI) GetChatPinnedMessageID(chatID int64) (int, error) {
-	chat, err := t.tg.GetChat(tgbotapi.ChatConfig{
-		ChatID: chatID,
+	var chat tgbotapi.Chat
+	err := t.retry.do("get chat", func() (err error) {
+		chat, err = t.tg.GetChat(tgbotapi.ChatConfig{
+			ChatID: chatID,
+		})
+
+		return err
 	})
 	if err != nil {
 		return 0, fmt.Errorf("получение инфы о чате: %w", err)
@@ -94,3 +213,36 @@ func (t *TelegramBotAPI) GetChatPinnedMessageID(chatID int64) (int, error) {
 
 	return chat.PinnedMessage.MessageID, nil
 }
+
+func (t *TelegramBotAPI) DeleteMessage(chatID int64, messageID int) error {
+	err := t.retry.do("delete message", func() error {
+		t.limit.Wait(chatID)
+		_, err := t.tg.DeleteMessage(tgbotapi.NewDeleteMessage(chatID, messageID))
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete message: %w", err)
+	}
+
+	return nil
+}
+
+// AnswerCallbackQuery убирает часики на нажатой кнопке, text покажется всплывашкой, если не пустой
+func (t *TelegramBotAPI) AnswerCallbackQuery(callbackID string, text string) error {
+	// на нажатие кнопки отвечать надо сразу, повторять нет смысла
+	if _, err := t.tg.AnswerCallbackQuery(tgbotapi.NewCallback(callbackID, text)); err != nil {
+		return fmt.Errorf("answer callback query: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+func (t *TelegramBotAPI) AnswerInlineQuery(inline tgbotapi.InlineConfig) error {
+	// инлайн запрос живет недолго, повторять нет смысла
+	if _, err := t.tg.AnswerInlineQuery(inline); err != nil {
+		return fmt.Errorf("answer inline query: %w", wrapError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Ошибки Bot API по видам, проверять через errors.Is
+var (
+	// ErrBadRequest кривой запрос, повторять бесполезно
+	ErrBadRequest = errors.New("bad request")
+	// ErrNotFound нет сообщения, чата или файла, обычно тоже приходит как Bad Request
+	ErrNotFound = errors.New("not found")
+	// ErrNotModified новый текст или подпись совпадают со старыми, сообщение уже такое, как надо
+	ErrNotModified = errors.New("not modified")
+	// ErrForbidden бота выгнали из канала или заблокировали
+	ErrForbidden = errors.New("forbidden")
+	// ErrFloodWait слишком много запросов, телега просит подождать retry_after
+	ErrFloodWait = errors.New("flood wait")
+)
+
+// Error ответ Bot API с ошибкой
+type Error struct {
+	Code        int
+	Description string
+	// RetryAfter сколько ждать перед повтором, только для ErrFloodWait
+	RetryAfter time.Duration
+	kind       error
+}
+
+func (e *Error) Error() string {
+	return e.Description
+}
+
+// Is not found приходит с кодом 400, поэтому такая ошибка одновременно и ErrNotFound, и ErrBadRequest
+func (e *Error) Is(target error) bool {
+	if target == e.kind {
+		return true
+	}
+
+	return target == ErrBadRequest && e.Code == http.StatusBadRequest
+}
+
+// temporary ошибку можно повторить: flood wait или телега прилегла
+func (e *Error) temporary() bool {
+	return e.kind == ErrFloodWait || e.Code >= http.StatusInternalServerError
+}
+
+// wrapError превратит ошибку tgbotapi в *Error, остальные (сеть, json) вернет как есть
+func wrapError(err error) error {
+	var tgErr tgbotapi.Error
+	if !errors.As(err, &tgErr) {
+		return err
+	}
+
+	apiErr := &Error{
+		Code:        tgErr.Code,
+		Description: tgErr.Message,
+		RetryAfter:  time.Duration(tgErr.RetryAfter) * time.Second,
+	}
+
+	switch {
+	case tgErr.Code == http.StatusTooManyRequests:
+		apiErr.kind = ErrFloodWait
+	case tgErr.Code == http.StatusForbidden:
+		apiErr.kind = ErrForbidden
+	case tgErr.Code == http.StatusNotFound || strings.Contains(strings.ToLower(tgErr.Message), "not found"):
+		apiErr.kind = ErrNotFound
+	case strings.Contains(strings.ToLower(tgErr.Message), "message is not modified"):
+		apiErr.kind = ErrNotModified
+	case tgErr.Code == http.StatusBadRequest:
+		apiErr.kind = ErrBadRequest
+	default:
+		apiErr.kind = fmt.Errorf("telegram error %d", tgErr.Code)
+	}
+
+	return apiErr
+}
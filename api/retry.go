@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryAttempts = 5
+	defaultRetryBase     = time.Second
+	defaultRetryMax      = 30 * time.Second
+)
+
+// retrier повторяет запросы с экспоненциальной задержкой. Для flood wait ждет столько,
+// сколько сказала телега в retry_after
+type retrier struct {
+	attempts int
+	base     time.Duration
+	max      time.Duration
+	sleep    func(time.Duration)
+}
+
+func newRetrier() *retrier {
+	return &retrier{
+		attempts: defaultRetryAttempts,
+		base:     defaultRetryBase,
+		max:      defaultRetryMax,
+		sleep:    time.Sleep,
+	}
+}
+
+// do выполнит fn, повторяя временные ошибки. Ошибки Bot API вернет как *Error
+func (r *retrier) do(name string, fn func() error) error {
+	return r.run(name, true, fn)
+}
+
+// send как do, но для отправки новых сообщений: повтор запроса, который дошел до телеги, пришлет дубль.
+// Сетевые ошибки повторяются, только если запрос точно не ушел, а ответы телеги - только flood wait и 5xx
+func (r *retrier) send(name string, fn func() error) error {
+	return r.run(name, false, fn)
+}
+
+// run idempotent повторять любые сетевые ошибки
+func (r *retrier) run(name string, idempotent bool, fn func() error) error {
+	delay := r.base
+
+	for attempt := 1; ; attempt++ {
+		err := wrapError(fn())
+		if err == nil {
+			return nil
+		}
+
+		wait, ok := r.retryDelay(err, delay, idempotent)
+		if !ok || attempt >= r.attempts {
+			return err
+		}
+
+		log.Printf("%s: %s, попытка %d из %d через %s\n", name, err, attempt+1, r.attempts, wait)
+		r.sleep(wait)
+
+		delay *= 2
+		if delay > r.max {
+			delay = r.max
+		}
+	}
+}
+
+// retryDelay сколько ждать перед повтором, false если повторять бесполезно или опасно
+func (r *retrier) retryDelay(err error, delay time.Duration, idempotent bool) (time.Duration, bool) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// сеть, таймауты, кривой ответ
+		return delay, idempotent || notSent(err)
+	}
+
+	if !apiErr.temporary() {
+		return 0, false
+	}
+
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+
+	return delay, true
+}
+
+// notSent ошибка случилась до того, как запрос ушел: не нашли адрес или не подключились.
+// Таймаут или обрыв после подключения сюда не относятся, телега могла успеть выполнить запрос
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
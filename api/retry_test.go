@@ -0,0 +1,235 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		is     []error
+		isNot  []error
+		retry  time.Duration
+		apiErr bool
+	}{
+		{
+			"message to edit not found",
+			tgbotapi.Error{Code: 400, Message: "Bad Request: message to edit not found"},
+			[]error{ErrNotFound, ErrBadRequest},
+			[]error{ErrForbidden, ErrFloodWait},
+			0,
+			true,
+		},
+		{
+			"bad request",
+			tgbotapi.Error{Code: 400, Message: "Bad Request: message text is empty"},
+			[]error{ErrBadRequest},
+			[]error{ErrNotFound},
+			0,
+			true,
+		},
+		{
+			"message is not modified",
+			tgbotapi.Error{Code: 400, Message: "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"},
+			[]error{ErrNotModified, ErrBadRequest},
+			[]error{ErrNotFound},
+			0,
+			true,
+		},
+		{
+			"forbidden",
+			tgbotapi.Error{Code: 403, Message: "Forbidden: bot was kicked from the channel chat"},
+			[]error{ErrForbidden},
+			[]error{ErrBadRequest},
+			0,
+			true,
+		},
+		{
+			"flood wait",
+			tgbotapi.Error{Code: 429, Message: "Too Many Requests: retry after 7", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 7}},
+			[]error{ErrFloodWait},
+			[]error{ErrBadRequest},
+			7 * time.Second,
+			true,
+		},
+		{
+			"wrapped not found",
+			fmt.Errorf("get chat: %w", tgbotapi.Error{Code: 400, Message: "Bad Request: chat not found"}),
+			[]error{ErrNotFound},
+			nil,
+			0,
+			true,
+		},
+		{
+			"network error",
+			errors.New("connection reset by peer"),
+			nil,
+			[]error{ErrNotFound, ErrBadRequest, ErrForbidden, ErrFloodWait},
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := wrapError(tt.err)
+			for _, target := range tt.is {
+				assert.True(t, errors.Is(err, target), "should be %s", target)
+			}
+			for _, target := range tt.isNot {
+				assert.False(t, errors.Is(err, target), "should not be %s", target)
+			}
+
+			var apiErr *Error
+			assert.Equal(t, tt.apiErr, errors.As(err, &apiErr))
+			if tt.apiErr {
+				assert.Equal(t, tt.retry, apiErr.RetryAfter)
+			}
+		})
+	}
+}
+
+func TestRetrier_do(t *testing.T) {
+	t.Parallel()
+
+	floodWait := tgbotapi.Error{Code: 429, Message: "Too Many Requests: retry after 7", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 7}}
+	serverErr := tgbotapi.Error{Code: 502, Message: "Bad Gateway"}
+	notFound := tgbotapi.Error{Code: 400, Message: "Bad Request: message to edit not found"}
+	network := errors.New("connection reset by peer")
+	// ответ не дочитали, сообщение могло уже уйти
+	readTimeout := &url.Error{Op: "Post", URL: "https://api.telegram.org", Err: errors.New("net/http: timeout awaiting response headers")}
+	refused := &url.Error{
+		Op:  "Post",
+		URL: "https://api.telegram.org",
+		Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+	}
+
+	tests := []struct {
+		name string
+		// send отправка нового сообщения, а не идемпотентный запрос
+		send bool
+		// errs ошибки попыток по порядку, после них успех
+		errs      []error
+		wantSleep []time.Duration
+		wantErr   error
+	}{
+		{
+			"should not retry success",
+			false,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"should wait retry_after on flood wait",
+			false,
+			[]error{floodWait},
+			[]time.Duration{7 * time.Second},
+			nil,
+		},
+		{
+			"should back off exponentially up to max",
+			false,
+			[]error{network, serverErr, network, serverErr},
+			[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+			nil,
+		},
+		{
+			"should not retry bad request",
+			false,
+			[]error{notFound},
+			nil,
+			ErrNotFound,
+		},
+		{
+			"should give up after attempts",
+			false,
+			[]error{network, network, network, network, network, network},
+			[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+			network,
+		},
+		{
+			"should retry send on server error",
+			true,
+			[]error{serverErr},
+			[]time.Duration{time.Second},
+			nil,
+		},
+		{
+			"should retry send if connection was refused",
+			true,
+			[]error{refused},
+			[]time.Duration{time.Second},
+			nil,
+		},
+		{
+			"should not retry send after response read error",
+			true,
+			[]error{readTimeout},
+			nil,
+			readTimeout,
+		},
+		{
+			"should retry idempotent request after response read error",
+			false,
+			[]error{readTimeout},
+			[]time.Duration{time.Second},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var slept []time.Duration
+			r := &retrier{
+				attempts: 5,
+				base:     time.Second,
+				max:      5 * time.Second,
+				sleep: func(d time.Duration) {
+					slept = append(slept, d)
+				},
+			}
+
+			calls := 0
+			fn := func() error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+
+				return nil
+			}
+
+			var err error
+			if tt.send {
+				err = r.send("test", fn)
+			} else {
+				err = r.do("test", fn)
+			}
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			}
+			assert.Equal(t, tt.wantSleep, slept)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package archive
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+const (
+	// FormatJSON весь архив одним json документом
+	FormatJSON = "json"
+	// FormatJSONL по записи на строку, удобно для diff и grep
+	FormatJSONL = "jsonl"
+	// FormatCSV для редактирования тегов в таблице
+	FormatCSV = "csv"
+)
+
+// Archive все содержимое хранилища, которое имеет смысл переносить между установками
+type Archive struct {
+	Tags        []string
+	TagsAliases map[string]string
+	Animations  []*storage.SentAnimation
+}
+
+// FromStorage собирает архив из хранилища, гифки упорядочены по id сообщения в канале
+func FromStorage(store storage.MetaTx) *Archive {
+	a := &Archive{
+		Tags:        store.GetTags(),
+		TagsAliases: store.GetTagsAliases(),
+	}
+
+	for _, anim := range store.GetSentAnimations() {
+		a.Animations = append(a.Animations, anim)
+	}
+	sort.Slice(a.Animations, func(i, j int) bool {
+		if a.Animations[i].MessageID == a.Animations[j].MessageID {
+			return a.Animations[i].FileID < a.Animations[j].FileID
+		}
+
+		return a.Animations[i].MessageID < a.Animations[j].MessageID
+	})
+
+	return a
+}
+
+// FormatFromPath определяет формат по расширению файла
+func FormatFromPath(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return FormatJSON, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unknown archive format '%s', use --format", ext)
+	}
+}
@@ -0,0 +1,211 @@
+package archive
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func testArchive() *Archive {
+	return &Archive{
+		Tags:        []string{"#cat", "#dog"},
+		TagsAliases: map[string]string{"#kot": "#cat"},
+		Animations: []*storage.SentAnimation{
+			{
+				MessageID:       1,
+				FileID:          "file_1",
+				Tags:            []string{"#cat"},
+				Description:     "very, \"funny\" cat",
+				ChannelMessages: map[int64]int{-200: 7, -300: 8},
+			},
+			{MessageID: 2, FileID: "file_2", Tags: []string{"#dog"}},
+		},
+	}
+}
+
+func testAnimation1() *storage.SentAnimation {
+	return testArchive().Animations[0]
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{FormatJSON, FormatJSONL, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, testArchive(), format); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			got, err := Decode(&buf, format)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			assert.Equal(t, testArchive(), got)
+		})
+	}
+}
+
+func TestEncodeDecode_descriptionWithHashtag(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{FormatJSON, FormatJSONL, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			a := &Archive{
+				TagsAliases: map[string]string{},
+				Animations: []*storage.SentAnimation{
+					{MessageID: 1, FileID: "file_1", Tags: []string{"#cat"}, Description: "кот и #word\nвторая строка"},
+				},
+			}
+
+			var buf bytes.Buffer
+			if err := Encode(&buf, a, format); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			got, err := Decode(&buf, format)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			assert.Equal(t, a.Animations, got.Animations)
+		})
+	}
+}
+
+func TestDecode_oldCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"without channel messages", "kind,key,message_id,value\nanimation,file_1,1,#cat funny\n"},
+		{"without description", "kind,key,message_id,value,channel_messages\nanimation,file_1,1,#cat funny,\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Decode(strings.NewReader(tt.csv), FormatCSV)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			assert.Equal(t, []*storage.SentAnimation{
+				{MessageID: 1, FileID: "file_1", Tags: []string{"#cat"}, Description: "funny"},
+			}, got.Animations)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		opts           ImportOptions
+		wantReport     *ImportReport
+		wantAnimations map[string]*storage.SentAnimation
+		wantAliases    map[string]string
+		wantTags       []string
+	}{
+		{
+			"merge keeps existing on conflict",
+			ImportOptions{Mode: ModeMerge},
+			&ImportReport{
+				Unchanged: 1,
+				Conflicts: []Conflict{
+					{Kind: kindAnimation, Key: "file_2", Existing: "#20 #puppy [-300:5]", Incoming: "#2 #dog [-300:5]"},
+					{Kind: kindAlias, Key: "#kot", Existing: "#kitten", Incoming: "#cat"},
+				},
+			},
+			map[string]*storage.SentAnimation{
+				"file_1": testAnimation1(),
+				"file_2": {MessageID: 20, FileID: "file_2", Tags: []string{"#puppy"}, ChannelMessages: map[int64]int{-300: 5}},
+				"file_3": {MessageID: 30, FileID: "file_3", Tags: []string{"#bird"}},
+			},
+			map[string]string{"#kot": "#kitten"},
+			[]string{"#bird", "#cat", "#dog", "#kitten", "#puppy"},
+		},
+		{
+			"merge with overwrite",
+			ImportOptions{Mode: ModeMerge, Overwrite: true},
+			&ImportReport{
+				Updated:   1,
+				Unchanged: 1,
+				Conflicts: []Conflict{
+					{Kind: kindAnimation, Key: "file_2", Existing: "#20 #puppy [-300:5]", Incoming: "#2 #dog [-300:5]"},
+					{Kind: kindAlias, Key: "#kot", Existing: "#kitten", Incoming: "#cat"},
+				},
+			},
+			map[string]*storage.SentAnimation{
+				"file_1": testAnimation1(),
+				// в архиве нет сообщений в остальных каналах, остаются те, что были
+				"file_2": {MessageID: 2, FileID: "file_2", Tags: []string{"#dog"}, ChannelMessages: map[int64]int{-300: 5}},
+				"file_3": {MessageID: 30, FileID: "file_3", Tags: []string{"#bird"}},
+			},
+			map[string]string{"#kot": "#cat"},
+			[]string{"#bird", "#cat", "#dog", "#kitten", "#puppy"},
+		},
+		{
+			"replace",
+			ImportOptions{Mode: ModeReplace},
+			&ImportReport{
+				Added:   1,
+				Updated: 1,
+				Removed: 1,
+			},
+			map[string]*storage.SentAnimation{
+				"file_1": testAnimation1(),
+				// в архиве нет сообщений в остальных каналах, остаются те, что были
+				"file_2": {MessageID: 2, FileID: "file_2", Tags: []string{"#dog"}, ChannelMessages: map[int64]int{-300: 5}},
+			},
+			map[string]string{"#kot": "#cat"},
+			[]string{"#cat", "#dog"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := storage.NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+			if err != nil {
+				t.Fatalf("open storage: %s", err)
+			}
+			defer store.Close()
+
+			store.SetTags([]string{"#bird", "#kitten", "#puppy"})
+			store.SetTagsAliases(map[string]string{"#kot": "#kitten"})
+			store.AddSentAnimations(map[string]*storage.SentAnimation{
+				"file_2": {MessageID: 20, FileID: "file_2", Tags: []string{"#puppy"}, ChannelMessages: map[int64]int{-300: 5}},
+				"file_3": {MessageID: 30, FileID: "file_3", Tags: []string{"#bird"}},
+			})
+			if tt.opts.Mode == ModeMerge {
+				store.AddSentAnimations(map[string]*storage.SentAnimation{
+					"file_1": testAnimation1(),
+				})
+			}
+
+			var report *ImportReport
+			err = store.Update(func(tx storage.MetaTx) error {
+				report, err = Import(tx, testArchive(), tt.opts)
+
+				return err
+			})
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+
+			assert.Equal(t, tt.wantReport, report)
+			assert.Equal(t, tt.wantAnimations, store.GetSentAnimations())
+			assert.Equal(t, tt.wantAliases, store.GetTagsAliases())
+			assert.Equal(t, tt.wantTags, store.GetTags())
+		})
+	}
+}
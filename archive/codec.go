@@ -0,0 +1,327 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+const (
+	kindTag       = "tag"
+	kindAlias     = "alias"
+	kindAnimation = "animation"
+)
+
+var csvHeader = []string{"kind", "key", "message_id", "value", "channel_messages", "description"}
+
+const (
+	// csvOldFields в самых старых csv не было колонок channel_messages и description
+	csvOldFields = 4
+	// csvNoDescriptionFields в старых csv не было колонки description, оно лежало в value вместе с тегами
+	csvNoDescriptionFields = 5
+)
+
+// jsonlRecord строка jsonl, Kind определяет, какие поля заполнены
+type jsonlRecord struct {
+	Kind      string
+	Tag       string   `json:",omitempty"`
+	Alias     string   `json:",omitempty"`
+	FileID    string   `json:",omitempty"`
+	MessageID int      `json:",omitempty"`
+	Tags      []string `json:",omitempty"`
+	// Description описание гифки, в старых архивах лежит в Tags
+	Description string `json:",omitempty"`
+	// ChannelMessages сообщения гифки в остальных каналах, в старых архивах их нет
+	ChannelMessages map[int64]int `json:",omitempty"`
+}
+
+// Encode пишет архив в указанном формате
+func Encode(w io.Writer, a *Archive, format string) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(a)
+	case FormatJSONL:
+		return encodeJSONL(w, a)
+	case FormatCSV:
+		return encodeCSV(w, a)
+	}
+
+	return fmt.Errorf("unknown archive format '%s'", format)
+}
+
+// Decode читает архив в указанном формате
+func Decode(r io.Reader, format string) (*Archive, error) {
+	switch format {
+	case FormatJSON:
+		a := &Archive{}
+		if err := json.NewDecoder(r).Decode(a); err != nil {
+			return nil, fmt.Errorf("parse json: %w", err)
+		}
+
+		return a, nil
+	case FormatJSONL:
+		return decodeJSONL(r)
+	case FormatCSV:
+		return decodeCSV(r)
+	}
+
+	return nil, fmt.Errorf("unknown archive format '%s'", format)
+}
+
+func encodeJSONL(w io.Writer, a *Archive) error {
+	enc := json.NewEncoder(w)
+
+	for _, tag := range a.Tags {
+		if err := enc.Encode(jsonlRecord{Kind: kindTag, Tag: tag}); err != nil {
+			return err
+		}
+	}
+
+	for _, alias := range sortedKeys(a.TagsAliases) {
+		if err := enc.Encode(jsonlRecord{Kind: kindAlias, Alias: alias, Tag: a.TagsAliases[alias]}); err != nil {
+			return err
+		}
+	}
+
+	for _, anim := range a.Animations {
+		err := enc.Encode(jsonlRecord{
+			Kind:            kindAnimation,
+			FileID:          anim.FileID,
+			MessageID:       anim.MessageID,
+			Tags:            anim.Tags,
+			Description:     anim.Description,
+			ChannelMessages: anim.ChannelMessages,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeJSONL(r io.Reader) (*Archive, error) {
+	a := &Archive{TagsAliases: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var rec jsonlRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch rec.Kind {
+		case kindTag:
+			a.Tags = append(a.Tags, rec.Tag)
+		case kindAlias:
+			a.TagsAliases[rec.Alias] = rec.Tag
+		case kindAnimation:
+			if rec.FileID == "" {
+				return nil, fmt.Errorf("line %d: empty FileID", line)
+			}
+			a.Animations = append(a.Animations, &storage.SentAnimation{
+				MessageID:       rec.MessageID,
+				FileID:          rec.FileID,
+				Tags:            rec.Tags,
+				Description:     rec.Description,
+				ChannelMessages: rec.ChannelMessages,
+			})
+		default:
+			return nil, fmt.Errorf("line %d: unknown kind '%s'", line, rec.Kind)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read jsonl: %w", err)
+	}
+
+	return a, nil
+}
+
+// encodeCSV пишет строки kind,key,message_id,value,channel_messages,description. У гифок value это теги через пробел,
+// channel_messages сообщения в остальных каналах "канал:сообщение" через пробел, а description описание как есть.
+// У алиасов value - тег, на который указывает алиас
+func encodeCSV(w io.Writer, a *Archive) error {
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{csvHeader}
+	for _, tag := range a.Tags {
+		rows = append(rows, []string{kindTag, tag, "", "", "", ""})
+	}
+	for _, alias := range sortedKeys(a.TagsAliases) {
+		rows = append(rows, []string{kindAlias, alias, "", a.TagsAliases[alias], "", ""})
+	}
+	for _, anim := range a.Animations {
+		rows = append(rows, []string{
+			kindAnimation,
+			anim.FileID,
+			strconv.Itoa(anim.MessageID),
+			strings.Join(anim.Tags, " "),
+			formatChannelMessages(anim.ChannelMessages),
+			anim.Description,
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	return nil
+}
+
+func decodeCSV(r io.Reader) (*Archive, error) {
+	cr := csv.NewReader(r)
+	// количество колонок проверяется ниже, старые архивы без channel_messages и description тоже читаются
+	cr.FieldsPerRecord = -1
+
+	a := &Archive{TagsAliases: make(map[string]string)}
+	line := 0
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		line++
+
+		if len(row) != len(csvHeader) && len(row) != csvNoDescriptionFields && len(row) != csvOldFields {
+			return nil, fmt.Errorf("line %d: wrong number of fields %d", line, len(row))
+		}
+
+		// заголовок может быть, а может и не быть, если таблицу собирали руками
+		if line == 1 && row[0] == csvHeader[0] {
+			continue
+		}
+
+		kind, key, value := strings.TrimSpace(row[0]), strings.TrimSpace(row[1]), strings.TrimSpace(row[3])
+
+		switch kind {
+		case kindTag:
+			a.Tags = append(a.Tags, key)
+		case kindAlias:
+			a.TagsAliases[key] = value
+		case kindAnimation:
+			if key == "" {
+				return nil, fmt.Errorf("line %d: empty file id", line)
+			}
+
+			anim := &storage.SentAnimation{FileID: key}
+			if len(row) == len(csvHeader) {
+				// описание в своей колонке, в нем могут быть и #слова, и переносы строк
+				anim.Tags = strings.Fields(value)
+				anim.Description = row[5]
+			} else {
+				anim.Tags, anim.Description = splitCaption(value)
+			}
+			if id := strings.TrimSpace(row[2]); id != "" {
+				if anim.MessageID, err = strconv.Atoi(id); err != nil {
+					return nil, fmt.Errorf("line %d: message id: %w", line, err)
+				}
+			}
+			if len(row) > csvOldFields {
+				if anim.ChannelMessages, err = parseChannelMessages(row[4]); err != nil {
+					return nil, fmt.Errorf("line %d: channel messages: %w", line, err)
+				}
+			}
+			a.Animations = append(a.Animations, anim)
+		default:
+			return nil, fmt.Errorf("line %d: unknown kind '%s'", line, kind)
+		}
+	}
+
+	return a, nil
+}
+
+// splitCaption разбивает "#tag1 #tag2 some description" из старых csv обратно на теги и описание
+func splitCaption(caption string) ([]string, string) {
+	var tags []string
+	var desc []string
+
+	for _, word := range strings.Fields(caption) {
+		if strings.HasPrefix(word, "#") {
+			tags = append(tags, word)
+
+			continue
+		}
+		desc = append(desc, word)
+	}
+
+	return tags, strings.Join(desc, " ")
+}
+
+// formatChannelMessages "-200:5 -300:7", каналы по порядку
+func formatChannelMessages(messages map[int64]int) string {
+	chatIDs := make([]int64, 0, len(messages))
+	for chatID := range messages {
+		chatIDs = append(chatIDs, chatID)
+	}
+	sort.Slice(chatIDs, func(i, j int) bool {
+		return chatIDs[i] < chatIDs[j]
+	})
+
+	pairs := make([]string, 0, len(chatIDs))
+	for _, chatID := range chatIDs {
+		pairs = append(pairs, fmt.Sprintf("%d:%d", chatID, messages[chatID]))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// parseChannelMessages обратно к formatChannelMessages, пустая строка - nil
+func parseChannelMessages(value string) (map[int64]int, error) {
+	var messages map[int64]int
+
+	for _, pair := range strings.Fields(value) {
+		i := strings.LastIndex(pair, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("'%s' should be channel:message", pair)
+		}
+
+		chatID, err := strconv.ParseInt(pair[:i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("channel id '%s': %w", pair, err)
+		}
+		messageID, err := strconv.Atoi(pair[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("message id '%s': %w", pair, err)
+		}
+
+		if messages == nil {
+			messages = make(map[int64]int)
+		}
+		messages[chatID] = messageID
+	}
+
+	return messages, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
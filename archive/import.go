@@ -0,0 +1,210 @@
+package archive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// Mode как импорт обходится с тем, что уже есть в хранилище
+type Mode string
+
+const (
+	// ModeMerge добавляет новое к существующему, расхождения попадают в конфликты
+	ModeMerge Mode = "merge"
+	// ModeReplace хранилище становится точной копией архива
+	ModeReplace Mode = "replace"
+)
+
+// ImportOptions настройки импорта
+type ImportOptions struct {
+	Mode Mode
+	// Overwrite в режиме merge при конфликте взять значение из архива, иначе оставить текущее
+	Overwrite bool
+}
+
+// Conflict гифка или алиас, которые есть и в хранилище, и в архиве, но отличаются
+type Conflict struct {
+	Kind     string
+	Key      string
+	Existing string
+	Incoming string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s %s: '%s' => '%s'", c.Kind, c.Key, c.Existing, c.Incoming)
+}
+
+// ImportReport итог импорта
+type ImportReport struct {
+	Added     int
+	Updated   int
+	Unchanged int
+	Removed   int
+	Conflicts []Conflict
+}
+
+// Import загружает архив в хранилище. Вызывать внутри storage.MetaStorage.Update,
+// чтобы при ошибке ничего не изменилось
+func Import(tx storage.MetaTx, a *Archive, opts ImportOptions) (*ImportReport, error) {
+	switch opts.Mode {
+	case ModeMerge, ModeReplace:
+	default:
+		return nil, fmt.Errorf("unknown import mode '%s'", opts.Mode)
+	}
+
+	report := &ImportReport{}
+	existing := tx.GetSentAnimations()
+	changed := make(map[string]*storage.SentAnimation)
+	incomingIDs := make(map[string]bool, len(a.Animations))
+
+	for _, anim := range a.Animations {
+		incomingIDs[anim.FileID] = true
+
+		old, ok := existing[anim.FileID]
+		if ok && anim.ChannelMessages == nil && old.ChannelMessages != nil {
+			// в старых архивах нет сообщений в остальных каналах, сохраним те, что уже есть
+			merged := *anim
+			merged.ChannelMessages = make(map[int64]int, len(old.ChannelMessages))
+			for chatID, messageID := range old.ChannelMessages {
+				merged.ChannelMessages[chatID] = messageID
+			}
+			anim = &merged
+		}
+
+		switch {
+		case !ok:
+			report.Added++
+			changed[anim.FileID] = anim
+		case animationsEqual(old, anim):
+			report.Unchanged++
+		case opts.Mode == ModeReplace:
+			report.Updated++
+			changed[anim.FileID] = anim
+		default:
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Kind:     kindAnimation,
+				Key:      anim.FileID,
+				Existing: describeAnimation(old),
+				Incoming: describeAnimation(anim),
+			})
+			if opts.Overwrite {
+				report.Updated++
+				changed[anim.FileID] = anim
+			}
+		}
+	}
+
+	if len(changed) > 0 {
+		tx.AddSentAnimations(changed)
+	}
+
+	if opts.Mode == ModeReplace {
+		var removed []string
+		for id := range existing {
+			if !incomingIDs[id] {
+				removed = append(removed, id)
+			}
+		}
+		if len(removed) > 0 {
+			sort.Strings(removed)
+			tx.DeleteSentAnimations(removed...)
+			report.Removed = len(removed)
+		}
+	}
+
+	tx.SetTagsAliases(importAliases(tx.GetTagsAliases(), a.TagsAliases, opts, report))
+	tx.SetTags(importTags(tx, a, opts))
+
+	return report, nil
+}
+
+func importAliases(existing, incoming map[string]string, opts ImportOptions, report *ImportReport) map[string]string {
+	if opts.Mode == ModeReplace || existing == nil {
+		existing = make(map[string]string)
+	}
+
+	for alias, tag := range incoming {
+		old, ok := existing[alias]
+		if ok && old != tag {
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Kind:     kindAlias,
+				Key:      alias,
+				Existing: old,
+				Incoming: tag,
+			})
+			if !opts.Overwrite {
+				continue
+			}
+		}
+		existing[alias] = tag
+	}
+
+	return existing
+}
+
+// importTags список тегов из архива плюс теги всех гифок, чтобы список не разошелся с гифками
+func importTags(tx storage.MetaTx, a *Archive, opts ImportOptions) []string {
+	unique := make(map[string]bool)
+	if opts.Mode == ModeMerge {
+		for _, tag := range tx.GetTags() {
+			unique[tag] = true
+		}
+	}
+	for _, tag := range a.Tags {
+		unique[tag] = true
+	}
+	for _, anim := range tx.GetSentAnimations() {
+		for _, tag := range anim.Tags {
+			if strings.HasPrefix(tag, "#") {
+				unique[tag] = true
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(unique))
+	for tag := range unique {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+func animationsEqual(a, b *storage.SentAnimation) bool {
+	if a.MessageID != b.MessageID || a.Description != b.Description || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+
+	for i := range a.Tags {
+		if a.Tags[i] != b.Tags[i] {
+			return false
+		}
+	}
+
+	if len(a.ChannelMessages) != len(b.ChannelMessages) {
+		return false
+	}
+	for chatID, messageID := range a.ChannelMessages {
+		if id, ok := b.ChannelMessages[chatID]; !ok || id != messageID {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeAnimation(anim *storage.SentAnimation) string {
+	caption := strings.Join(anim.Tags, " ")
+	if anim.Description != "" {
+		caption += " " + anim.Description
+	}
+
+	if len(anim.ChannelMessages) > 0 {
+		caption += " [" + formatChannelMessages(anim.ChannelMessages) + "]"
+	}
+
+	return fmt.Sprintf("#%d %s", anim.MessageID, caption)
+}
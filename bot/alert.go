@@ -1,29 +1,220 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/cyhalothrin/gifkoskladbot/config"
 )
 
+const (
+	defaultAlertDedupWindow  = 10 * time.Minute
+	defaultAlertMaxPerMinute = 10
+)
+
 type alerter interface {
 	Send(err error) error
 }
 
+// TgAlert шлет ошибки бота в админский чат. Одинаковые ошибки внутри окна склеиваются,
+// больше MaxPerMinute в минуту в телегу не уходит. Все, что не ушло в телегу, пишется в stderr и FallbackFile.
+// Окно и лимит живут в памяти процесса, поэтому склеиваются ошибки только внутри одного запуска:
+// в режиме poll это работает, а запуск без команды (по крону) каждый раз начинает заново
 type TgAlert struct {
 	api  telegramBotAPI
 	conf config.Config
+
+	mu          sync.Mutex
+	dedupWindow time.Duration
+	maxPerMin   int
+	// lastSent когда ошибка с таким текстом последний раз ушла в телегу
+	lastSent map[string]time.Time
+	// suppressed сколько раз ошибка повторилась с последней отправки
+	suppressed map[string]int
+	// sentTimes время отправок за последнюю минуту, для ограничения
+	sentTimes []time.Time
+	// fallbackMu запись в fallback и FallbackFile по одному алерту
+	fallbackMu sync.Mutex
+	fallback   io.Writer
+	now        func() time.Time
 }
 
 func NewTgAlert(cnf config.Config, api telegramBotAPI) *TgAlert {
+	dedupWindow := defaultAlertDedupWindow
+	if cnf.Alert.DedupWindowSeconds > 0 {
+		dedupWindow = time.Duration(cnf.Alert.DedupWindowSeconds) * time.Second
+	}
+
+	maxPerMin := defaultAlertMaxPerMinute
+	if cnf.Alert.MaxPerMinute > 0 {
+		maxPerMin = cnf.Alert.MaxPerMinute
+	}
+
 	return &TgAlert{
-		api:  api,
-		conf: cnf,
+		api:         api,
+		conf:        cnf,
+		dedupWindow: dedupWindow,
+		maxPerMin:   maxPerMin,
+		lastSent:    make(map[string]time.Time),
+		suppressed:  make(map[string]int),
+		fallback:    os.Stderr,
+		now:         time.Now,
 	}
 }
 
 func (t *TgAlert) Send(err error) error {
-	fmt.Println(err)
+	if err == nil {
+		return nil
+	}
+
+	now := t.now()
+	key := err.Error()
+
+	// решение принимается под блокировкой, а в телегу алерт уходит уже без нее,
+	// чтобы медленная телега не держала остальные алерты
+	t.mu.Lock()
+	if last, ok := t.lastSent[key]; ok && now.Sub(last) < t.dedupWindow {
+		t.suppressed[key]++
+		t.mu.Unlock()
+
+		return nil
+	}
+
+	repeated := t.suppressed[key]
+	text := formatAlert(err, repeated)
+
+	if t.conf.Alert.AdminChatID == 0 {
+		t.mu.Unlock()
+
+		return t.writeFallback(now, text, nil)
+	}
+
+	if !t.allow(now) {
+		t.mu.Unlock()
+
+		return t.writeFallback(now, text, errors.New("слишком много алертов"))
+	}
+
+	// отправка засчитывается сразу, чтобы такая же ошибка из соседней горутины не ушла второй раз
+	t.lastSent[key] = now
+	delete(t.suppressed, key)
+	t.sentTimes = append(t.sentTimes, now)
+	t.mu.Unlock()
+
+	if _, sendErr := t.api.SendMessage(t.conf.Alert.AdminChatID, text, ""); sendErr != nil {
+		t.mu.Lock()
+		// не ушло, следующая такая же ошибка попробует отправиться снова
+		if last, ok := t.lastSent[key]; ok && last.Equal(now) {
+			delete(t.lastSent, key)
+			t.suppressed[key] += repeated
+		}
+		t.mu.Unlock()
+
+		return t.writeFallback(now, text, sendErr)
+	}
+
+	return nil
+}
+
+// allow ограничение на количество отправок за последнюю минуту
+func (t *TgAlert) allow(now time.Time) bool {
+	recent := t.sentTimes[:0]
+	for _, sent := range t.sentTimes {
+		if now.Sub(sent) < time.Minute {
+			recent = append(recent, sent)
+		}
+	}
+	t.sentTimes = recent
+
+	return len(t.sentTimes) < t.maxPerMin
+}
+
+// writeFallback алерт не ушел в телегу по причине reason, nil - админский чат не указан
+func (t *TgAlert) writeFallback(now time.Time, text string, reason error) error {
+	t.fallbackMu.Lock()
+	defer t.fallbackMu.Unlock()
+
+	line := fmt.Sprintf("%s %s", now.Format(time.RFC3339), text)
+	if reason != nil {
+		line += fmt.Sprintf("\n(не отправлено в телегу: %s)", reason)
+	}
+	line += "\n"
+
+	if _, err := io.WriteString(t.fallback, line); err != nil {
+		return fmt.Errorf("write alert: %w", err)
+	}
+
+	if t.conf.Alert.FallbackFile == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(t.conf.Alert.FallbackFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return fmt.Errorf("open alerts file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("write alerts file: %w", err)
+	}
 
 	return nil
 }
+
+func formatAlert(err error, repeated int) string {
+	var b strings.Builder
+	b.WriteString("Ошибка: ")
+	b.WriteString(err.Error())
+
+	var ctxErr *alertContextError
+	if errors.As(err, &ctxErr) {
+		for _, field := range ctxErr.fields {
+			b.WriteString("\n")
+			b.WriteString(field)
+		}
+	}
+
+	if repeated > 0 {
+		fmt.Fprintf(&b, "\nповторов с прошлой отправки: %d", repeated)
+	}
+
+	return b.String()
+}
+
+// alertContextError ошибка с контекстом для алерта: какое обновление, какая гифка.
+// Текст ошибки не меняется, чтобы одинаковые ошибки из разных обновлений склеивались
+type alertContextError struct {
+	err    error
+	fields []string
+}
+
+func (e *alertContextError) Error() string {
+	return e.err.Error()
+}
+
+func (e *alertContextError) Unwrap() error {
+	return e.err
+}
+
+// withAlertContext добавит к ошибке строку контекста для алерта
+func withAlertContext(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	field := fmt.Sprintf(format, args...)
+
+	var ctxErr *alertContextError
+	if errors.As(err, &ctxErr) {
+		ctxErr.fields = append(ctxErr.fields, field)
+
+		return err
+	}
+
+	return &alertContextError{err: err, fields: []string{field}}
+}
@@ -0,0 +1,182 @@
+package bot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+func TestTgAlert_Send(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	type alert struct {
+		err error
+		// after сколько прошло от начала
+		after time.Duration
+	}
+	type args struct {
+		conf    config.Alert
+		sendErr error
+		alerts  []alert
+	}
+	type want struct {
+		sent     []string
+		fallback string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"should write to fallback without admin chat",
+			args{
+				alerts: []alert{{err: errors.New("boom")}},
+			},
+			want{fallback: "2021-03-01T10:00:00Z Ошибка: boom\n"},
+		},
+		{
+			"should send with context",
+			args{
+				conf: config.Alert{AdminChatID: 1},
+				alerts: []alert{
+					{err: withAlertContext(withAlertContext(errors.New("boom"), "update #5"), "fileID: file_1")},
+				},
+			},
+			want{sent: []string{"Ошибка: boom\nupdate #5\nfileID: file_1"}},
+		},
+		{
+			"should deduplicate same errors within window",
+			args{
+				conf: config.Alert{AdminChatID: 1, DedupWindowSeconds: 60},
+				alerts: []alert{
+					{err: errors.New("boom")},
+					{err: withAlertContext(errors.New("boom"), "update #6"), after: 10 * time.Second},
+					{err: errors.New("boom"), after: 20 * time.Second},
+					{err: errors.New("other"), after: 30 * time.Second},
+					{err: errors.New("boom"), after: 70 * time.Second},
+				},
+			},
+			want{sent: []string{
+				"Ошибка: boom",
+				"Ошибка: other",
+				"Ошибка: boom\nповторов с прошлой отправки: 2",
+			}},
+		},
+		{
+			"should rate limit bursts",
+			args{
+				conf: config.Alert{AdminChatID: 1, MaxPerMinute: 2},
+				alerts: []alert{
+					{err: errors.New("first")},
+					{err: errors.New("second"), after: time.Second},
+					{err: errors.New("third"), after: 2 * time.Second},
+					{err: errors.New("fourth"), after: 61 * time.Second},
+				},
+			},
+			want{
+				sent: []string{"Ошибка: first", "Ошибка: second", "Ошибка: fourth"},
+				fallback: "2021-03-01T10:00:02Z Ошибка: third\n" +
+					"(не отправлено в телегу: слишком много алертов)\n",
+			},
+		},
+		{
+			"should write to fallback when telegram fails",
+			args{
+				conf:    config.Alert{AdminChatID: 1},
+				sendErr: errors.New("Forbidden: bot was blocked by the user"),
+				alerts:  []alert{{err: errors.New("boom")}},
+			},
+			want{fallback: "2021-03-01T10:00:00Z Ошибка: boom\n" +
+				"(не отправлено в телегу: Forbidden: bot was blocked by the user)\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			var sent []string
+			api := NewTelegramBotAPIMock(mc)
+			if len(tt.want.sent) > 0 || tt.args.sendErr != nil {
+				api.SendMessageMock.Set(func(chatID int64, text string, parseMode string) (int, error) {
+					assert.Equal(t, tt.args.conf.AdminChatID, chatID)
+					if tt.args.sendErr != nil {
+						return 0, tt.args.sendErr
+					}
+					sent = append(sent, text)
+
+					return len(sent), nil
+				})
+			}
+
+			fallbackFile := filepath.Join(t.TempDir(), "alerts.log")
+			tt.args.conf.FallbackFile = fallbackFile
+
+			a := NewTgAlert(config.Config{Alert: tt.args.conf}, api)
+			fallback := &bytes.Buffer{}
+			a.fallback = fallback
+
+			for i, al := range tt.args.alerts {
+				now := start.Add(al.after)
+				a.now = func() time.Time { return now }
+
+				assert.NoError(t, a.Send(al.err), fmt.Sprintf("alert #%d", i))
+			}
+
+			assert.Equal(t, tt.want.sent, sent)
+			assert.Equal(t, tt.want.fallback, fallback.String())
+
+			content, _ := ioutil.ReadFile(fallbackFile)
+			assert.Equal(t, tt.want.fallback, string(content))
+		})
+	}
+}
+
+func TestTgAlert_Send_slowTelegram(t *testing.T) {
+	t.Parallel()
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	api := NewTelegramBotAPIMock(mc).SendMessageMock.Set(func(chatID int64, text string, parseMode string) (int, error) {
+		if text == "Ошибка: slow" {
+			close(entered)
+			<-release
+		}
+
+		return 1, nil
+	})
+
+	a := NewTgAlert(config.Config{Alert: config.Alert{AdminChatID: 1}}, api)
+
+	done := make(chan error)
+	go func() {
+		done <- a.Send(errors.New("slow"))
+	}()
+	<-entered
+
+	// пока первый алерт висит в телеге, остальные не ждут его
+	assert.NoError(t, a.Send(errors.New("other")))
+	assert.NoError(t, a.Send(errors.New("slow")), "same error should be deduplicated while sending")
+
+	close(release)
+	assert.NoError(t, <-done)
+	assert.Equal(t, 2, int(api.SendMessageAfterCounter()))
+}
@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// aliasRewriteFlag с ним /alias add заменит старый тег на новый у уже отправленных гифок
+const aliasRewriteFlag = "--rewrite"
+
+const aliasUsage = `/alias add #kot #cat - писать #kot вместо #cat
+/alias add #kot #cat --rewrite - то же, и заменить #kot на #cat у уже отправленных гифок
+/alias rm #kot - удалить алиас
+/alias list - все алиасы`
+
+// handleAliasCommand управление алиасами тегов из чата: /alias add|rm|list
+func (u *UpdatesHandler) handleAliasCommand(update tgbotapi.Update) (bool, error) {
+	message := update.Message
+	if message == nil || !message.IsCommand() {
+		return false, nil
+	}
+
+	args := strings.Fields(strings.ToLower(message.CommandArguments()))
+
+	var reply string
+	var err error
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		reply = u.aliasesList()
+	case len(args) == 2 && args[0] == "rm":
+		reply, err = u.removeAlias(args[1])
+	case len(args) == 3 && args[0] == "add":
+		reply, err = u.addAlias(args[1], args[2], false, message)
+	case len(args) == 4 && args[0] == "add" && args[3] == aliasRewriteFlag:
+		reply, err = u.addAlias(args[1], args[2], true, message)
+	default:
+		reply = aliasUsage
+	}
+
+	if err != nil {
+		reply = err.Error()
+	}
+
+	if _, sendErr := u.api.SendMessage(message.Chat.ID, reply, ""); sendErr != nil {
+		return true, fmt.Errorf("ответ на /alias: %w", sendErr)
+	}
+
+	return true, nil
+}
+
+// addAlias добавит алиас alias => tag. С rewrite у отправленных гифок alias заменится на tag,
+// а подписи в канале отредактируются при публикации
+func (u *UpdatesHandler) addAlias(alias, tag string, rewrite bool, message *tgbotapi.Message) (string, error) {
+	alias, ok := u.normalizer.Tag(alias)
+	if !ok {
+		return "", fmt.Errorf("пустой алиас")
+	}
+	tag, ok = u.normalizer.Tag(tag)
+	if !ok {
+		return "", fmt.Errorf("пустой тег")
+	}
+
+	// алиас на алиас не делаем, сразу указываем конечный тег
+	if target, ok := u.tagsAliases[tag]; ok {
+		tag = target
+	}
+
+	if alias == tag {
+		return "", fmt.Errorf("алиас %s указывает сам на себя", alias)
+	}
+
+	for other, target := range u.tagsAliases {
+		if target == alias {
+			return "", fmt.Errorf("%s уже тег для алиаса %s, сначала удали его", alias, other)
+		}
+	}
+
+	u.tagsAliases[alias] = tag
+	u.saveAliases()
+
+	log.Printf("Алиас %s => %s\n", alias, tag)
+
+	reply := fmt.Sprintf("Алиас %s => %s", alias, tag)
+
+	if !rewrite {
+		if count := len(u.animationsWithTag(alias)); count > 0 {
+			reply += fmt.Sprintf("\nУ %d гифок остался тег %s, заменить: /alias add %s %s %s",
+				count, alias, alias, tag, aliasRewriteFlag)
+		}
+
+		return reply, nil
+	}
+
+	rewritten := 0
+	for _, anim := range u.animationsWithTag(alias) {
+		tags, description := animationCaption(anim)
+		tags = replaceTag(tags, alias, tag)
+		if u.AddAnimationWithTags(anim.FileID, tags, description, message.From, message.Time()) {
+			rewritten++
+		}
+	}
+
+	if u.uniqueTags[alias] {
+		delete(u.uniqueTags, alias)
+		u.hasTagsListChanges = true
+	}
+
+	return reply + fmt.Sprintf("\nЗаменил у %d гифок", rewritten), nil
+}
+
+func (u *UpdatesHandler) removeAlias(alias string) (string, error) {
+	alias, _ = u.normalizer.Tag(alias)
+
+	tag, ok := u.tagsAliases[alias]
+	if !ok {
+		return "", fmt.Errorf("нет алиаса %s", alias)
+	}
+
+	delete(u.tagsAliases, alias)
+	u.saveAliases()
+
+	log.Printf("Удален алиас %s => %s\n", alias, tag)
+
+	return fmt.Sprintf("Удален алиас %s => %s", alias, tag), nil
+}
+
+func (u *UpdatesHandler) aliasesList() string {
+	if len(u.tagsAliases) == 0 {
+		return "Алиасов нет"
+	}
+
+	aliases := make([]string, 0, len(u.tagsAliases))
+	for alias := range u.tagsAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	lines := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		lines = append(lines, alias+" => "+u.tagsAliases[alias])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// saveAliases в хранилище отдаем копию, мапу алиасов дальше меняет обработчик
+func (u *UpdatesHandler) saveAliases() {
+	aliases := make(map[string]string, len(u.tagsAliases))
+	for alias, tag := range u.tagsAliases {
+		aliases[alias] = tag
+	}
+
+	u.storage.SetTagsAliases(aliases)
+}
+
+// animationsWithTag отправленные и ожидающие отправки гифки с тегом, по fileID
+func (u *UpdatesHandler) animationsWithTag(tag string) []*storage.SentAnimation {
+	var found []*storage.SentAnimation
+
+	for fileID, anim := range u.sentAnimations {
+		if pending, ok := u.animationsNewCaptions[fileID]; ok {
+			anim = pending
+		}
+		if containsTag(anim.Tags, tag) {
+			found = append(found, anim)
+		}
+	}
+	for fileID, anim := range u.animationsNewCaptions {
+		if _, ok := u.sentAnimations[fileID]; !ok && containsTag(anim.Tags, tag) {
+			found = append(found, anim)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].FileID < found[j].FileID
+	})
+
+	return found
+}
+
+// replaceTag заменит тег, не допуская повторов, если новый тег уже был
+func replaceTag(tags []string, oldTag, newTag string) []string {
+	replaced := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag == oldTag {
+			tag = newTag
+		}
+		if !containsTag(replaced, tag) {
+			replaced = append(replaced, tag)
+		}
+	}
+
+	return replaced
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
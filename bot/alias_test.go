@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func commandUpdate(userName, text string) tgbotapi.Update {
+	command := strings.Fields(text)[0]
+
+	return tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID: 1,
+		From:      &tgbotapi.User{ID: 42, UserName: userName},
+		Chat:      &tgbotapi.Chat{ID: 100},
+		Date:      1600000000,
+		Text:      text,
+		Entities:  &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(command)}},
+	}}
+}
+
+func TestUpdatesHandler_handleAliasCommand(t *testing.T) {
+	t.Parallel()
+
+	animations := func() map[string]*storage.SentAnimation {
+		return map[string]*storage.SentAnimation{
+			"file_1": {FileID: "file_1", MessageID: 1, Tags: []string{"#kot", "#funny"}},
+			"file_2": {FileID: "file_2", MessageID: 2, Tags: []string{"#kot", "#cat"}},
+			"file_3": {FileID: "file_3", MessageID: 3, Tags: []string{"#dog"}},
+		}
+	}
+
+	type args struct {
+		aliases map[string]string
+		update  tgbotapi.Update
+	}
+	type want struct {
+		ok      bool
+		reply   string
+		aliases map[string]string
+		// newCaptions теги гифок, поставленных в очередь на отправку
+		newCaptions map[string][]string
+		removedTag  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"should skip not command",
+			args{update: tgbotapi.Update{Message: &tgbotapi.Message{
+				From: &tgbotapi.User{UserName: "user"},
+				Text: "/alias list",
+			}}},
+			want{},
+		},
+		{
+			"should show usage",
+			args{update: commandUpdate("user", "/alias what")},
+			want{ok: true, reply: aliasUsage},
+		},
+		{
+			"should list aliases",
+			args{
+				aliases: map[string]string{"#kot": "#cat", "#dg": "#dog"},
+				update:  commandUpdate("user", "/alias list"),
+			},
+			want{ok: true, reply: "#dg => #dog\n#kot => #cat"},
+		},
+		{
+			"should list empty aliases",
+			args{update: commandUpdate("user", "/alias list")},
+			want{ok: true, reply: "Алиасов нет"},
+		},
+		{
+			"should add alias and suggest rewrite",
+			args{update: commandUpdate("user", "/alias add #Kot cat")},
+			want{
+				ok:      true,
+				reply:   "Алиас #kot => #cat\nУ 2 гифок остался тег #kot, заменить: /alias add #kot #cat --rewrite",
+				aliases: map[string]string{"#kot": "#cat"},
+			},
+		},
+		{
+			"should add alias to final tag",
+			args{
+				aliases: map[string]string{"#kotik": "#cat"},
+				update:  commandUpdate("user", "/alias add #kit #kotik"),
+			},
+			want{
+				ok:      true,
+				reply:   "Алиас #kit => #cat",
+				aliases: map[string]string{"#kotik": "#cat", "#kit": "#cat"},
+			},
+		},
+		{
+			"should add alias and rewrite gifs",
+			args{update: commandUpdate("user", "/alias add #kot #cat --rewrite")},
+			want{
+				ok:      true,
+				reply:   "Алиас #kot => #cat\nЗаменил у 2 гифок",
+				aliases: map[string]string{"#kot": "#cat"},
+				newCaptions: map[string][]string{
+					"file_1": {"#cat", "#funny"},
+					"file_2": {"#cat"},
+				},
+				removedTag: "#kot",
+			},
+		},
+		{
+			"should not add alias to itself",
+			args{
+				aliases: map[string]string{"#kotik": "#cat"},
+				update:  commandUpdate("user", "/alias add #cat #kotik"),
+			},
+			want{ok: true, reply: "алиас #cat указывает сам на себя"},
+		},
+		{
+			"should not add alias on tag of other alias",
+			args{
+				aliases: map[string]string{"#kotik": "#cat"},
+				update:  commandUpdate("user", "/alias add #cat #dog"),
+			},
+			want{ok: true, reply: "#cat уже тег для алиаса #kotik, сначала удали его"},
+		},
+		{
+			"should remove alias",
+			args{
+				aliases: map[string]string{"#kot": "#cat", "#dg": "#dog"},
+				update:  commandUpdate("user", "/alias rm kot"),
+			},
+			want{
+				ok:      true,
+				reply:   "Удален алиас #kot => #cat",
+				aliases: map[string]string{"#dg": "#dog"},
+			},
+		},
+		{
+			"should not remove unknown alias",
+			args{update: commandUpdate("user", "/alias rm #kot")},
+			want{ok: true, reply: "нет алиаса #kot"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			store := NewGifkoskladMetaStorageMock(mc).
+				GetTagsAliasesMock.Return(tt.args.aliases).
+				GetUserRolesMock.Return(nil).
+				GetPendingTyposMock.Return(nil).
+				GetSentAnimationsMock.Return(animations()).
+				GetTagsMock.Return([]string{"#cat", "#dog", "#funny", "#kot"})
+			if tt.want.aliases != nil {
+				store.SetTagsAliasesMock.Expect(tt.want.aliases).Return()
+			}
+			api := NewTelegramBotAPIMock(mc)
+			if tt.want.reply != "" {
+				api.SendMessageMock.Expect(100, tt.want.reply, "").Return(2, nil)
+			}
+
+			u := NewUpdatesHandler(config.Config{AllowedUsers: []string{"user"}}, store, nil, api)
+
+			ok, err := u.handleAliasCommand(tt.args.update)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.ok, ok)
+
+			newCaptions := make(map[string][]string)
+			for fileID, anim := range u.animationsNewCaptions {
+				newCaptions[fileID] = anim.Tags
+			}
+			if tt.want.newCaptions == nil {
+				tt.want.newCaptions = map[string][]string{}
+			}
+			assert.Equal(t, tt.want.newCaptions, newCaptions)
+			assert.Len(t, u.tagChanges, len(tt.want.newCaptions))
+
+			if tt.want.removedTag != "" {
+				assert.False(t, u.uniqueTags[tt.want.removedTag])
+				assert.True(t, u.hasTagsListChanges)
+			}
+		})
+	}
+}
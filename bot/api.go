@@ -5,10 +5,15 @@ import (
 )
 
 type telegramBotAPI interface {
-	SendAnimation(chatID int64, fileID string, caption string) (int, error)
-	GetUpdates() ([]tgbotapi.Update, error)
-	SendMessage(chatID int64, text string) (int, error)
+	SendAnimation(chatID int64, fileID string, caption string, parseMode string) (int, error)
+	GetUpdates(offset int) ([]tgbotapi.Update, error)
+	SendMessage(chatID int64, text string, parseMode string) (int, error)
+	SendReply(chatID int64, replyToID int, text string) (int, error)
+	SendReplyKeyboard(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) (int, error)
 	PinMessage(chatID int64, messageID int) error
-	EditMessage(chatID int64, messageID int, text string) error
-	GetChatPinnedMessageID(chatID int64) (int, error)
+	EditMessage(chatID int64, messageID int, text string, parseMode string) error
+	EditCaption(chatID int64, messageID int, caption string, parseMode string) error
+	DeleteMessage(chatID int64, messageID int) error
+	AnswerInlineQuery(inline tgbotapi.InlineConfig) error
+	AnswerCallbackQuery(callbackID string, text string) error
 }
@@ -7,14 +7,22 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"time"
 
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
 	"github.com/cyhalothrin/gifkoskladbot/api"
+	"github.com/cyhalothrin/gifkoskladbot/caption"
 	"github.com/cyhalothrin/gifkoskladbot/config"
 	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
 )
 
+// defaultUpdateMaxAttempts сколько раз пробовать обработать обновление, если в конфиге не задано
+const defaultUpdateMaxAttempts = 5
+
 func HandleNewMessages() error {
 	gbot, err := newGifkoSkladBot()
 	if err != nil {
@@ -59,10 +67,23 @@ func PollUpdates() error {
 	}
 }
 
+// RollbackAnimationTags вернет гифке теги, которые были у нее до изменения номер change из истории
+// (нумерация с 1, как в GetTagHistory), 0 - отменить последнее изменение.
+// Подпись в канале отредактируется, а откат сам попадет в историю
+func RollbackAnimationTags(fileID string, change int) error {
+	gbot, err := newGifkoSkladBot()
+	if err != nil {
+		return err
+	}
+	defer gbot.close()
+
+	return gbot.rollbackAnimationTags(fileID, change)
+}
+
 type gsBot struct {
 	conf    config.Config
-	store   *storage.FileMetaStorage
-	tgAPI   *api.TelegramBotAPI
+	store   storage.MetaStorage
+	tgAPI   telegramBotAPI
 	handler *UpdatesHandler
 }
 
@@ -72,7 +93,15 @@ func newGifkoSkladBot() (*gsBot, error) {
 		return nil, err
 	}
 
-	store, err := storage.NewFileMetaStorage(conf.StoragePath)
+	if err := checkConfig(conf); err != nil {
+		return nil, err
+	}
+
+	if len(conf.AllowedUsers) > 0 {
+		log.Println("allowedUsers устарел, пользователи из него пока админы, лучше перенести их id в adminIDs или выдать роли через /role")
+	}
+
+	store, err := storage.NewMetaStorageForWrite(conf)
 	if err != nil {
 		return nil, err
 	}
@@ -82,21 +111,241 @@ func newGifkoSkladBot() (*gsBot, error) {
 		return nil, err
 	}
 
+	handler := NewUpdatesHandler(conf, store, NewTgAlert(conf, tgAPI), tgAPI)
+	// при прошлом запуске список тегов мог не обновиться, а обновления уже подтверждены.
+	// Неизменившиеся сообщения списка не редактируются, так что это дешево
+	handler.hasTagsListChanges = true
+
 	return &gsBot{
 		conf:    conf,
 		store:   store,
 		tgAPI:   tgAPI,
-		handler: NewUpdatesHandler(conf, store, NewTgAlert(conf, tgAPI), tgAPI),
+		handler: handler,
 	}, nil
 }
 
+// UpdateTagsList обновит список тегов в канале по тегам из хранилища. Для тех, кто отправляет гифки в канал
+// не через бота (перенос из избранного канала), список хранится в тех же сообщениях, что и у бота
+func UpdateTagsList(conf config.Config, store GifkoskladMetaStorage) error {
+	if err := checkConfig(conf); err != nil {
+		return err
+	}
+
+	tgAPI, err := api.NewTelegramBotAPI(conf)
+	if err != nil {
+		return err
+	}
+
+	handler := NewUpdatesHandler(conf, store, NewTgAlert(conf, tgAPI), tgAPI)
+	// неизменившиеся сообщения списка не редактируются
+	handler.hasTagsListChanges = true
+
+	return handler.UpdateTagsList()
+}
+
+// checkConfig кривые шаблоны и правила каналов лучше найти сразу
+func checkConfig(conf config.Config) error {
+	if _, err := newTagsIndexFormatter(conf); err != nil {
+		return err
+	}
+	if _, err := newChannelRoutes(conf, tagnorm.New(conf.TagNormalization)); err != nil {
+		return err
+	}
+	if _, err := newCaptionFormatter(conf); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleNewMessages заберет и обработает обновления после последнего подтвержденного.
+// Каждое обновление подтверждается само по себе, когда его гифки дошли до канала и сохранились.
+// Необработанное придет еще раз, а уже обработанные после него пропускаются
 func (g *gsBot) handleNewMessages() error {
-	updates, err := g.tgAPI.GetUpdates()
+	updates, err := g.tgAPI.GetUpdates(g.store.GetLastUpdateID() + 1)
 	if err != nil {
 		return err
 	}
 
-	return g.handler.HandleUpdates(updates)
+	done := make(map[int]bool)
+	for _, id := range g.store.GetHandledUpdateIDs() {
+		done[id] = true
+	}
+
+	fresh := make([]tgbotapi.Update, 0, len(updates))
+	for _, update := range updates {
+		if done[update.UpdateID] {
+			log.Printf("Обновление #%d уже обработано\n", update.UpdateID)
+
+			continue
+		}
+		fresh = append(fresh, update)
+	}
+
+	handled, handleErr := g.handler.HandleUpdates(fresh)
+	for _, id := range handled {
+		done[id] = true
+	}
+
+	// сохраним все, что успели обработать, даже если была ошибка
+	if err := g.store.Flush(); err != nil {
+		if handleErr == nil {
+			return fmt.Errorf("сохранение хранилища: %w", err)
+		}
+
+		log.Println("сохранение хранилища:", err)
+	}
+
+	if err := g.ackUpdates(updates, fresh, done); err != nil {
+		if handleErr == nil {
+			return err
+		}
+
+		log.Println(err)
+	}
+
+	return handleErr
+}
+
+// ackUpdates подтвердит обработанные обновления. Телега принимает подтверждение только всех обновлений
+// до id, поэтому обработанные после первого необработанного запоминаются отдельно, чтобы их пропустить,
+// когда телега отдаст их еще раз. Неудачные попытки из attempted считаются, и обновление, которое так и не
+// обработалось за UpdateMaxAttempts раз, подтверждается без обработки, иначе оно навсегда держит следующие
+func (g *gsBot) ackUpdates(updates, attempted []tgbotapi.Update, done map[int]bool) error {
+	sorted := append([]tgbotapi.Update(nil), updates...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UpdateID < sorted[j].UpdateID
+	})
+
+	var lastID int
+	var skipped []error
+	err := g.store.Update(func(tx storage.MetaTx) error {
+		lastID = tx.GetLastUpdateID()
+		skipped = nil
+
+		failures := tx.GetUpdateFailures()
+		if failures == nil {
+			failures = make(map[int]int)
+		}
+		for _, update := range attempted {
+			id := update.UpdateID
+			if done[id] {
+				delete(failures, id)
+
+				continue
+			}
+
+			failures[id]++
+			if failures[id] >= g.updateMaxAttempts() {
+				skipped = append(skipped, fmt.Errorf("обновление #%d не обработалось за %d попыток, подтверждаю его без обработки", id, failures[id]))
+				delete(failures, id)
+				done[id] = true
+			}
+		}
+
+		var handled []int
+		blocked := false
+		for _, update := range sorted {
+			switch {
+			case !done[update.UpdateID]:
+				blocked = true
+			case blocked:
+				handled = append(handled, update.UpdateID)
+			case update.UpdateID > lastID:
+				lastID = update.UpdateID
+			}
+		}
+
+		// подтвержденные телега больше не отдаст, помнить их незачем
+		tx.SetLastUpdateID(lastID)
+		tx.SetHandledUpdateIDs(idsAfter(handled, lastID))
+		for id := range failures {
+			if id <= lastID {
+				delete(failures, id)
+			}
+		}
+		tx.SetUpdateFailures(failures)
+
+		return nil
+	})
+	if err == nil {
+		err = g.store.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("сохранение последнего обновления #%d: %w", lastID, err)
+	}
+
+	for _, err := range skipped {
+		g.handler.sendMeError(err)
+	}
+
+	return nil
+}
+
+func (g *gsBot) updateMaxAttempts() int {
+	if g.conf.UpdateMaxAttempts > 0 {
+		return g.conf.UpdateMaxAttempts
+	}
+
+	return defaultUpdateMaxAttempts
+}
+
+// idsAfter id больше lastID
+func idsAfter(ids []int, lastID int) []int {
+	var result []int
+	for _, id := range ids {
+		if id > lastID {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+func (g *gsBot) rollbackAnimationTags(fileID string, change int) error {
+	history := g.store.GetTagHistory(fileID)
+	if len(history) == 0 {
+		return fmt.Errorf("нет истории изменений тегов гифки %s", fileID)
+	}
+
+	if change == 0 {
+		change = len(history)
+	}
+	if change < 1 || change > len(history) {
+		return fmt.Errorf("нет изменения #%d, всего изменений %d", change, len(history))
+	}
+
+	old := history[change-1]
+	if len(old.OldTags) == 0 {
+		return fmt.Errorf("изменение #%d первая отправка гифки, до него тегов не было", change)
+	}
+
+	// в старых записях истории описание лежит в тегах
+	tags, description := caption.SplitDescription(old.OldTags)
+	if old.OldDescription != "" {
+		description = old.OldDescription
+	}
+
+	if !g.handler.AddAnimationWithTags(fileID, tags, description, nil, time.Now()) {
+		log.Println("Теги уже такие, откатывать нечего")
+
+		return nil
+	}
+
+	handleErr := g.handler.PublishAnimations()
+	if err := g.handler.UpdateTagsList(); err != nil && handleErr == nil {
+		handleErr = err
+	}
+
+	if err := g.store.Flush(); err != nil {
+		if handleErr == nil {
+			return fmt.Errorf("сохранение хранилища: %w", err)
+		}
+
+		log.Println("сохранение хранилища:", err)
+	}
+
+	return handleErr
 }
 
 func (g *gsBot) poll(ctx context.Context) error {
@@ -111,8 +360,9 @@ func (g *gsBot) poll(ctx context.Context) error {
 
 		select {
 		case <-time.After(30 * time.Second):
+			// обновления не подтверждены, в следующий раз придут еще раз
 			if err := g.handleNewMessages(); err != nil {
-				return err
+				g.handler.sendMeError(fmt.Errorf("обработка обновлений: %w", err))
 			}
 		case <-ctx.Done():
 			return nil
@@ -121,5 +371,7 @@ func (g *gsBot) poll(ctx context.Context) error {
 }
 
 func (g *gsBot) close() {
-	g.store.Close()
+	if err := g.store.Close(); err != nil {
+		log.Println("закрытие хранилища:", err)
+	}
 }
@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func TestGsBot_handleNewMessages(t *testing.T) {
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	store, err := storage.NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+
+	captionUpdate := func(updateID int, fileID, text string) tgbotapi.Update {
+		return tgbotapi.Update{UpdateID: updateID, Message: &tgbotapi.Message{
+			MessageID:      updateID,
+			From:           &tgbotapi.User{ID: 1, UserName: "user"},
+			Chat:           &tgbotapi.Chat{ID: 100},
+			Date:           1600000000 + updateID,
+			Text:           text,
+			ReplyToMessage: &tgbotapi.Message{Animation: &tgbotapi.ChatAnimation{FileID: fileID}},
+		}}
+	}
+	// телега отдает все неподтвержденные обновления, пока не подтвердят
+	updates := []tgbotapi.Update{
+		captionUpdate(1, "file_1", "#cat"),
+		captionUpdate(2, "file_2", "#dog"),
+	}
+
+	var offsets []int
+	sent := make(map[string]int)
+	tgAPI := NewTelegramBotAPIMock(mc).
+		GetUpdatesMock.Set(func(offset int) ([]tgbotapi.Update, error) {
+		offsets = append(offsets, offset)
+
+		var result []tgbotapi.Update
+		for _, update := range updates {
+			if update.UpdateID >= offset {
+				result = append(result, update)
+			}
+		}
+
+		return result, nil
+	}).
+		SendAnimationMock.Set(func(chatID int64, fileID, caption, parseMode string) (int, error) {
+		sent[fileID]++
+		if fileID == "file_1" && sent[fileID] == 1 {
+			return 0, errors.New("Bad Gateway")
+		}
+
+		return 10 + sent[fileID], nil
+	}).
+		SendMessageMock.Return(20, nil).
+		PinMessageMock.Return(nil).
+		EditMessageMock.Return(nil)
+
+	conf := config.Config{ChannelID: -100, AdminIDs: []int{1}}
+	alert := NewAlerterMock(mc).SendMock.Return(nil)
+	newBot := func() *gsBot {
+		// в обычном режиме каждый запуск это новый процесс
+		return &gsBot{
+			conf:    conf,
+			store:   store,
+			tgAPI:   tgAPI,
+			handler: NewUpdatesHandler(conf, store, alert, tgAPI),
+		}
+	}
+
+	assert.Error(t, newBot().handleNewMessages(), "first gif was not sent")
+	assert.Equal(t, 0, store.GetLastUpdateID(), "first update should not be acked")
+	assert.Equal(t, []int{2}, store.GetHandledUpdateIDs())
+	assert.Equal(t, map[string]int{"file_1": 1, "file_2": 1}, sent)
+
+	assert.NoError(t, newBot().handleNewMessages())
+	assert.Equal(t, 2, store.GetLastUpdateID())
+	assert.Empty(t, store.GetHandledUpdateIDs())
+	assert.Equal(t, map[string]int{"file_1": 2, "file_2": 1}, sent, "second gif should not be sent again")
+	assert.Equal(t, []int{1, 1}, offsets)
+
+	animations := store.GetSentAnimations()
+	assert.Equal(t, 12, animations["file_1"].MessageID)
+	assert.Equal(t, 11, animations["file_2"].MessageID)
+
+	assert.NoError(t, newBot().handleNewMessages())
+	assert.Equal(t, []int{1, 1, 3}, offsets)
+}
+
+func TestGsBot_handleNewMessages_permanentFailure(t *testing.T) {
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	store, err := storage.NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+
+	updates := []tgbotapi.Update{
+		{UpdateID: 1, Message: &tgbotapi.Message{
+			MessageID:      1,
+			From:           &tgbotapi.User{ID: 1, UserName: "user"},
+			Chat:           &tgbotapi.Chat{ID: 100},
+			Text:           "#cat",
+			ReplyToMessage: &tgbotapi.Message{Animation: &tgbotapi.ChatAnimation{FileID: "broken"}},
+		}},
+		{UpdateID: 2, Message: &tgbotapi.Message{
+			MessageID:      2,
+			From:           &tgbotapi.User{ID: 1, UserName: "user"},
+			Chat:           &tgbotapi.Chat{ID: 100},
+			Text:           "#dog",
+			ReplyToMessage: &tgbotapi.Message{Animation: &tgbotapi.ChatAnimation{FileID: "file_2"}},
+		}},
+	}
+
+	var offsets []int
+	tgAPI := NewTelegramBotAPIMock(mc).
+		GetUpdatesMock.Set(func(offset int) ([]tgbotapi.Update, error) {
+		offsets = append(offsets, offset)
+
+		var result []tgbotapi.Update
+		for _, update := range updates {
+			if update.UpdateID >= offset {
+				result = append(result, update)
+			}
+		}
+
+		return result, nil
+	}).
+		SendAnimationMock.Set(func(chatID int64, fileID, caption, parseMode string) (int, error) {
+		if fileID == "broken" {
+			return 0, errors.New("Bad Request: wrong file identifier")
+		}
+
+		return 11, nil
+	}).
+		SendMessageMock.Return(20, nil).
+		PinMessageMock.Return(nil)
+
+	conf := config.Config{ChannelID: -100, AdminIDs: []int{1}, UpdateMaxAttempts: 2}
+	var alerts []string
+	alert := NewAlerterMock(mc).SendMock.Set(func(err error) error {
+		alerts = append(alerts, err.Error())
+
+		return nil
+	})
+	newBot := func() *gsBot {
+		return &gsBot{
+			conf:    conf,
+			store:   store,
+			tgAPI:   tgAPI,
+			handler: NewUpdatesHandler(conf, store, alert, tgAPI),
+		}
+	}
+
+	assert.Error(t, newBot().handleNewMessages())
+	assert.Equal(t, 0, store.GetLastUpdateID())
+	assert.Equal(t, []int{2}, store.GetHandledUpdateIDs())
+	assert.Equal(t, map[int]int{1: 1}, store.GetUpdateFailures())
+
+	alerts = nil
+	assert.Error(t, newBot().handleNewMessages())
+	assert.Equal(t, 2, store.GetLastUpdateID(), "update should be acked after max attempts")
+	assert.Empty(t, store.GetHandledUpdateIDs())
+	assert.Empty(t, store.GetUpdateFailures())
+	assert.Contains(t, alerts, "обновление #1 не обработалось за 2 попыток, подтверждаю его без обработки")
+
+	assert.NoError(t, newBot().handleNewMessages())
+	assert.Equal(t, []int{1, 1, 3}, offsets)
+}
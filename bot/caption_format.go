@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+// captionLimit длиннее подпись к гифке телега не примет, считается текст без разметки
+const captionLimit = 1024
+
+// defaultCaptionTemplate теги и описание через пробел, как подписи выглядели всегда
+const defaultCaptionTemplate = `{{esc .Hashtags}}{{with .Description}} {{esc .}}{{end}}`
+
+// captionData данные для шаблона подписи гифки
+type captionData struct {
+	Tags []string
+	// Hashtags теги через пробел
+	Hashtags    string
+	Description string
+}
+
+// captionFormatter собирает подпись гифки в канале по шаблону и разметке из конфига
+type captionFormatter struct {
+	tmpl *template.Template
+	// plain тот же шаблон без разметки, по нему считается длина подписи
+	plain     *template.Template
+	parseMode string
+}
+
+func newCaptionFormatter(conf config.Config) (*captionFormatter, error) {
+	m, ok := parseMarkup(conf.Caption.Format)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный формат подписи '%s'", conf.Caption.Format)
+	}
+
+	text := conf.Caption.Template
+	if text == "" {
+		text = defaultCaptionTemplate
+	}
+
+	tmpl, err := parseCaptionTemplate(text, m)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := parseCaptionTemplate(text, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &captionFormatter{tmpl: tmpl, plain: plain, parseMode: string(m)}, nil
+}
+
+func parseCaptionTemplate(text string, m markup) (*template.Template, error) {
+	tmpl, err := template.New("caption").Funcs(template.FuncMap{
+		"esc":    m.escape,
+		"bold":   m.bold,
+		"italic": m.italic,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("шаблон подписи: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// format подпись гифки, вернет ошибку, если подпись не влезет в лимит телеги
+func (f *captionFormatter) format(anim *storage.SentAnimation) (string, error) {
+	tags, description := animationCaption(anim)
+	data := captionData{
+		Tags:        tags,
+		Hashtags:    strings.Join(tags, " "),
+		Description: description,
+	}
+
+	plain, err := execCaptionTemplate(f.plain, data)
+	if err != nil {
+		return "", err
+	}
+	if n := utf8.RuneCountInString(plain); n > captionLimit {
+		return "", fmt.Errorf("подпись длиннее %d символов (%d), сократи описание", captionLimit, n)
+	}
+
+	if f.parseMode == "" {
+		return plain, nil
+	}
+
+	return execCaptionTemplate(f.tmpl, data)
+}
+
+func execCaptionTemplate(tmpl *template.Template, data captionData) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("шаблон подписи: %w", err)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// PostFormatter подпись и канал новой гифки по конфигу бота, для тех, кто отправляет гифки в канал сам
+// (перенос из избранного канала), чтобы они выглядели так же, как отправленные ботом
+type PostFormatter struct {
+	captions *captionFormatter
+	channels *channelRoutes
+}
+
+func NewPostFormatter(conf config.Config) (*PostFormatter, error) {
+	captions, err := newCaptionFormatter(conf)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := newChannelRoutes(conf, tagnorm.New(conf.TagNormalization))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostFormatter{captions: captions, channels: channels}, nil
+}
+
+// Caption подпись гифки по шаблону, вернет ошибку, если подпись не влезет в лимит телеги
+func (p *PostFormatter) Caption(tags []string, description string) (string, error) {
+	return p.captions.format(&storage.SentAnimation{Tags: tags, Description: description})
+}
+
+// ParseMode разметка подписи: HTML, MarkdownV2 или пусто
+func (p *PostFormatter) ParseMode() string {
+	return p.captions.parseMode
+}
+
+// Channel канал новой гифки по правилам из конфига. Автора у таких гифок нет,
+// поэтому правила по пользователям не подходят
+func (p *PostFormatter) Channel(tags []string) int64 {
+	return p.channels.route(tags, nil)
+}
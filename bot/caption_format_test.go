@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func TestCaptionFormatter_format(t *testing.T) {
+	t.Parallel()
+
+	italicTemplate := `{{with .Description}}{{italic .}}{{"\n"}}{{end}}{{esc .Hashtags}}`
+
+	tests := []struct {
+		name          string
+		conf          config.Caption
+		anim          *storage.SentAnimation
+		wantCaption   string
+		wantParseMode string
+		wantErr       bool
+	}{
+		{
+			"default template is plain tags and description",
+			config.Caption{},
+			&storage.SentAnimation{Tags: []string{"#cat", "#funny"}, Description: "кот <упал>"},
+			"#cat #funny кот <упал>",
+			"",
+			false,
+		},
+		{
+			"should split legacy description from tags",
+			config.Caption{},
+			&storage.SentAnimation{Tags: []string{"#cat", "кот упал"}},
+			"#cat кот упал",
+			"",
+			false,
+		},
+		{
+			"html",
+			config.Caption{Format: "HTML", Template: italicTemplate},
+			&storage.SentAnimation{Tags: []string{"#cat"}, Description: "кот <упал>"},
+			"<i>кот &lt;упал&gt;</i>\n#cat",
+			"HTML",
+			false,
+		},
+		{
+			"markdownv2 without description",
+			config.Caption{Format: "markdownv2", Template: italicTemplate},
+			&storage.SentAnimation{Tags: []string{"#cat", "#like_a_boss"}},
+			`\#cat \#like\_a\_boss`,
+			"MarkdownV2",
+			false,
+		},
+		{
+			"should not count markup in length",
+			config.Caption{Format: "html", Template: `{{bold .Description}}`},
+			&storage.SentAnimation{Description: strings.Repeat("&", captionLimit)},
+			"<b>" + strings.Repeat("&amp;", captionLimit) + "</b>",
+			"HTML",
+			false,
+		},
+		{
+			"should fail on too long caption",
+			config.Caption{},
+			&storage.SentAnimation{Tags: []string{"#cat"}, Description: strings.Repeat("ы", captionLimit)},
+			"",
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := newCaptionFormatter(config.Config{Caption: tt.conf})
+			if err != nil {
+				t.Fatalf("newCaptionFormatter() error = %v", err)
+			}
+
+			got, err := f.format(tt.anim)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantCaption, got)
+			assert.Equal(t, tt.wantParseMode, f.parseMode)
+		})
+	}
+}
+
+func TestNewCaptionFormatter(t *testing.T) {
+	t.Parallel()
+
+	_, err := newCaptionFormatter(config.Config{Caption: config.Caption{Format: "bbcode"}})
+	assert.Error(t, err)
+
+	_, err = newCaptionFormatter(config.Config{Caption: config.Caption{Template: "{{.Unknown"}})
+	assert.Error(t, err)
+}
+
+func TestPostFormatter(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewPostFormatter(config.Config{
+		ChannelID: -100,
+		Caption:   config.Caption{Format: "html", Template: `{{with .Description}}{{italic .}}{{"\n"}}{{end}}{{esc .Hashtags}}`},
+		Routing: config.Routing{
+			Channels: []config.RoutingChannel{{Name: "cats", ID: -200}},
+			Rules: []config.RoutingRule{
+				{Channel: "cats", Tags: []string{"#Кот"}},
+				{Channel: "main", UserIDs: []int{42}},
+			},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	caption, err := p.Caption([]string{"#кот"}, "упал <сам>")
+	assert.NoError(t, err)
+	assert.Equal(t, "<i>упал &lt;сам&gt;</i>\n#кот", caption)
+	assert.Equal(t, "HTML", p.ParseMode())
+
+	_, err = p.Caption([]string{"#кот"}, strings.Repeat("a", captionLimit))
+	assert.Error(t, err)
+
+	assert.Equal(t, int64(-200), p.Channel([]string{"#пес", "#кот"}))
+	assert.Equal(t, int64(-100), p.Channel([]string{"#пес"}))
+}
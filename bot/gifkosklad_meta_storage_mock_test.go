@@ -2,7 +2,7 @@ package bot
 
 // Code generated by http://github.com/gojuno/minimock (3.0.8). DO NOT EDIT.
 
-//go:generate minimock -i github.com/cyhalothrin/gifkoskladbot/bot.GifkoskladMetaStorage -o ./bot\gifkosklad_meta_storage_mock_test.go
+//go:generate minimock -i github.com/cyhalothrin/gifkoskladbot/bot.GifkoskladMetaStorage -o ./bot/gifkosklad_meta_storage_mock_test.go
 
 import (
 	"sync"
@@ -23,6 +23,18 @@ type GifkoskladMetaStorageMock struct {
 	beforeAddSentAnimationsCounter uint64
 	AddSentAnimationsMock          mGifkoskladMetaStorageMockAddSentAnimations
 
+	funcAddTagChanges          func(changes ...*storage.TagChange)
+	inspectFuncAddTagChanges   func(changes ...*storage.TagChange)
+	afterAddTagChangesCounter  uint64
+	beforeAddTagChangesCounter uint64
+	AddTagChangesMock          mGifkoskladMetaStorageMockAddTagChanges
+
+	funcGetPendingTypos          func() (m1 map[string]*storage.PendingTypos)
+	inspectFuncGetPendingTypos   func()
+	afterGetPendingTyposCounter  uint64
+	beforeGetPendingTyposCounter uint64
+	GetPendingTyposMock          mGifkoskladMetaStorageMockGetPendingTypos
+
 	funcGetSentAnimations          func() (m1 map[string]*storage.SentAnimation)
 	inspectFuncGetSentAnimations   func()
 	afterGetSentAnimationsCounter  uint64
@@ -41,6 +53,24 @@ type GifkoskladMetaStorageMock struct {
 	beforeGetTagsAliasesCounter uint64
 	GetTagsAliasesMock          mGifkoskladMetaStorageMockGetTagsAliases
 
+	funcGetTagsIndex          func() (tpa1 []*storage.TagsIndexMessage)
+	inspectFuncGetTagsIndex   func()
+	afterGetTagsIndexCounter  uint64
+	beforeGetTagsIndexCounter uint64
+	GetTagsIndexMock          mGifkoskladMetaStorageMockGetTagsIndex
+
+	funcGetUserRoles          func() (m1 map[int]string)
+	inspectFuncGetUserRoles   func()
+	afterGetUserRolesCounter  uint64
+	beforeGetUserRolesCounter uint64
+	GetUserRolesMock          mGifkoskladMetaStorageMockGetUserRoles
+
+	funcSetPendingTypos          func(m1 map[string]*storage.PendingTypos)
+	inspectFuncSetPendingTypos   func(m1 map[string]*storage.PendingTypos)
+	afterSetPendingTyposCounter  uint64
+	beforeSetPendingTyposCounter uint64
+	SetPendingTyposMock          mGifkoskladMetaStorageMockSetPendingTypos
+
 	funcSetTags          func(sa1 []string)
 	inspectFuncSetTags   func(sa1 []string)
 	afterSetTagsCounter  uint64
@@ -52,6 +82,18 @@ type GifkoskladMetaStorageMock struct {
 	afterSetTagsAliasesCounter  uint64
 	beforeSetTagsAliasesCounter uint64
 	SetTagsAliasesMock          mGifkoskladMetaStorageMockSetTagsAliases
+
+	funcSetTagsIndex          func(tpa1 []*storage.TagsIndexMessage)
+	inspectFuncSetTagsIndex   func(tpa1 []*storage.TagsIndexMessage)
+	afterSetTagsIndexCounter  uint64
+	beforeSetTagsIndexCounter uint64
+	SetTagsIndexMock          mGifkoskladMetaStorageMockSetTagsIndex
+
+	funcSetUserRoles          func(m1 map[int]string)
+	inspectFuncSetUserRoles   func(m1 map[int]string)
+	afterSetUserRolesCounter  uint64
+	beforeSetUserRolesCounter uint64
+	SetUserRolesMock          mGifkoskladMetaStorageMockSetUserRoles
 }
 
 // NewGifkoskladMetaStorageMock returns a mock for GifkoskladMetaStorage
@@ -64,18 +106,36 @@ func NewGifkoskladMetaStorageMock(t minimock.Tester) *GifkoskladMetaStorageMock
 	m.AddSentAnimationsMock = mGifkoskladMetaStorageMockAddSentAnimations{mock: m}
 	m.AddSentAnimationsMock.callArgs = []*GifkoskladMetaStorageMockAddSentAnimationsParams{}
 
+	m.AddTagChangesMock = mGifkoskladMetaStorageMockAddTagChanges{mock: m}
+	m.AddTagChangesMock.callArgs = []*GifkoskladMetaStorageMockAddTagChangesParams{}
+
+	m.GetPendingTyposMock = mGifkoskladMetaStorageMockGetPendingTypos{mock: m}
+
 	m.GetSentAnimationsMock = mGifkoskladMetaStorageMockGetSentAnimations{mock: m}
 
 	m.GetTagsMock = mGifkoskladMetaStorageMockGetTags{mock: m}
 
 	m.GetTagsAliasesMock = mGifkoskladMetaStorageMockGetTagsAliases{mock: m}
 
+	m.GetTagsIndexMock = mGifkoskladMetaStorageMockGetTagsIndex{mock: m}
+
+	m.GetUserRolesMock = mGifkoskladMetaStorageMockGetUserRoles{mock: m}
+
+	m.SetPendingTyposMock = mGifkoskladMetaStorageMockSetPendingTypos{mock: m}
+	m.SetPendingTyposMock.callArgs = []*GifkoskladMetaStorageMockSetPendingTyposParams{}
+
 	m.SetTagsMock = mGifkoskladMetaStorageMockSetTags{mock: m}
 	m.SetTagsMock.callArgs = []*GifkoskladMetaStorageMockSetTagsParams{}
 
 	m.SetTagsAliasesMock = mGifkoskladMetaStorageMockSetTagsAliases{mock: m}
 	m.SetTagsAliasesMock.callArgs = []*GifkoskladMetaStorageMockSetTagsAliasesParams{}
 
+	m.SetTagsIndexMock = mGifkoskladMetaStorageMockSetTagsIndex{mock: m}
+	m.SetTagsIndexMock.callArgs = []*GifkoskladMetaStorageMockSetTagsIndexParams{}
+
+	m.SetUserRolesMock = mGifkoskladMetaStorageMockSetUserRoles{mock: m}
+	m.SetUserRolesMock.callArgs = []*GifkoskladMetaStorageMockSetUserRolesParams{}
+
 	return m
 }
 
@@ -145,7 +205,7 @@ func (mmAddSentAnimations *mGifkoskladMetaStorageMockAddSentAnimations) Return()
 	return mmAddSentAnimations.mock
 }
 
-//Set uses given function f to mock the GifkoskladMetaStorage.AddSentAnimations method
+// Set uses given function f to mock the GifkoskladMetaStorage.AddSentAnimations method
 func (mmAddSentAnimations *mGifkoskladMetaStorageMockAddSentAnimations) Set(f func(m1 map[string]*storage.SentAnimation)) *GifkoskladMetaStorageMock {
 	if mmAddSentAnimations.defaultExpectation != nil {
 		mmAddSentAnimations.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.AddSentAnimations method")
@@ -266,6 +326,336 @@ func (m *GifkoskladMetaStorageMock) MinimockAddSentAnimationsInspect() {
 	}
 }
 
+type mGifkoskladMetaStorageMockAddTagChanges struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockAddTagChangesExpectation
+	expectations       []*GifkoskladMetaStorageMockAddTagChangesExpectation
+
+	callArgs []*GifkoskladMetaStorageMockAddTagChangesParams
+	mutex    sync.RWMutex
+}
+
+// GifkoskladMetaStorageMockAddTagChangesExpectation specifies expectation struct of the GifkoskladMetaStorage.AddTagChanges
+type GifkoskladMetaStorageMockAddTagChangesExpectation struct {
+	mock   *GifkoskladMetaStorageMock
+	params *GifkoskladMetaStorageMockAddTagChangesParams
+
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockAddTagChangesParams contains parameters of the GifkoskladMetaStorage.AddTagChanges
+type GifkoskladMetaStorageMockAddTagChangesParams struct {
+	changes []*storage.TagChange
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.AddTagChanges
+func (mmAddTagChanges *mGifkoskladMetaStorageMockAddTagChanges) Expect(changes ...*storage.TagChange) *mGifkoskladMetaStorageMockAddTagChanges {
+	if mmAddTagChanges.mock.funcAddTagChanges != nil {
+		mmAddTagChanges.mock.t.Fatalf("GifkoskladMetaStorageMock.AddTagChanges mock is already set by Set")
+	}
+
+	if mmAddTagChanges.defaultExpectation == nil {
+		mmAddTagChanges.defaultExpectation = &GifkoskladMetaStorageMockAddTagChangesExpectation{}
+	}
+
+	mmAddTagChanges.defaultExpectation.params = &GifkoskladMetaStorageMockAddTagChangesParams{changes}
+	for _, e := range mmAddTagChanges.expectations {
+		if minimock.Equal(e.params, mmAddTagChanges.defaultExpectation.params) {
+			mmAddTagChanges.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddTagChanges.defaultExpectation.params)
+		}
+	}
+
+	return mmAddTagChanges
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.AddTagChanges
+func (mmAddTagChanges *mGifkoskladMetaStorageMockAddTagChanges) Inspect(f func(changes ...*storage.TagChange)) *mGifkoskladMetaStorageMockAddTagChanges {
+	if mmAddTagChanges.mock.inspectFuncAddTagChanges != nil {
+		mmAddTagChanges.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.AddTagChanges")
+	}
+
+	mmAddTagChanges.mock.inspectFuncAddTagChanges = f
+
+	return mmAddTagChanges
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.AddTagChanges
+func (mmAddTagChanges *mGifkoskladMetaStorageMockAddTagChanges) Return() *GifkoskladMetaStorageMock {
+	if mmAddTagChanges.mock.funcAddTagChanges != nil {
+		mmAddTagChanges.mock.t.Fatalf("GifkoskladMetaStorageMock.AddTagChanges mock is already set by Set")
+	}
+
+	if mmAddTagChanges.defaultExpectation == nil {
+		mmAddTagChanges.defaultExpectation = &GifkoskladMetaStorageMockAddTagChangesExpectation{mock: mmAddTagChanges.mock}
+	}
+
+	return mmAddTagChanges.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.AddTagChanges method
+func (mmAddTagChanges *mGifkoskladMetaStorageMockAddTagChanges) Set(f func(changes ...*storage.TagChange)) *GifkoskladMetaStorageMock {
+	if mmAddTagChanges.defaultExpectation != nil {
+		mmAddTagChanges.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.AddTagChanges method")
+	}
+
+	if len(mmAddTagChanges.expectations) > 0 {
+		mmAddTagChanges.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.AddTagChanges method")
+	}
+
+	mmAddTagChanges.mock.funcAddTagChanges = f
+	return mmAddTagChanges.mock
+}
+
+// AddTagChanges implements GifkoskladMetaStorage
+func (mmAddTagChanges *GifkoskladMetaStorageMock) AddTagChanges(changes ...*storage.TagChange) {
+	mm_atomic.AddUint64(&mmAddTagChanges.beforeAddTagChangesCounter, 1)
+	defer mm_atomic.AddUint64(&mmAddTagChanges.afterAddTagChangesCounter, 1)
+
+	if mmAddTagChanges.inspectFuncAddTagChanges != nil {
+		mmAddTagChanges.inspectFuncAddTagChanges(changes...)
+	}
+
+	mm_params := &GifkoskladMetaStorageMockAddTagChangesParams{changes}
+
+	// Record call args
+	mmAddTagChanges.AddTagChangesMock.mutex.Lock()
+	mmAddTagChanges.AddTagChangesMock.callArgs = append(mmAddTagChanges.AddTagChangesMock.callArgs, mm_params)
+	mmAddTagChanges.AddTagChangesMock.mutex.Unlock()
+
+	for _, e := range mmAddTagChanges.AddTagChangesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmAddTagChanges.AddTagChangesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAddTagChanges.AddTagChangesMock.defaultExpectation.Counter, 1)
+		mm_want := mmAddTagChanges.AddTagChangesMock.defaultExpectation.params
+		mm_got := GifkoskladMetaStorageMockAddTagChangesParams{changes}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAddTagChanges.t.Errorf("GifkoskladMetaStorageMock.AddTagChanges got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmAddTagChanges.funcAddTagChanges != nil {
+		mmAddTagChanges.funcAddTagChanges(changes...)
+		return
+	}
+	mmAddTagChanges.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.AddTagChanges. %v", changes)
+
+}
+
+// AddTagChangesAfterCounter returns a count of finished GifkoskladMetaStorageMock.AddTagChanges invocations
+func (mmAddTagChanges *GifkoskladMetaStorageMock) AddTagChangesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddTagChanges.afterAddTagChangesCounter)
+}
+
+// AddTagChangesBeforeCounter returns a count of GifkoskladMetaStorageMock.AddTagChanges invocations
+func (mmAddTagChanges *GifkoskladMetaStorageMock) AddTagChangesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddTagChanges.beforeAddTagChangesCounter)
+}
+
+// Calls returns a list of arguments used in each call to GifkoskladMetaStorageMock.AddTagChanges.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAddTagChanges *mGifkoskladMetaStorageMockAddTagChanges) Calls() []*GifkoskladMetaStorageMockAddTagChangesParams {
+	mmAddTagChanges.mutex.RLock()
+
+	argCopy := make([]*GifkoskladMetaStorageMockAddTagChangesParams, len(mmAddTagChanges.callArgs))
+	copy(argCopy, mmAddTagChanges.callArgs)
+
+	mmAddTagChanges.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAddTagChangesDone returns true if the count of the AddTagChanges invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockAddTagChangesDone() bool {
+	for _, e := range m.AddTagChangesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddTagChangesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddTagChanges != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockAddTagChangesInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockAddTagChangesInspect() {
+	for _, e := range m.AddTagChangesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.AddTagChanges with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddTagChangesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		if m.AddTagChangesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.AddTagChanges")
+		} else {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.AddTagChanges with params: %#v", *m.AddTagChangesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddTagChanges != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.AddTagChanges")
+	}
+}
+
+type mGifkoskladMetaStorageMockGetPendingTypos struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockGetPendingTyposExpectation
+	expectations       []*GifkoskladMetaStorageMockGetPendingTyposExpectation
+}
+
+// GifkoskladMetaStorageMockGetPendingTyposExpectation specifies expectation struct of the GifkoskladMetaStorage.GetPendingTypos
+type GifkoskladMetaStorageMockGetPendingTyposExpectation struct {
+	mock *GifkoskladMetaStorageMock
+
+	results *GifkoskladMetaStorageMockGetPendingTyposResults
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockGetPendingTyposResults contains results of the GifkoskladMetaStorage.GetPendingTypos
+type GifkoskladMetaStorageMockGetPendingTyposResults struct {
+	m1 map[string]*storage.PendingTypos
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.GetPendingTypos
+func (mmGetPendingTypos *mGifkoskladMetaStorageMockGetPendingTypos) Expect() *mGifkoskladMetaStorageMockGetPendingTypos {
+	if mmGetPendingTypos.mock.funcGetPendingTypos != nil {
+		mmGetPendingTypos.mock.t.Fatalf("GifkoskladMetaStorageMock.GetPendingTypos mock is already set by Set")
+	}
+
+	if mmGetPendingTypos.defaultExpectation == nil {
+		mmGetPendingTypos.defaultExpectation = &GifkoskladMetaStorageMockGetPendingTyposExpectation{}
+	}
+
+	return mmGetPendingTypos
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.GetPendingTypos
+func (mmGetPendingTypos *mGifkoskladMetaStorageMockGetPendingTypos) Inspect(f func()) *mGifkoskladMetaStorageMockGetPendingTypos {
+	if mmGetPendingTypos.mock.inspectFuncGetPendingTypos != nil {
+		mmGetPendingTypos.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.GetPendingTypos")
+	}
+
+	mmGetPendingTypos.mock.inspectFuncGetPendingTypos = f
+
+	return mmGetPendingTypos
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.GetPendingTypos
+func (mmGetPendingTypos *mGifkoskladMetaStorageMockGetPendingTypos) Return(m1 map[string]*storage.PendingTypos) *GifkoskladMetaStorageMock {
+	if mmGetPendingTypos.mock.funcGetPendingTypos != nil {
+		mmGetPendingTypos.mock.t.Fatalf("GifkoskladMetaStorageMock.GetPendingTypos mock is already set by Set")
+	}
+
+	if mmGetPendingTypos.defaultExpectation == nil {
+		mmGetPendingTypos.defaultExpectation = &GifkoskladMetaStorageMockGetPendingTyposExpectation{mock: mmGetPendingTypos.mock}
+	}
+	mmGetPendingTypos.defaultExpectation.results = &GifkoskladMetaStorageMockGetPendingTyposResults{m1}
+	return mmGetPendingTypos.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.GetPendingTypos method
+func (mmGetPendingTypos *mGifkoskladMetaStorageMockGetPendingTypos) Set(f func() (m1 map[string]*storage.PendingTypos)) *GifkoskladMetaStorageMock {
+	if mmGetPendingTypos.defaultExpectation != nil {
+		mmGetPendingTypos.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.GetPendingTypos method")
+	}
+
+	if len(mmGetPendingTypos.expectations) > 0 {
+		mmGetPendingTypos.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.GetPendingTypos method")
+	}
+
+	mmGetPendingTypos.mock.funcGetPendingTypos = f
+	return mmGetPendingTypos.mock
+}
+
+// GetPendingTypos implements GifkoskladMetaStorage
+func (mmGetPendingTypos *GifkoskladMetaStorageMock) GetPendingTypos() (m1 map[string]*storage.PendingTypos) {
+	mm_atomic.AddUint64(&mmGetPendingTypos.beforeGetPendingTyposCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetPendingTypos.afterGetPendingTyposCounter, 1)
+
+	if mmGetPendingTypos.inspectFuncGetPendingTypos != nil {
+		mmGetPendingTypos.inspectFuncGetPendingTypos()
+	}
+
+	if mmGetPendingTypos.GetPendingTyposMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetPendingTypos.GetPendingTyposMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetPendingTypos.GetPendingTyposMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetPendingTypos.t.Fatal("No results are set for the GifkoskladMetaStorageMock.GetPendingTypos")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetPendingTypos.funcGetPendingTypos != nil {
+		return mmGetPendingTypos.funcGetPendingTypos()
+	}
+	mmGetPendingTypos.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.GetPendingTypos.")
+	return
+}
+
+// GetPendingTyposAfterCounter returns a count of finished GifkoskladMetaStorageMock.GetPendingTypos invocations
+func (mmGetPendingTypos *GifkoskladMetaStorageMock) GetPendingTyposAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPendingTypos.afterGetPendingTyposCounter)
+}
+
+// GetPendingTyposBeforeCounter returns a count of GifkoskladMetaStorageMock.GetPendingTypos invocations
+func (mmGetPendingTypos *GifkoskladMetaStorageMock) GetPendingTyposBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPendingTypos.beforeGetPendingTyposCounter)
+}
+
+// MinimockGetPendingTyposDone returns true if the count of the GetPendingTypos invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockGetPendingTyposDone() bool {
+	for _, e := range m.GetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetPendingTyposInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockGetPendingTyposInspect() {
+	for _, e := range m.GetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.GetPendingTypos")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.GetPendingTypos")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.GetPendingTypos")
+	}
+}
+
 type mGifkoskladMetaStorageMockGetSentAnimations struct {
 	mock               *GifkoskladMetaStorageMock
 	defaultExpectation *GifkoskladMetaStorageMockGetSentAnimationsExpectation
@@ -322,7 +712,7 @@ func (mmGetSentAnimations *mGifkoskladMetaStorageMockGetSentAnimations) Return(m
 	return mmGetSentAnimations.mock
 }
 
-//Set uses given function f to mock the GifkoskladMetaStorage.GetSentAnimations method
+// Set uses given function f to mock the GifkoskladMetaStorage.GetSentAnimations method
 func (mmGetSentAnimations *mGifkoskladMetaStorageMockGetSentAnimations) Set(f func() (m1 map[string]*storage.SentAnimation)) *GifkoskladMetaStorageMock {
 	if mmGetSentAnimations.defaultExpectation != nil {
 		mmGetSentAnimations.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.GetSentAnimations method")
@@ -465,7 +855,7 @@ func (mmGetTags *mGifkoskladMetaStorageMockGetTags) Return(sa1 []string) *Gifkos
 	return mmGetTags.mock
 }
 
-//Set uses given function f to mock the GifkoskladMetaStorage.GetTags method
+// Set uses given function f to mock the GifkoskladMetaStorage.GetTags method
 func (mmGetTags *mGifkoskladMetaStorageMockGetTags) Set(f func() (sa1 []string)) *GifkoskladMetaStorageMock {
 	if mmGetTags.defaultExpectation != nil {
 		mmGetTags.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.GetTags method")
@@ -608,7 +998,7 @@ func (mmGetTagsAliases *mGifkoskladMetaStorageMockGetTagsAliases) Return(m1 map[
 	return mmGetTagsAliases.mock
 }
 
-//Set uses given function f to mock the GifkoskladMetaStorage.GetTagsAliases method
+// Set uses given function f to mock the GifkoskladMetaStorage.GetTagsAliases method
 func (mmGetTagsAliases *mGifkoskladMetaStorageMockGetTagsAliases) Set(f func() (m1 map[string]string)) *GifkoskladMetaStorageMock {
 	if mmGetTagsAliases.defaultExpectation != nil {
 		mmGetTagsAliases.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.GetTagsAliases method")
@@ -695,110 +1085,583 @@ func (m *GifkoskladMetaStorageMock) MinimockGetTagsAliasesInspect() {
 	}
 }
 
-type mGifkoskladMetaStorageMockSetTags struct {
+type mGifkoskladMetaStorageMockGetTagsIndex struct {
 	mock               *GifkoskladMetaStorageMock
-	defaultExpectation *GifkoskladMetaStorageMockSetTagsExpectation
-	expectations       []*GifkoskladMetaStorageMockSetTagsExpectation
-
-	callArgs []*GifkoskladMetaStorageMockSetTagsParams
-	mutex    sync.RWMutex
+	defaultExpectation *GifkoskladMetaStorageMockGetTagsIndexExpectation
+	expectations       []*GifkoskladMetaStorageMockGetTagsIndexExpectation
 }
 
-// GifkoskladMetaStorageMockSetTagsExpectation specifies expectation struct of the GifkoskladMetaStorage.SetTags
-type GifkoskladMetaStorageMockSetTagsExpectation struct {
-	mock   *GifkoskladMetaStorageMock
-	params *GifkoskladMetaStorageMockSetTagsParams
+// GifkoskladMetaStorageMockGetTagsIndexExpectation specifies expectation struct of the GifkoskladMetaStorage.GetTagsIndex
+type GifkoskladMetaStorageMockGetTagsIndexExpectation struct {
+	mock *GifkoskladMetaStorageMock
 
+	results *GifkoskladMetaStorageMockGetTagsIndexResults
 	Counter uint64
 }
 
-// GifkoskladMetaStorageMockSetTagsParams contains parameters of the GifkoskladMetaStorage.SetTags
-type GifkoskladMetaStorageMockSetTagsParams struct {
-	sa1 []string
+// GifkoskladMetaStorageMockGetTagsIndexResults contains results of the GifkoskladMetaStorage.GetTagsIndex
+type GifkoskladMetaStorageMockGetTagsIndexResults struct {
+	tpa1 []*storage.TagsIndexMessage
 }
 
-// Expect sets up expected params for GifkoskladMetaStorage.SetTags
-func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Expect(sa1 []string) *mGifkoskladMetaStorageMockSetTags {
-	if mmSetTags.mock.funcSetTags != nil {
-		mmSetTags.mock.t.Fatalf("GifkoskladMetaStorageMock.SetTags mock is already set by Set")
-	}
-
-	if mmSetTags.defaultExpectation == nil {
-		mmSetTags.defaultExpectation = &GifkoskladMetaStorageMockSetTagsExpectation{}
+// Expect sets up expected params for GifkoskladMetaStorage.GetTagsIndex
+func (mmGetTagsIndex *mGifkoskladMetaStorageMockGetTagsIndex) Expect() *mGifkoskladMetaStorageMockGetTagsIndex {
+	if mmGetTagsIndex.mock.funcGetTagsIndex != nil {
+		mmGetTagsIndex.mock.t.Fatalf("GifkoskladMetaStorageMock.GetTagsIndex mock is already set by Set")
 	}
 
-	mmSetTags.defaultExpectation.params = &GifkoskladMetaStorageMockSetTagsParams{sa1}
-	for _, e := range mmSetTags.expectations {
-		if minimock.Equal(e.params, mmSetTags.defaultExpectation.params) {
-			mmSetTags.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetTags.defaultExpectation.params)
-		}
+	if mmGetTagsIndex.defaultExpectation == nil {
+		mmGetTagsIndex.defaultExpectation = &GifkoskladMetaStorageMockGetTagsIndexExpectation{}
 	}
 
-	return mmSetTags
+	return mmGetTagsIndex
 }
 
-// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.SetTags
-func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Inspect(f func(sa1 []string)) *mGifkoskladMetaStorageMockSetTags {
-	if mmSetTags.mock.inspectFuncSetTags != nil {
-		mmSetTags.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.SetTags")
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.GetTagsIndex
+func (mmGetTagsIndex *mGifkoskladMetaStorageMockGetTagsIndex) Inspect(f func()) *mGifkoskladMetaStorageMockGetTagsIndex {
+	if mmGetTagsIndex.mock.inspectFuncGetTagsIndex != nil {
+		mmGetTagsIndex.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.GetTagsIndex")
 	}
 
-	mmSetTags.mock.inspectFuncSetTags = f
+	mmGetTagsIndex.mock.inspectFuncGetTagsIndex = f
 
-	return mmSetTags
+	return mmGetTagsIndex
 }
 
-// Return sets up results that will be returned by GifkoskladMetaStorage.SetTags
-func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Return() *GifkoskladMetaStorageMock {
-	if mmSetTags.mock.funcSetTags != nil {
-		mmSetTags.mock.t.Fatalf("GifkoskladMetaStorageMock.SetTags mock is already set by Set")
+// Return sets up results that will be returned by GifkoskladMetaStorage.GetTagsIndex
+func (mmGetTagsIndex *mGifkoskladMetaStorageMockGetTagsIndex) Return(tpa1 []*storage.TagsIndexMessage) *GifkoskladMetaStorageMock {
+	if mmGetTagsIndex.mock.funcGetTagsIndex != nil {
+		mmGetTagsIndex.mock.t.Fatalf("GifkoskladMetaStorageMock.GetTagsIndex mock is already set by Set")
 	}
 
-	if mmSetTags.defaultExpectation == nil {
-		mmSetTags.defaultExpectation = &GifkoskladMetaStorageMockSetTagsExpectation{mock: mmSetTags.mock}
+	if mmGetTagsIndex.defaultExpectation == nil {
+		mmGetTagsIndex.defaultExpectation = &GifkoskladMetaStorageMockGetTagsIndexExpectation{mock: mmGetTagsIndex.mock}
 	}
-
-	return mmSetTags.mock
+	mmGetTagsIndex.defaultExpectation.results = &GifkoskladMetaStorageMockGetTagsIndexResults{tpa1}
+	return mmGetTagsIndex.mock
 }
 
-//Set uses given function f to mock the GifkoskladMetaStorage.SetTags method
-func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Set(f func(sa1 []string)) *GifkoskladMetaStorageMock {
-	if mmSetTags.defaultExpectation != nil {
-		mmSetTags.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.SetTags method")
+// Set uses given function f to mock the GifkoskladMetaStorage.GetTagsIndex method
+func (mmGetTagsIndex *mGifkoskladMetaStorageMockGetTagsIndex) Set(f func() (tpa1 []*storage.TagsIndexMessage)) *GifkoskladMetaStorageMock {
+	if mmGetTagsIndex.defaultExpectation != nil {
+		mmGetTagsIndex.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.GetTagsIndex method")
 	}
 
-	if len(mmSetTags.expectations) > 0 {
-		mmSetTags.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.SetTags method")
+	if len(mmGetTagsIndex.expectations) > 0 {
+		mmGetTagsIndex.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.GetTagsIndex method")
 	}
 
-	mmSetTags.mock.funcSetTags = f
-	return mmSetTags.mock
+	mmGetTagsIndex.mock.funcGetTagsIndex = f
+	return mmGetTagsIndex.mock
 }
 
-// SetTags implements GifkoskladMetaStorage
-func (mmSetTags *GifkoskladMetaStorageMock) SetTags(sa1 []string) {
-	mm_atomic.AddUint64(&mmSetTags.beforeSetTagsCounter, 1)
-	defer mm_atomic.AddUint64(&mmSetTags.afterSetTagsCounter, 1)
+// GetTagsIndex implements GifkoskladMetaStorage
+func (mmGetTagsIndex *GifkoskladMetaStorageMock) GetTagsIndex() (tpa1 []*storage.TagsIndexMessage) {
+	mm_atomic.AddUint64(&mmGetTagsIndex.beforeGetTagsIndexCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetTagsIndex.afterGetTagsIndexCounter, 1)
 
-	if mmSetTags.inspectFuncSetTags != nil {
-		mmSetTags.inspectFuncSetTags(sa1)
+	if mmGetTagsIndex.inspectFuncGetTagsIndex != nil {
+		mmGetTagsIndex.inspectFuncGetTagsIndex()
 	}
 
-	mm_params := &GifkoskladMetaStorageMockSetTagsParams{sa1}
-
-	// Record call args
-	mmSetTags.SetTagsMock.mutex.Lock()
-	mmSetTags.SetTagsMock.callArgs = append(mmSetTags.SetTagsMock.callArgs, mm_params)
-	mmSetTags.SetTagsMock.mutex.Unlock()
+	if mmGetTagsIndex.GetTagsIndexMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetTagsIndex.GetTagsIndexMock.defaultExpectation.Counter, 1)
 
-	for _, e := range mmSetTags.SetTagsMock.expectations {
-		if minimock.Equal(e.params, mm_params) {
-			mm_atomic.AddUint64(&e.Counter, 1)
-			return
+		mm_results := mmGetTagsIndex.GetTagsIndexMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetTagsIndex.t.Fatal("No results are set for the GifkoskladMetaStorageMock.GetTagsIndex")
 		}
+		return (*mm_results).tpa1
 	}
+	if mmGetTagsIndex.funcGetTagsIndex != nil {
+		return mmGetTagsIndex.funcGetTagsIndex()
+	}
+	mmGetTagsIndex.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.GetTagsIndex.")
+	return
+}
 
-	if mmSetTags.SetTagsMock.defaultExpectation != nil {
+// GetTagsIndexAfterCounter returns a count of finished GifkoskladMetaStorageMock.GetTagsIndex invocations
+func (mmGetTagsIndex *GifkoskladMetaStorageMock) GetTagsIndexAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTagsIndex.afterGetTagsIndexCounter)
+}
+
+// GetTagsIndexBeforeCounter returns a count of GifkoskladMetaStorageMock.GetTagsIndex invocations
+func (mmGetTagsIndex *GifkoskladMetaStorageMock) GetTagsIndexBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTagsIndex.beforeGetTagsIndexCounter)
+}
+
+// MinimockGetTagsIndexDone returns true if the count of the GetTagsIndex invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockGetTagsIndexDone() bool {
+	for _, e := range m.GetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetTagsIndexInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockGetTagsIndexInspect() {
+	for _, e := range m.GetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.GetTagsIndex")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.GetTagsIndex")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.GetTagsIndex")
+	}
+}
+
+type mGifkoskladMetaStorageMockGetUserRoles struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockGetUserRolesExpectation
+	expectations       []*GifkoskladMetaStorageMockGetUserRolesExpectation
+}
+
+// GifkoskladMetaStorageMockGetUserRolesExpectation specifies expectation struct of the GifkoskladMetaStorage.GetUserRoles
+type GifkoskladMetaStorageMockGetUserRolesExpectation struct {
+	mock *GifkoskladMetaStorageMock
+
+	results *GifkoskladMetaStorageMockGetUserRolesResults
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockGetUserRolesResults contains results of the GifkoskladMetaStorage.GetUserRoles
+type GifkoskladMetaStorageMockGetUserRolesResults struct {
+	m1 map[int]string
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.GetUserRoles
+func (mmGetUserRoles *mGifkoskladMetaStorageMockGetUserRoles) Expect() *mGifkoskladMetaStorageMockGetUserRoles {
+	if mmGetUserRoles.mock.funcGetUserRoles != nil {
+		mmGetUserRoles.mock.t.Fatalf("GifkoskladMetaStorageMock.GetUserRoles mock is already set by Set")
+	}
+
+	if mmGetUserRoles.defaultExpectation == nil {
+		mmGetUserRoles.defaultExpectation = &GifkoskladMetaStorageMockGetUserRolesExpectation{}
+	}
+
+	return mmGetUserRoles
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.GetUserRoles
+func (mmGetUserRoles *mGifkoskladMetaStorageMockGetUserRoles) Inspect(f func()) *mGifkoskladMetaStorageMockGetUserRoles {
+	if mmGetUserRoles.mock.inspectFuncGetUserRoles != nil {
+		mmGetUserRoles.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.GetUserRoles")
+	}
+
+	mmGetUserRoles.mock.inspectFuncGetUserRoles = f
+
+	return mmGetUserRoles
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.GetUserRoles
+func (mmGetUserRoles *mGifkoskladMetaStorageMockGetUserRoles) Return(m1 map[int]string) *GifkoskladMetaStorageMock {
+	if mmGetUserRoles.mock.funcGetUserRoles != nil {
+		mmGetUserRoles.mock.t.Fatalf("GifkoskladMetaStorageMock.GetUserRoles mock is already set by Set")
+	}
+
+	if mmGetUserRoles.defaultExpectation == nil {
+		mmGetUserRoles.defaultExpectation = &GifkoskladMetaStorageMockGetUserRolesExpectation{mock: mmGetUserRoles.mock}
+	}
+	mmGetUserRoles.defaultExpectation.results = &GifkoskladMetaStorageMockGetUserRolesResults{m1}
+	return mmGetUserRoles.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.GetUserRoles method
+func (mmGetUserRoles *mGifkoskladMetaStorageMockGetUserRoles) Set(f func() (m1 map[int]string)) *GifkoskladMetaStorageMock {
+	if mmGetUserRoles.defaultExpectation != nil {
+		mmGetUserRoles.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.GetUserRoles method")
+	}
+
+	if len(mmGetUserRoles.expectations) > 0 {
+		mmGetUserRoles.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.GetUserRoles method")
+	}
+
+	mmGetUserRoles.mock.funcGetUserRoles = f
+	return mmGetUserRoles.mock
+}
+
+// GetUserRoles implements GifkoskladMetaStorage
+func (mmGetUserRoles *GifkoskladMetaStorageMock) GetUserRoles() (m1 map[int]string) {
+	mm_atomic.AddUint64(&mmGetUserRoles.beforeGetUserRolesCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetUserRoles.afterGetUserRolesCounter, 1)
+
+	if mmGetUserRoles.inspectFuncGetUserRoles != nil {
+		mmGetUserRoles.inspectFuncGetUserRoles()
+	}
+
+	if mmGetUserRoles.GetUserRolesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetUserRoles.GetUserRolesMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetUserRoles.GetUserRolesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetUserRoles.t.Fatal("No results are set for the GifkoskladMetaStorageMock.GetUserRoles")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetUserRoles.funcGetUserRoles != nil {
+		return mmGetUserRoles.funcGetUserRoles()
+	}
+	mmGetUserRoles.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.GetUserRoles.")
+	return
+}
+
+// GetUserRolesAfterCounter returns a count of finished GifkoskladMetaStorageMock.GetUserRoles invocations
+func (mmGetUserRoles *GifkoskladMetaStorageMock) GetUserRolesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetUserRoles.afterGetUserRolesCounter)
+}
+
+// GetUserRolesBeforeCounter returns a count of GifkoskladMetaStorageMock.GetUserRoles invocations
+func (mmGetUserRoles *GifkoskladMetaStorageMock) GetUserRolesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetUserRoles.beforeGetUserRolesCounter)
+}
+
+// MinimockGetUserRolesDone returns true if the count of the GetUserRoles invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockGetUserRolesDone() bool {
+	for _, e := range m.GetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetUserRoles != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetUserRolesInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockGetUserRolesInspect() {
+	for _, e := range m.GetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.GetUserRoles")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.GetUserRoles")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetUserRoles != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.GetUserRoles")
+	}
+}
+
+type mGifkoskladMetaStorageMockSetPendingTypos struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockSetPendingTyposExpectation
+	expectations       []*GifkoskladMetaStorageMockSetPendingTyposExpectation
+
+	callArgs []*GifkoskladMetaStorageMockSetPendingTyposParams
+	mutex    sync.RWMutex
+}
+
+// GifkoskladMetaStorageMockSetPendingTyposExpectation specifies expectation struct of the GifkoskladMetaStorage.SetPendingTypos
+type GifkoskladMetaStorageMockSetPendingTyposExpectation struct {
+	mock   *GifkoskladMetaStorageMock
+	params *GifkoskladMetaStorageMockSetPendingTyposParams
+
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockSetPendingTyposParams contains parameters of the GifkoskladMetaStorage.SetPendingTypos
+type GifkoskladMetaStorageMockSetPendingTyposParams struct {
+	m1 map[string]*storage.PendingTypos
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.SetPendingTypos
+func (mmSetPendingTypos *mGifkoskladMetaStorageMockSetPendingTypos) Expect(m1 map[string]*storage.PendingTypos) *mGifkoskladMetaStorageMockSetPendingTypos {
+	if mmSetPendingTypos.mock.funcSetPendingTypos != nil {
+		mmSetPendingTypos.mock.t.Fatalf("GifkoskladMetaStorageMock.SetPendingTypos mock is already set by Set")
+	}
+
+	if mmSetPendingTypos.defaultExpectation == nil {
+		mmSetPendingTypos.defaultExpectation = &GifkoskladMetaStorageMockSetPendingTyposExpectation{}
+	}
+
+	mmSetPendingTypos.defaultExpectation.params = &GifkoskladMetaStorageMockSetPendingTyposParams{m1}
+	for _, e := range mmSetPendingTypos.expectations {
+		if minimock.Equal(e.params, mmSetPendingTypos.defaultExpectation.params) {
+			mmSetPendingTypos.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetPendingTypos.defaultExpectation.params)
+		}
+	}
+
+	return mmSetPendingTypos
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.SetPendingTypos
+func (mmSetPendingTypos *mGifkoskladMetaStorageMockSetPendingTypos) Inspect(f func(m1 map[string]*storage.PendingTypos)) *mGifkoskladMetaStorageMockSetPendingTypos {
+	if mmSetPendingTypos.mock.inspectFuncSetPendingTypos != nil {
+		mmSetPendingTypos.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.SetPendingTypos")
+	}
+
+	mmSetPendingTypos.mock.inspectFuncSetPendingTypos = f
+
+	return mmSetPendingTypos
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.SetPendingTypos
+func (mmSetPendingTypos *mGifkoskladMetaStorageMockSetPendingTypos) Return() *GifkoskladMetaStorageMock {
+	if mmSetPendingTypos.mock.funcSetPendingTypos != nil {
+		mmSetPendingTypos.mock.t.Fatalf("GifkoskladMetaStorageMock.SetPendingTypos mock is already set by Set")
+	}
+
+	if mmSetPendingTypos.defaultExpectation == nil {
+		mmSetPendingTypos.defaultExpectation = &GifkoskladMetaStorageMockSetPendingTyposExpectation{mock: mmSetPendingTypos.mock}
+	}
+
+	return mmSetPendingTypos.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.SetPendingTypos method
+func (mmSetPendingTypos *mGifkoskladMetaStorageMockSetPendingTypos) Set(f func(m1 map[string]*storage.PendingTypos)) *GifkoskladMetaStorageMock {
+	if mmSetPendingTypos.defaultExpectation != nil {
+		mmSetPendingTypos.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.SetPendingTypos method")
+	}
+
+	if len(mmSetPendingTypos.expectations) > 0 {
+		mmSetPendingTypos.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.SetPendingTypos method")
+	}
+
+	mmSetPendingTypos.mock.funcSetPendingTypos = f
+	return mmSetPendingTypos.mock
+}
+
+// SetPendingTypos implements GifkoskladMetaStorage
+func (mmSetPendingTypos *GifkoskladMetaStorageMock) SetPendingTypos(m1 map[string]*storage.PendingTypos) {
+	mm_atomic.AddUint64(&mmSetPendingTypos.beforeSetPendingTyposCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetPendingTypos.afterSetPendingTyposCounter, 1)
+
+	if mmSetPendingTypos.inspectFuncSetPendingTypos != nil {
+		mmSetPendingTypos.inspectFuncSetPendingTypos(m1)
+	}
+
+	mm_params := &GifkoskladMetaStorageMockSetPendingTyposParams{m1}
+
+	// Record call args
+	mmSetPendingTypos.SetPendingTyposMock.mutex.Lock()
+	mmSetPendingTypos.SetPendingTyposMock.callArgs = append(mmSetPendingTypos.SetPendingTyposMock.callArgs, mm_params)
+	mmSetPendingTypos.SetPendingTyposMock.mutex.Unlock()
+
+	for _, e := range mmSetPendingTypos.SetPendingTyposMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetPendingTypos.SetPendingTyposMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetPendingTypos.SetPendingTyposMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetPendingTypos.SetPendingTyposMock.defaultExpectation.params
+		mm_got := GifkoskladMetaStorageMockSetPendingTyposParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetPendingTypos.t.Errorf("GifkoskladMetaStorageMock.SetPendingTypos got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetPendingTypos.funcSetPendingTypos != nil {
+		mmSetPendingTypos.funcSetPendingTypos(m1)
+		return
+	}
+	mmSetPendingTypos.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.SetPendingTypos. %v", m1)
+
+}
+
+// SetPendingTyposAfterCounter returns a count of finished GifkoskladMetaStorageMock.SetPendingTypos invocations
+func (mmSetPendingTypos *GifkoskladMetaStorageMock) SetPendingTyposAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetPendingTypos.afterSetPendingTyposCounter)
+}
+
+// SetPendingTyposBeforeCounter returns a count of GifkoskladMetaStorageMock.SetPendingTypos invocations
+func (mmSetPendingTypos *GifkoskladMetaStorageMock) SetPendingTyposBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetPendingTypos.beforeSetPendingTyposCounter)
+}
+
+// Calls returns a list of arguments used in each call to GifkoskladMetaStorageMock.SetPendingTypos.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetPendingTypos *mGifkoskladMetaStorageMockSetPendingTypos) Calls() []*GifkoskladMetaStorageMockSetPendingTyposParams {
+	mmSetPendingTypos.mutex.RLock()
+
+	argCopy := make([]*GifkoskladMetaStorageMockSetPendingTyposParams, len(mmSetPendingTypos.callArgs))
+	copy(argCopy, mmSetPendingTypos.callArgs)
+
+	mmSetPendingTypos.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetPendingTyposDone returns true if the count of the SetPendingTypos invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockSetPendingTyposDone() bool {
+	for _, e := range m.SetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetPendingTyposInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockSetPendingTyposInspect() {
+	for _, e := range m.SetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.SetPendingTypos with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		if m.SetPendingTyposMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.SetPendingTypos")
+		} else {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.SetPendingTypos with params: %#v", *m.SetPendingTyposMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.SetPendingTypos")
+	}
+}
+
+type mGifkoskladMetaStorageMockSetTags struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockSetTagsExpectation
+	expectations       []*GifkoskladMetaStorageMockSetTagsExpectation
+
+	callArgs []*GifkoskladMetaStorageMockSetTagsParams
+	mutex    sync.RWMutex
+}
+
+// GifkoskladMetaStorageMockSetTagsExpectation specifies expectation struct of the GifkoskladMetaStorage.SetTags
+type GifkoskladMetaStorageMockSetTagsExpectation struct {
+	mock   *GifkoskladMetaStorageMock
+	params *GifkoskladMetaStorageMockSetTagsParams
+
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockSetTagsParams contains parameters of the GifkoskladMetaStorage.SetTags
+type GifkoskladMetaStorageMockSetTagsParams struct {
+	sa1 []string
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.SetTags
+func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Expect(sa1 []string) *mGifkoskladMetaStorageMockSetTags {
+	if mmSetTags.mock.funcSetTags != nil {
+		mmSetTags.mock.t.Fatalf("GifkoskladMetaStorageMock.SetTags mock is already set by Set")
+	}
+
+	if mmSetTags.defaultExpectation == nil {
+		mmSetTags.defaultExpectation = &GifkoskladMetaStorageMockSetTagsExpectation{}
+	}
+
+	mmSetTags.defaultExpectation.params = &GifkoskladMetaStorageMockSetTagsParams{sa1}
+	for _, e := range mmSetTags.expectations {
+		if minimock.Equal(e.params, mmSetTags.defaultExpectation.params) {
+			mmSetTags.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetTags.defaultExpectation.params)
+		}
+	}
+
+	return mmSetTags
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.SetTags
+func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Inspect(f func(sa1 []string)) *mGifkoskladMetaStorageMockSetTags {
+	if mmSetTags.mock.inspectFuncSetTags != nil {
+		mmSetTags.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.SetTags")
+	}
+
+	mmSetTags.mock.inspectFuncSetTags = f
+
+	return mmSetTags
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.SetTags
+func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Return() *GifkoskladMetaStorageMock {
+	if mmSetTags.mock.funcSetTags != nil {
+		mmSetTags.mock.t.Fatalf("GifkoskladMetaStorageMock.SetTags mock is already set by Set")
+	}
+
+	if mmSetTags.defaultExpectation == nil {
+		mmSetTags.defaultExpectation = &GifkoskladMetaStorageMockSetTagsExpectation{mock: mmSetTags.mock}
+	}
+
+	return mmSetTags.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.SetTags method
+func (mmSetTags *mGifkoskladMetaStorageMockSetTags) Set(f func(sa1 []string)) *GifkoskladMetaStorageMock {
+	if mmSetTags.defaultExpectation != nil {
+		mmSetTags.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.SetTags method")
+	}
+
+	if len(mmSetTags.expectations) > 0 {
+		mmSetTags.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.SetTags method")
+	}
+
+	mmSetTags.mock.funcSetTags = f
+	return mmSetTags.mock
+}
+
+// SetTags implements GifkoskladMetaStorage
+func (mmSetTags *GifkoskladMetaStorageMock) SetTags(sa1 []string) {
+	mm_atomic.AddUint64(&mmSetTags.beforeSetTagsCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetTags.afterSetTagsCounter, 1)
+
+	if mmSetTags.inspectFuncSetTags != nil {
+		mmSetTags.inspectFuncSetTags(sa1)
+	}
+
+	mm_params := &GifkoskladMetaStorageMockSetTagsParams{sa1}
+
+	// Record call args
+	mmSetTags.SetTagsMock.mutex.Lock()
+	mmSetTags.SetTagsMock.callArgs = append(mmSetTags.SetTagsMock.callArgs, mm_params)
+	mmSetTags.SetTagsMock.mutex.Unlock()
+
+	for _, e := range mmSetTags.SetTagsMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetTags.SetTagsMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmSetTags.SetTagsMock.defaultExpectation.Counter, 1)
 		mm_want := mmSetTags.SetTagsMock.defaultExpectation.params
 		mm_got := GifkoskladMetaStorageMockSetTagsParams{sa1}
@@ -948,7 +1811,7 @@ func (mmSetTagsAliases *mGifkoskladMetaStorageMockSetTagsAliases) Return() *Gifk
 	return mmSetTagsAliases.mock
 }
 
-//Set uses given function f to mock the GifkoskladMetaStorage.SetTagsAliases method
+// Set uses given function f to mock the GifkoskladMetaStorage.SetTagsAliases method
 func (mmSetTagsAliases *mGifkoskladMetaStorageMockSetTagsAliases) Set(f func(m1 map[string]string)) *GifkoskladMetaStorageMock {
 	if mmSetTagsAliases.defaultExpectation != nil {
 		mmSetTagsAliases.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.SetTagsAliases method")
@@ -1069,20 +1932,408 @@ func (m *GifkoskladMetaStorageMock) MinimockSetTagsAliasesInspect() {
 	}
 }
 
+type mGifkoskladMetaStorageMockSetTagsIndex struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockSetTagsIndexExpectation
+	expectations       []*GifkoskladMetaStorageMockSetTagsIndexExpectation
+
+	callArgs []*GifkoskladMetaStorageMockSetTagsIndexParams
+	mutex    sync.RWMutex
+}
+
+// GifkoskladMetaStorageMockSetTagsIndexExpectation specifies expectation struct of the GifkoskladMetaStorage.SetTagsIndex
+type GifkoskladMetaStorageMockSetTagsIndexExpectation struct {
+	mock   *GifkoskladMetaStorageMock
+	params *GifkoskladMetaStorageMockSetTagsIndexParams
+
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockSetTagsIndexParams contains parameters of the GifkoskladMetaStorage.SetTagsIndex
+type GifkoskladMetaStorageMockSetTagsIndexParams struct {
+	tpa1 []*storage.TagsIndexMessage
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.SetTagsIndex
+func (mmSetTagsIndex *mGifkoskladMetaStorageMockSetTagsIndex) Expect(tpa1 []*storage.TagsIndexMessage) *mGifkoskladMetaStorageMockSetTagsIndex {
+	if mmSetTagsIndex.mock.funcSetTagsIndex != nil {
+		mmSetTagsIndex.mock.t.Fatalf("GifkoskladMetaStorageMock.SetTagsIndex mock is already set by Set")
+	}
+
+	if mmSetTagsIndex.defaultExpectation == nil {
+		mmSetTagsIndex.defaultExpectation = &GifkoskladMetaStorageMockSetTagsIndexExpectation{}
+	}
+
+	mmSetTagsIndex.defaultExpectation.params = &GifkoskladMetaStorageMockSetTagsIndexParams{tpa1}
+	for _, e := range mmSetTagsIndex.expectations {
+		if minimock.Equal(e.params, mmSetTagsIndex.defaultExpectation.params) {
+			mmSetTagsIndex.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetTagsIndex.defaultExpectation.params)
+		}
+	}
+
+	return mmSetTagsIndex
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.SetTagsIndex
+func (mmSetTagsIndex *mGifkoskladMetaStorageMockSetTagsIndex) Inspect(f func(tpa1 []*storage.TagsIndexMessage)) *mGifkoskladMetaStorageMockSetTagsIndex {
+	if mmSetTagsIndex.mock.inspectFuncSetTagsIndex != nil {
+		mmSetTagsIndex.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.SetTagsIndex")
+	}
+
+	mmSetTagsIndex.mock.inspectFuncSetTagsIndex = f
+
+	return mmSetTagsIndex
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.SetTagsIndex
+func (mmSetTagsIndex *mGifkoskladMetaStorageMockSetTagsIndex) Return() *GifkoskladMetaStorageMock {
+	if mmSetTagsIndex.mock.funcSetTagsIndex != nil {
+		mmSetTagsIndex.mock.t.Fatalf("GifkoskladMetaStorageMock.SetTagsIndex mock is already set by Set")
+	}
+
+	if mmSetTagsIndex.defaultExpectation == nil {
+		mmSetTagsIndex.defaultExpectation = &GifkoskladMetaStorageMockSetTagsIndexExpectation{mock: mmSetTagsIndex.mock}
+	}
+
+	return mmSetTagsIndex.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.SetTagsIndex method
+func (mmSetTagsIndex *mGifkoskladMetaStorageMockSetTagsIndex) Set(f func(tpa1 []*storage.TagsIndexMessage)) *GifkoskladMetaStorageMock {
+	if mmSetTagsIndex.defaultExpectation != nil {
+		mmSetTagsIndex.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.SetTagsIndex method")
+	}
+
+	if len(mmSetTagsIndex.expectations) > 0 {
+		mmSetTagsIndex.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.SetTagsIndex method")
+	}
+
+	mmSetTagsIndex.mock.funcSetTagsIndex = f
+	return mmSetTagsIndex.mock
+}
+
+// SetTagsIndex implements GifkoskladMetaStorage
+func (mmSetTagsIndex *GifkoskladMetaStorageMock) SetTagsIndex(tpa1 []*storage.TagsIndexMessage) {
+	mm_atomic.AddUint64(&mmSetTagsIndex.beforeSetTagsIndexCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetTagsIndex.afterSetTagsIndexCounter, 1)
+
+	if mmSetTagsIndex.inspectFuncSetTagsIndex != nil {
+		mmSetTagsIndex.inspectFuncSetTagsIndex(tpa1)
+	}
+
+	mm_params := &GifkoskladMetaStorageMockSetTagsIndexParams{tpa1}
+
+	// Record call args
+	mmSetTagsIndex.SetTagsIndexMock.mutex.Lock()
+	mmSetTagsIndex.SetTagsIndexMock.callArgs = append(mmSetTagsIndex.SetTagsIndexMock.callArgs, mm_params)
+	mmSetTagsIndex.SetTagsIndexMock.mutex.Unlock()
+
+	for _, e := range mmSetTagsIndex.SetTagsIndexMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetTagsIndex.SetTagsIndexMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetTagsIndex.SetTagsIndexMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetTagsIndex.SetTagsIndexMock.defaultExpectation.params
+		mm_got := GifkoskladMetaStorageMockSetTagsIndexParams{tpa1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetTagsIndex.t.Errorf("GifkoskladMetaStorageMock.SetTagsIndex got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetTagsIndex.funcSetTagsIndex != nil {
+		mmSetTagsIndex.funcSetTagsIndex(tpa1)
+		return
+	}
+	mmSetTagsIndex.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.SetTagsIndex. %v", tpa1)
+
+}
+
+// SetTagsIndexAfterCounter returns a count of finished GifkoskladMetaStorageMock.SetTagsIndex invocations
+func (mmSetTagsIndex *GifkoskladMetaStorageMock) SetTagsIndexAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTagsIndex.afterSetTagsIndexCounter)
+}
+
+// SetTagsIndexBeforeCounter returns a count of GifkoskladMetaStorageMock.SetTagsIndex invocations
+func (mmSetTagsIndex *GifkoskladMetaStorageMock) SetTagsIndexBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTagsIndex.beforeSetTagsIndexCounter)
+}
+
+// Calls returns a list of arguments used in each call to GifkoskladMetaStorageMock.SetTagsIndex.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetTagsIndex *mGifkoskladMetaStorageMockSetTagsIndex) Calls() []*GifkoskladMetaStorageMockSetTagsIndexParams {
+	mmSetTagsIndex.mutex.RLock()
+
+	argCopy := make([]*GifkoskladMetaStorageMockSetTagsIndexParams, len(mmSetTagsIndex.callArgs))
+	copy(argCopy, mmSetTagsIndex.callArgs)
+
+	mmSetTagsIndex.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetTagsIndexDone returns true if the count of the SetTagsIndex invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockSetTagsIndexDone() bool {
+	for _, e := range m.SetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetTagsIndexInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockSetTagsIndexInspect() {
+	for _, e := range m.SetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.SetTagsIndex with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		if m.SetTagsIndexMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.SetTagsIndex")
+		} else {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.SetTagsIndex with params: %#v", *m.SetTagsIndexMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.SetTagsIndex")
+	}
+}
+
+type mGifkoskladMetaStorageMockSetUserRoles struct {
+	mock               *GifkoskladMetaStorageMock
+	defaultExpectation *GifkoskladMetaStorageMockSetUserRolesExpectation
+	expectations       []*GifkoskladMetaStorageMockSetUserRolesExpectation
+
+	callArgs []*GifkoskladMetaStorageMockSetUserRolesParams
+	mutex    sync.RWMutex
+}
+
+// GifkoskladMetaStorageMockSetUserRolesExpectation specifies expectation struct of the GifkoskladMetaStorage.SetUserRoles
+type GifkoskladMetaStorageMockSetUserRolesExpectation struct {
+	mock   *GifkoskladMetaStorageMock
+	params *GifkoskladMetaStorageMockSetUserRolesParams
+
+	Counter uint64
+}
+
+// GifkoskladMetaStorageMockSetUserRolesParams contains parameters of the GifkoskladMetaStorage.SetUserRoles
+type GifkoskladMetaStorageMockSetUserRolesParams struct {
+	m1 map[int]string
+}
+
+// Expect sets up expected params for GifkoskladMetaStorage.SetUserRoles
+func (mmSetUserRoles *mGifkoskladMetaStorageMockSetUserRoles) Expect(m1 map[int]string) *mGifkoskladMetaStorageMockSetUserRoles {
+	if mmSetUserRoles.mock.funcSetUserRoles != nil {
+		mmSetUserRoles.mock.t.Fatalf("GifkoskladMetaStorageMock.SetUserRoles mock is already set by Set")
+	}
+
+	if mmSetUserRoles.defaultExpectation == nil {
+		mmSetUserRoles.defaultExpectation = &GifkoskladMetaStorageMockSetUserRolesExpectation{}
+	}
+
+	mmSetUserRoles.defaultExpectation.params = &GifkoskladMetaStorageMockSetUserRolesParams{m1}
+	for _, e := range mmSetUserRoles.expectations {
+		if minimock.Equal(e.params, mmSetUserRoles.defaultExpectation.params) {
+			mmSetUserRoles.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetUserRoles.defaultExpectation.params)
+		}
+	}
+
+	return mmSetUserRoles
+}
+
+// Inspect accepts an inspector function that has same arguments as the GifkoskladMetaStorage.SetUserRoles
+func (mmSetUserRoles *mGifkoskladMetaStorageMockSetUserRoles) Inspect(f func(m1 map[int]string)) *mGifkoskladMetaStorageMockSetUserRoles {
+	if mmSetUserRoles.mock.inspectFuncSetUserRoles != nil {
+		mmSetUserRoles.mock.t.Fatalf("Inspect function is already set for GifkoskladMetaStorageMock.SetUserRoles")
+	}
+
+	mmSetUserRoles.mock.inspectFuncSetUserRoles = f
+
+	return mmSetUserRoles
+}
+
+// Return sets up results that will be returned by GifkoskladMetaStorage.SetUserRoles
+func (mmSetUserRoles *mGifkoskladMetaStorageMockSetUserRoles) Return() *GifkoskladMetaStorageMock {
+	if mmSetUserRoles.mock.funcSetUserRoles != nil {
+		mmSetUserRoles.mock.t.Fatalf("GifkoskladMetaStorageMock.SetUserRoles mock is already set by Set")
+	}
+
+	if mmSetUserRoles.defaultExpectation == nil {
+		mmSetUserRoles.defaultExpectation = &GifkoskladMetaStorageMockSetUserRolesExpectation{mock: mmSetUserRoles.mock}
+	}
+
+	return mmSetUserRoles.mock
+}
+
+// Set uses given function f to mock the GifkoskladMetaStorage.SetUserRoles method
+func (mmSetUserRoles *mGifkoskladMetaStorageMockSetUserRoles) Set(f func(m1 map[int]string)) *GifkoskladMetaStorageMock {
+	if mmSetUserRoles.defaultExpectation != nil {
+		mmSetUserRoles.mock.t.Fatalf("Default expectation is already set for the GifkoskladMetaStorage.SetUserRoles method")
+	}
+
+	if len(mmSetUserRoles.expectations) > 0 {
+		mmSetUserRoles.mock.t.Fatalf("Some expectations are already set for the GifkoskladMetaStorage.SetUserRoles method")
+	}
+
+	mmSetUserRoles.mock.funcSetUserRoles = f
+	return mmSetUserRoles.mock
+}
+
+// SetUserRoles implements GifkoskladMetaStorage
+func (mmSetUserRoles *GifkoskladMetaStorageMock) SetUserRoles(m1 map[int]string) {
+	mm_atomic.AddUint64(&mmSetUserRoles.beforeSetUserRolesCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetUserRoles.afterSetUserRolesCounter, 1)
+
+	if mmSetUserRoles.inspectFuncSetUserRoles != nil {
+		mmSetUserRoles.inspectFuncSetUserRoles(m1)
+	}
+
+	mm_params := &GifkoskladMetaStorageMockSetUserRolesParams{m1}
+
+	// Record call args
+	mmSetUserRoles.SetUserRolesMock.mutex.Lock()
+	mmSetUserRoles.SetUserRolesMock.callArgs = append(mmSetUserRoles.SetUserRolesMock.callArgs, mm_params)
+	mmSetUserRoles.SetUserRolesMock.mutex.Unlock()
+
+	for _, e := range mmSetUserRoles.SetUserRolesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetUserRoles.SetUserRolesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetUserRoles.SetUserRolesMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetUserRoles.SetUserRolesMock.defaultExpectation.params
+		mm_got := GifkoskladMetaStorageMockSetUserRolesParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetUserRoles.t.Errorf("GifkoskladMetaStorageMock.SetUserRoles got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetUserRoles.funcSetUserRoles != nil {
+		mmSetUserRoles.funcSetUserRoles(m1)
+		return
+	}
+	mmSetUserRoles.t.Fatalf("Unexpected call to GifkoskladMetaStorageMock.SetUserRoles. %v", m1)
+
+}
+
+// SetUserRolesAfterCounter returns a count of finished GifkoskladMetaStorageMock.SetUserRoles invocations
+func (mmSetUserRoles *GifkoskladMetaStorageMock) SetUserRolesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetUserRoles.afterSetUserRolesCounter)
+}
+
+// SetUserRolesBeforeCounter returns a count of GifkoskladMetaStorageMock.SetUserRoles invocations
+func (mmSetUserRoles *GifkoskladMetaStorageMock) SetUserRolesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetUserRoles.beforeSetUserRolesCounter)
+}
+
+// Calls returns a list of arguments used in each call to GifkoskladMetaStorageMock.SetUserRoles.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetUserRoles *mGifkoskladMetaStorageMockSetUserRoles) Calls() []*GifkoskladMetaStorageMockSetUserRolesParams {
+	mmSetUserRoles.mutex.RLock()
+
+	argCopy := make([]*GifkoskladMetaStorageMockSetUserRolesParams, len(mmSetUserRoles.callArgs))
+	copy(argCopy, mmSetUserRoles.callArgs)
+
+	mmSetUserRoles.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetUserRolesDone returns true if the count of the SetUserRoles invocations corresponds
+// the number of defined expectations
+func (m *GifkoskladMetaStorageMock) MinimockSetUserRolesDone() bool {
+	for _, e := range m.SetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetUserRoles != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetUserRolesInspect logs each unmet expectation
+func (m *GifkoskladMetaStorageMock) MinimockSetUserRolesInspect() {
+	for _, e := range m.SetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.SetUserRoles with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		if m.SetUserRolesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to GifkoskladMetaStorageMock.SetUserRoles")
+		} else {
+			m.t.Errorf("Expected call to GifkoskladMetaStorageMock.SetUserRoles with params: %#v", *m.SetUserRolesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetUserRoles != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		m.t.Error("Expected call to GifkoskladMetaStorageMock.SetUserRoles")
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *GifkoskladMetaStorageMock) MinimockFinish() {
 	if !m.minimockDone() {
 		m.MinimockAddSentAnimationsInspect()
 
+		m.MinimockAddTagChangesInspect()
+
+		m.MinimockGetPendingTyposInspect()
+
 		m.MinimockGetSentAnimationsInspect()
 
 		m.MinimockGetTagsInspect()
 
 		m.MinimockGetTagsAliasesInspect()
 
+		m.MinimockGetTagsIndexInspect()
+
+		m.MinimockGetUserRolesInspect()
+
+		m.MinimockSetPendingTyposInspect()
+
 		m.MinimockSetTagsInspect()
 
 		m.MinimockSetTagsAliasesInspect()
+
+		m.MinimockSetTagsIndexInspect()
+
+		m.MinimockSetUserRolesInspect()
 		m.t.FailNow()
 	}
 }
@@ -1107,9 +2358,16 @@ func (m *GifkoskladMetaStorageMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockAddSentAnimationsDone() &&
+		m.MinimockAddTagChangesDone() &&
+		m.MinimockGetPendingTyposDone() &&
 		m.MinimockGetSentAnimationsDone() &&
 		m.MinimockGetTagsDone() &&
 		m.MinimockGetTagsAliasesDone() &&
+		m.MinimockGetTagsIndexDone() &&
+		m.MinimockGetUserRolesDone() &&
+		m.MinimockSetPendingTyposDone() &&
 		m.MinimockSetTagsDone() &&
-		m.MinimockSetTagsAliasesDone()
+		m.MinimockSetTagsAliasesDone() &&
+		m.MinimockSetTagsIndexDone() &&
+		m.MinimockSetUserRolesDone()
 }
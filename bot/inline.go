@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/cyhalothrin/gifkoskladbot/search"
+)
+
+const (
+	// inlinePageSize больше 50 результатов телега за раз не принимает
+	inlinePageSize = 50
+	// inlineCacheTime секунд, теги меняются, поэтому долго кешировать не стоит
+	inlineCacheTime = 60
+)
+
+// handleInlineQuery поиск гифок по тегам из любого чата: @gifkoskladbot #cat funny
+func (u *UpdatesHandler) handleInlineQuery(update tgbotapi.Update) (bool, error) {
+	query := update.InlineQuery
+	if query == nil {
+		return false, nil
+	}
+
+	offset := 0
+	if query.Offset != "" {
+		var err error
+		if offset, err = strconv.Atoi(query.Offset); err != nil || offset < 0 {
+			return true, fmt.Errorf("кривой offset инлайн запроса '%s'", query.Offset)
+		}
+	}
+
+	q, err := search.Parse(u.inlineQueryText(query.Query), u.normalizer)
+	if errors.Is(err, search.ErrEmptyQuery) {
+		// пустой запрос, покажем последние
+		q, err = search.All(), nil
+	}
+	if err != nil {
+		// запрос еще набирают, ошибку показывать некому, просто ничего не найдено
+		q = search.Not(search.All())
+	}
+
+	found := u.index.Search(q, search.WithOffset(offset), search.WithLimit(inlinePageSize))
+
+	results := make([]interface{}, 0, len(found))
+	for _, anim := range found {
+		results = append(results, tgbotapi.NewInlineQueryResultCachedGIF(inlineResultID(anim.FileID), anim.FileID))
+	}
+
+	nextOffset := ""
+	if len(found) == inlinePageSize {
+		nextOffset = strconv.Itoa(offset + inlinePageSize)
+	}
+
+	err = u.api.AnswerInlineQuery(tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     inlineCacheTime,
+		NextOffset:    nextOffset,
+	})
+	if err != nil {
+		return true, fmt.Errorf("ответ на инлайн запрос '%s': %w", query.Query, err)
+	}
+
+	return true, nil
+}
+
+// inlineQueryText заменяет алиасы на теги, а последнее слово, которое еще набирают, ищет по префиксу
+func (u *UpdatesHandler) inlineQueryText(text string) string {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return ""
+	}
+
+	for i, word := range words {
+		sign := ""
+		if strings.HasPrefix(word, "-") || strings.HasPrefix(word, "!") {
+			sign, word = word[:1], word[1:]
+		}
+
+		if strings.HasSuffix(word, "*") {
+			continue
+		}
+
+		// алиасы записаны нормализованными тегами
+		if normalized, ok := u.normalizer.Tag(word); ok {
+			if tag, ok := u.tagsAliases[normalized]; ok {
+				words[i] = sign + tag
+			}
+		}
+	}
+
+	last := words[len(words)-1]
+	typing := !strings.HasSuffix(text, " ") && last != "|" && last != "or" && !strings.HasSuffix(last, "*")
+	if typing {
+		words[len(words)-1] = last + "*"
+	}
+
+	return strings.Join(words, " ")
+}
+
+// inlineResultID id результата не длиннее 64 байт, fileID бывает длиннее
+func inlineResultID(fileID string) string {
+	h := fnv.New64a()
+	h.Write([]byte(fileID))
+
+	return strconv.FormatUint(h.Sum64(), 16)
+}
@@ -0,0 +1,251 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func TestUpdatesHandler_handleInlineQuery(t *testing.T) {
+	t.Parallel()
+
+	animations := map[string]*storage.SentAnimation{
+		"file_1": {FileID: "file_1", MessageID: 1, Tags: []string{"#cat", "#funny"}},
+		"file_2": {FileID: "file_2", MessageID: 2, Tags: []string{"#cat"}},
+		"file_3": {FileID: "file_3", MessageID: 3, Tags: []string{"#dog", "#funny"}},
+	}
+	many := make(map[string]*storage.SentAnimation)
+	for i := 1; i <= inlinePageSize+1; i++ {
+		fileID := fmt.Sprintf("many_%02d", i)
+		many[fileID] = &storage.SentAnimation{FileID: fileID, MessageID: i, Tags: []string{"#cat"}}
+	}
+
+	results := func(fileIDs ...string) []interface{} {
+		res := make([]interface{}, 0, len(fileIDs))
+		for _, fileID := range fileIDs {
+			res = append(res, tgbotapi.NewInlineQueryResultCachedGIF(inlineResultID(fileID), fileID))
+		}
+
+		return res
+	}
+
+	type args struct {
+		animations map[string]*storage.SentAnimation
+		aliases    map[string]string
+		update     tgbotapi.Update
+	}
+	type want struct {
+		ok     bool
+		err    bool
+		answer *tgbotapi.InlineConfig
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"should skip not inline query",
+			args{
+				animations: animations,
+				update:     tgbotapi.Update{Message: &tgbotapi.Message{Text: "#cat"}},
+			},
+			want{},
+		},
+		{
+			"should find gifs with all tags",
+			args{
+				animations: animations,
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:    "q1",
+					From:  &tgbotapi.User{UserName: "user"},
+					Query: "#cat funny ",
+				}},
+			},
+			want{
+				ok: true,
+				answer: &tgbotapi.InlineConfig{
+					InlineQueryID: "q1",
+					Results:       results("file_1"),
+					CacheTime:     inlineCacheTime,
+				},
+			},
+		},
+		{
+			"should search last word by prefix and replace aliases",
+			args{
+				animations: animations,
+				aliases:    map[string]string{"#kot": "#cat"},
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:    "q1",
+					From:  &tgbotapi.User{UserName: "user"},
+					Query: "#kot #fu",
+				}},
+			},
+			want{
+				ok: true,
+				answer: &tgbotapi.InlineConfig{
+					InlineQueryID: "q1",
+					Results:       results("file_1"),
+					CacheTime:     inlineCacheTime,
+				},
+			},
+		},
+		{
+			"should normalize query like tags",
+			args{
+				animations: map[string]*storage.SentAnimation{
+					"file_4": {FileID: "file_4", MessageID: 4, Tags: []string{"#ежик", "#в_тумане"}},
+					"file_5": {FileID: "file_5", MessageID: 5, Tags: []string{"#ежик"}},
+				},
+				aliases: map[string]string{"#туман": "#в_тумане"},
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:    "q1",
+					From:  &tgbotapi.User{UserName: "user"},
+					Query: "#Ёжик #Туман ",
+				}},
+			},
+			want{
+				ok: true,
+				answer: &tgbotapi.InlineConfig{
+					InlineQueryID: "q1",
+					Results:       results("file_4"),
+					CacheTime:     inlineCacheTime,
+				},
+			},
+		},
+		{
+			"should show recent gifs on empty query",
+			args{
+				animations: animations,
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:   "q1",
+					From: &tgbotapi.User{UserName: "user"},
+				}},
+			},
+			want{
+				ok: true,
+				answer: &tgbotapi.InlineConfig{
+					InlineQueryID: "q1",
+					Results:       results("file_3", "file_2", "file_1"),
+					CacheTime:     inlineCacheTime,
+				},
+			},
+		},
+		{
+			"should answer nothing on broken query",
+			args{
+				animations: animations,
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:    "q1",
+					From:  &tgbotapi.User{UserName: "user"},
+					Query: "#cat | ",
+				}},
+			},
+			want{
+				ok: true,
+				answer: &tgbotapi.InlineConfig{
+					InlineQueryID: "q1",
+					Results:       results(),
+					CacheTime:     inlineCacheTime,
+				},
+			},
+		},
+		{
+			"should return next offset when page is full",
+			args{
+				animations: many,
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:    "q1",
+					From:  &tgbotapi.User{UserName: "user"},
+					Query: "#cat ",
+				}},
+			},
+			want{
+				ok: true,
+				answer: func() *tgbotapi.InlineConfig {
+					var fileIDs []string
+					for i := inlinePageSize + 1; i > 1; i-- {
+						fileIDs = append(fileIDs, fmt.Sprintf("many_%02d", i))
+					}
+
+					return &tgbotapi.InlineConfig{
+						InlineQueryID: "q1",
+						Results:       results(fileIDs...),
+						CacheTime:     inlineCacheTime,
+						NextOffset:    fmt.Sprint(inlinePageSize),
+					}
+				}(),
+			},
+		},
+		{
+			"should return last page by offset",
+			args{
+				animations: many,
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:     "q1",
+					From:   &tgbotapi.User{UserName: "user"},
+					Query:  "#cat ",
+					Offset: fmt.Sprint(inlinePageSize),
+				}},
+			},
+			want{
+				ok: true,
+				answer: &tgbotapi.InlineConfig{
+					InlineQueryID: "q1",
+					Results:       results("many_01"),
+					CacheTime:     inlineCacheTime,
+				},
+			},
+		},
+		{
+			"should fail on broken offset",
+			args{
+				animations: animations,
+				update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+					ID:     "q1",
+					From:   &tgbotapi.User{UserName: "user"},
+					Query:  "#cat ",
+					Offset: "abc",
+				}},
+			},
+			want{ok: true, err: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			store := NewGifkoskladMetaStorageMock(mc).
+				GetTagsAliasesMock.Return(tt.args.aliases).
+				GetUserRolesMock.Return(nil).
+				GetPendingTyposMock.Return(nil).
+				GetSentAnimationsMock.Return(tt.args.animations).
+				GetTagsMock.Return(nil)
+			api := NewTelegramBotAPIMock(mc)
+			if tt.want.answer != nil {
+				api.AnswerInlineQueryMock.Expect(*tt.want.answer).Return(nil)
+			}
+
+			conf := config.Config{
+				AllowedUsers:     []string{"user"},
+				TagNormalization: config.TagNormalization{FoldYo: true},
+			}
+			u := NewUpdatesHandler(conf, store, nil, api)
+
+			ok, err := u.handleInlineQuery(tt.args.update)
+			assert.Equal(t, tt.want.ok, ok)
+			assert.Equal(t, tt.want.err, err != nil, err)
+		})
+	}
+}
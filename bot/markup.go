@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// markup разметка текста для шаблонов, значение - parseMode телеги, пустое для простого текста
+type markup string
+
+// parseMarkup разметка по формату из конфига: html, markdownv2 или пусто
+func parseMarkup(format string) (markup, bool) {
+	switch strings.ToLower(format) {
+	case "":
+		return "", true
+	case "html":
+		return tgbotapi.ModeHTML, true
+	case "markdownv2":
+		return tgbotapi.ModeMarkdownV2, true
+	}
+
+	return "", false
+}
+
+func (m markup) escape(text string) string {
+	switch m {
+	case tgbotapi.ModeHTML:
+		return html.EscapeString(text)
+	case tgbotapi.ModeMarkdownV2:
+		return escapeMarkdownV2(text)
+	}
+
+	return text
+}
+
+func (m markup) bold(text string) string {
+	switch m {
+	case tgbotapi.ModeHTML:
+		return "<b>" + m.escape(text) + "</b>"
+	case tgbotapi.ModeMarkdownV2:
+		return "*" + m.escape(text) + "*"
+	}
+
+	return text
+}
+
+func (m markup) italic(text string) string {
+	switch m {
+	case tgbotapi.ModeHTML:
+		return "<i>" + m.escape(text) + "</i>"
+	case tgbotapi.ModeMarkdownV2:
+		return "_" + m.escape(text) + "_"
+	}
+
+	return text
+}
+
+func (m markup) link(text, url string) string {
+	if url == "" {
+		return m.escape(text)
+	}
+
+	switch m {
+	case tgbotapi.ModeHTML:
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), m.escape(text))
+	case tgbotapi.ModeMarkdownV2:
+		url = strings.NewReplacer(`\`, `\\`, `)`, `\)`).Replace(url)
+
+		return fmt.Sprintf("[%s](%s)", m.escape(text), url)
+	}
+
+	return text
+}
+
+// escapeMarkdownV2 экранирует все, что в MarkdownV2 считается разметкой
+func escapeMarkdownV2(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune("_*[]()~`>#+-=|{}.!\\", r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,248 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// role что пользователю можно делать в боте, каждая следующая роль умеет все, что умеют предыдущие
+type role int
+
+const (
+	roleNone role = iota
+	// roleViewer только поиск гифок инлайн запросами
+	roleViewer
+	// roleTagger подписывает гифки тегами
+	roleTagger
+	// roleAdmin алиасы, команды и выдача ролей
+	roleAdmin
+)
+
+var roleNames = map[role]string{
+	roleViewer: "viewer",
+	roleTagger: "tagger",
+	roleAdmin:  "admin",
+}
+
+func (r role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+
+	return "none"
+}
+
+func parseRole(name string) (role, bool) {
+	for r, n := range roleNames {
+		if n == name {
+			return r, true
+		}
+	}
+
+	return roleNone, false
+}
+
+const roleUsage = `/role grant 123456 tagger - выдать роль пользователю с id 123456
+/role grant tagger - то же реплаем на сообщение пользователя
+/role revoke 123456 - отозвать роль
+/role list - все роли
+Роли: viewer - только поиск, tagger - поиск и теги, admin - все, включая алиасы и роли`
+
+// loadUserRoles роли из хранилища, незнакомые роли пропускаются
+func (u *UpdatesHandler) loadUserRoles() map[int]role {
+	roles := make(map[int]role)
+
+	for id, name := range u.storage.GetUserRoles() {
+		r, ok := parseRole(name)
+		if !ok {
+			log.Printf("Неизвестная роль %q у пользователя %d\n", name, id)
+
+			continue
+		}
+		roles[id] = r
+	}
+
+	return roles
+}
+
+// userRole роль из конфига важнее выданной в чате, а имена из AllowedUsers учитываются последними
+func (u *UpdatesHandler) userRole(user *tgbotapi.User) role {
+	if user == nil {
+		return roleNone
+	}
+
+	if u.adminIDs[user.ID] {
+		return roleAdmin
+	}
+	if r, ok := u.userRoles[user.ID]; ok {
+		return r
+	}
+	if user.UserName != "" && u.allowedUsers[user.UserName] {
+		return roleAdmin
+	}
+
+	return roleNone
+}
+
+// handleRoleCommand выдача ролей из чата: /role grant|revoke|list
+func (u *UpdatesHandler) handleRoleCommand(update tgbotapi.Update) (bool, error) {
+	message := update.Message
+	if message == nil || !message.IsCommand() {
+		return false, nil
+	}
+
+	args := strings.Fields(strings.ToLower(message.CommandArguments()))
+
+	var reply string
+	var err error
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		reply = u.rolesList()
+	case len(args) >= 1 && args[0] == "grant":
+		reply, err = u.grantRole(args[1:], message)
+	case len(args) >= 1 && args[0] == "revoke":
+		reply, err = u.revokeRole(args[1:], message)
+	default:
+		reply = roleUsage
+	}
+
+	if err != nil {
+		reply = err.Error()
+	}
+
+	if _, sendErr := u.api.SendMessage(message.Chat.ID, reply, ""); sendErr != nil {
+		return true, fmt.Errorf("ответ на /role: %w", sendErr)
+	}
+
+	return true, nil
+}
+
+func (u *UpdatesHandler) grantRole(args []string, message *tgbotapi.Message) (string, error) {
+	var userArgs []string
+	var roleName string
+
+	switch len(args) {
+	case 1:
+		roleName = args[0]
+	case 2:
+		userArgs, roleName = args[:1], args[1]
+	default:
+		return roleUsage, nil
+	}
+
+	r, ok := parseRole(roleName)
+	if !ok {
+		return "", fmt.Errorf("нет роли %s, есть viewer, tagger и admin", roleName)
+	}
+
+	userID, err := u.roleTarget(userArgs, message)
+	if err != nil {
+		return "", err
+	}
+
+	u.userRoles[userID] = r
+	u.saveUserRoles()
+
+	log.Printf("Пользователю %d выдана роль %s\n", userID, r)
+
+	return fmt.Sprintf("Пользователю %d выдана роль %s", userID, r), nil
+}
+
+func (u *UpdatesHandler) revokeRole(args []string, message *tgbotapi.Message) (string, error) {
+	if len(args) > 1 {
+		return roleUsage, nil
+	}
+
+	userID, err := u.roleTarget(args, message)
+	if err != nil {
+		return "", err
+	}
+
+	r, ok := u.userRoles[userID]
+	if !ok {
+		return "", fmt.Errorf("у пользователя %d нет выданной роли", userID)
+	}
+
+	delete(u.userRoles, userID)
+	u.saveUserRoles()
+
+	log.Printf("У пользователя %d отозвана роль %s\n", userID, r)
+
+	return fmt.Sprintf("У пользователя %d отозвана роль %s", userID, r), nil
+}
+
+// roleTarget id пользователя из аргумента или из сообщения, на которое ответили командой.
+// Админов из конфига и себя менять нельзя, чтобы не остаться без админа
+func (u *UpdatesHandler) roleTarget(args []string, message *tgbotapi.Message) (int, error) {
+	var userID int
+
+	switch {
+	case len(args) == 1:
+		id, err := strconv.Atoi(args[0])
+		if err != nil || id <= 0 {
+			return 0, fmt.Errorf("%s не похоже на id пользователя", args[0])
+		}
+		userID = id
+	case message.ReplyToMessage != nil && message.ReplyToMessage.From != nil:
+		userID = message.ReplyToMessage.From.ID
+	default:
+		return 0, fmt.Errorf("укажи id пользователя или ответь командой на его сообщение")
+	}
+
+	if u.adminIDs[userID] {
+		return 0, fmt.Errorf("пользователь %d админ из конфига, его роль меняется только там", userID)
+	}
+	if message.From != nil && message.From.ID == userID {
+		return 0, fmt.Errorf("свою роль менять нельзя")
+	}
+
+	return userID, nil
+}
+
+func (u *UpdatesHandler) rolesList() string {
+	lines := make([]string, 0, len(u.adminIDs)+len(u.userRoles))
+
+	adminIDs := make([]int, 0, len(u.adminIDs))
+	for id := range u.adminIDs {
+		adminIDs = append(adminIDs, id)
+	}
+	sort.Ints(adminIDs)
+
+	for _, id := range adminIDs {
+		lines = append(lines, fmt.Sprintf("%d %s (конфиг)", id, roleAdmin))
+	}
+
+	userIDs := make([]int, 0, len(u.userRoles))
+	for id := range u.userRoles {
+		if !u.adminIDs[id] {
+			userIDs = append(userIDs, id)
+		}
+	}
+	sort.Ints(userIDs)
+
+	for _, id := range userIDs {
+		lines = append(lines, fmt.Sprintf("%d %s", id, u.userRoles[id]))
+	}
+
+	if len(lines) == 0 {
+		return "Ролей нет"
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// saveUserRoles в хранилище отдаем копию, мапу ролей дальше меняет обработчик
+func (u *UpdatesHandler) saveUserRoles() {
+	roles := make(map[int]string, len(u.userRoles))
+	for id, r := range u.userRoles {
+		roles[id] = r.String()
+	}
+
+	u.storage.SetUserRoles(roles)
+}
@@ -0,0 +1,196 @@
+package bot
+
+import (
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+func TestUpdatesHandler_handleRoleCommand(t *testing.T) {
+	t.Parallel()
+
+	replyUpdate := func(text string, to *tgbotapi.User) tgbotapi.Update {
+		update := commandUpdate("user", text)
+		update.Message.ReplyToMessage = &tgbotapi.Message{MessageID: 2, From: to}
+
+		return update
+	}
+
+	type args struct {
+		roles  map[int]string
+		update tgbotapi.Update
+	}
+	type want struct {
+		ok    bool
+		reply string
+		roles map[int]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"should reply usage on unknown subcommand",
+			args{update: commandUpdate("user", "/role what")},
+			want{ok: true, reply: roleUsage},
+		},
+		{
+			"should list config admins and granted roles",
+			args{
+				roles:  map[int]string{7: "viewer", 5: "tagger", 1: "viewer"},
+				update: commandUpdate("user", "/role list"),
+			},
+			want{ok: true, reply: "1 admin (конфиг)\n5 tagger\n7 viewer"},
+		},
+		{
+			"should grant role by id",
+			args{
+				roles:  map[int]string{5: "viewer"},
+				update: commandUpdate("user", "/role grant 5 Tagger"),
+			},
+			want{
+				ok:    true,
+				reply: "Пользователю 5 выдана роль tagger",
+				roles: map[int]string{5: "tagger"},
+			},
+		},
+		{
+			"should grant role by reply",
+			args{update: replyUpdate("/role grant admin", &tgbotapi.User{ID: 9})},
+			want{
+				ok:    true,
+				reply: "Пользователю 9 выдана роль admin",
+				roles: map[int]string{9: "admin"},
+			},
+		},
+		{
+			"should not grant unknown role",
+			args{update: commandUpdate("user", "/role grant 5 owner")},
+			want{ok: true, reply: "нет роли owner, есть viewer, tagger и admin"},
+		},
+		{
+			"should not grant without user",
+			args{update: commandUpdate("user", "/role grant tagger")},
+			want{ok: true, reply: "укажи id пользователя или ответь командой на его сообщение"},
+		},
+		{
+			"should not grant to bad id",
+			args{update: commandUpdate("user", "/role grant @bob tagger")},
+			want{ok: true, reply: "@bob не похоже на id пользователя"},
+		},
+		{
+			"should not change config admin",
+			args{update: commandUpdate("user", "/role revoke 1")},
+			want{ok: true, reply: "пользователь 1 админ из конфига, его роль меняется только там"},
+		},
+		{
+			"should not change own role",
+			args{update: commandUpdate("user", "/role grant 42 viewer")},
+			want{ok: true, reply: "свою роль менять нельзя"},
+		},
+		{
+			"should revoke role",
+			args{
+				roles:  map[int]string{5: "tagger", 7: "viewer"},
+				update: commandUpdate("user", "/role revoke 5"),
+			},
+			want{
+				ok:    true,
+				reply: "У пользователя 5 отозвана роль tagger",
+				roles: map[int]string{7: "viewer"},
+			},
+		},
+		{
+			"should revoke role by reply",
+			args{
+				roles:  map[int]string{5: "tagger"},
+				update: replyUpdate("/role revoke", &tgbotapi.User{ID: 5}),
+			},
+			want{
+				ok:    true,
+				reply: "У пользователя 5 отозвана роль tagger",
+				roles: map[int]string{},
+			},
+		},
+		{
+			"should not revoke missing role",
+			args{update: commandUpdate("user", "/role revoke 5")},
+			want{ok: true, reply: "у пользователя 5 нет выданной роли"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			store := NewGifkoskladMetaStorageMock(mc).
+				GetTagsAliasesMock.Return(nil).
+				GetUserRolesMock.Return(tt.args.roles).
+				GetPendingTyposMock.Return(nil).
+				GetSentAnimationsMock.Return(nil).
+				GetTagsMock.Return(nil)
+			if tt.want.roles != nil {
+				store.SetUserRolesMock.Expect(tt.want.roles).Return()
+			}
+			api := NewTelegramBotAPIMock(mc)
+			if tt.want.reply != "" {
+				api.SendMessageMock.Expect(100, tt.want.reply, "").Return(2, nil)
+			}
+
+			u := NewUpdatesHandler(config.Config{AdminIDs: []int{1}}, store, nil, api)
+
+			ok, err := u.handleRoleCommand(tt.args.update)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.ok, ok)
+		})
+	}
+}
+
+func TestUpdatesHandler_userRole(t *testing.T) {
+	t.Parallel()
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	store := NewGifkoskladMetaStorageMock(mc).
+		GetTagsAliasesMock.Return(nil).
+		GetUserRolesMock.Return(map[int]string{1: "viewer", 2: "tagger", 3: "viewer", 4: "owner"}).
+		GetPendingTyposMock.Return(nil).
+		GetSentAnimationsMock.Return(nil).
+		GetTagsMock.Return(nil)
+	conf := config.Config{
+		AdminIDs:     []int{1},
+		AllowedUsers: []string{"legacy"},
+	}
+	u := NewUpdatesHandler(conf, store, nil, NewTelegramBotAPIMock(mc))
+
+	tests := []struct {
+		name string
+		user *tgbotapi.User
+		want role
+	}{
+		{"nobody", nil, roleNone},
+		{"config admin wins over granted role", &tgbotapi.User{ID: 1}, roleAdmin},
+		{"granted role", &tgbotapi.User{ID: 2}, roleTagger},
+		{"granted role wins over legacy username", &tgbotapi.User{ID: 3, UserName: "legacy"}, roleViewer},
+		{"legacy username", &tgbotapi.User{ID: 5, UserName: "legacy"}, roleAdmin},
+		{"unknown role in storage", &tgbotapi.User{ID: 4}, roleNone},
+		{"stranger", &tgbotapi.User{ID: 6, UserName: "stranger"}, roleNone},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, u.userRole(tt.user))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// updateHandler обработчик обновления, вернет false, если обновление ему не подходит,
+// тогда роутер отдаст его следующему обработчику маршрута
+type updateHandler func(update tgbotapi.Update) (bool, error)
+
+// middleware оборачивает обработчик: авторизация, логи, паники, алерты
+type middleware func(next updateHandler) updateHandler
+
+// route вид обновления, по нему роутер выбирает обработчики
+type route string
+
+const (
+	routeCommand     route = "command"
+	routeReply       route = "reply"
+	routeInlineQuery route = "inline_query"
+	routeCallback    route = "callback_query"
+	routeChannelPost route = "channel_post"
+)
+
+// router раскидывает обновления по обработчикам: /команды по имени, остальное по виду обновления
+type router struct {
+	commands    map[string]updateHandler
+	handlers    map[route][]updateHandler
+	middlewares []middleware
+}
+
+func newRouter() *router {
+	return &router{
+		commands: make(map[string]updateHandler),
+		handlers: make(map[route][]updateHandler),
+	}
+}
+
+// Use добавит middleware, первый добавленный будет снаружи
+func (r *router) Use(mw ...middleware) {
+	r.middlewares = append(r.middlewares, mw...)
+}
+
+// Command обработчик /команды, name без слеша
+func (r *router) Command(name string, handler updateHandler) {
+	r.commands[name] = handler
+}
+
+// Reply обработчик ответов на сообщения, в том числе отредактированных
+func (r *router) Reply(handler updateHandler) {
+	r.handlers[routeReply] = append(r.handlers[routeReply], handler)
+}
+
+func (r *router) InlineQuery(handler updateHandler) {
+	r.handlers[routeInlineQuery] = append(r.handlers[routeInlineQuery], handler)
+}
+
+func (r *router) CallbackQuery(handler updateHandler) {
+	r.handlers[routeCallback] = append(r.handlers[routeCallback], handler)
+}
+
+// ChannelPost обработчик постов в каналах, в том числе отредактированных
+func (r *router) ChannelPost(handler updateHandler) {
+	r.handlers[routeChannelPost] = append(r.handlers[routeChannelPost], handler)
+}
+
+// Handle отдаст обновление обработчикам его маршрута по очереди, пока один из них не возьмет его.
+// Вернет false, если обновление никто не взял
+func (r *router) Handle(update tgbotapi.Update) (bool, error) {
+	return r.wrap(r.dispatch)(update)
+}
+
+func (r *router) dispatch(update tgbotapi.Update) (bool, error) {
+	var handlers []updateHandler
+
+	switch kind := updateRoute(update); kind {
+	case routeCommand:
+		if handler, ok := r.commands[update.Message.Command()]; ok {
+			handlers = []updateHandler{handler}
+		}
+	case "":
+	default:
+		handlers = r.handlers[kind]
+	}
+
+	for _, handler := range handlers {
+		ok, err := handler(update)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+func (r *router) wrap(handler updateHandler) updateHandler {
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// updateRoute пустая строка, если для такого обновления маршрута нет
+func updateRoute(update tgbotapi.Update) route {
+	switch {
+	case update.Message != nil && update.Message.IsCommand():
+		return routeCommand
+	case update.Message != nil && update.Message.ReplyToMessage != nil,
+		update.EditedMessage != nil && update.EditedMessage.ReplyToMessage != nil:
+		return routeReply
+	case update.InlineQuery != nil:
+		return routeInlineQuery
+	case update.CallbackQuery != nil:
+		return routeCallback
+	case update.ChannelPost != nil, update.EditedChannelPost != nil:
+		return routeChannelPost
+	}
+
+	return ""
+}
+
+// updateSender пользователь, от которого пришло обновление, nil для постов в каналах
+func updateSender(update tgbotapi.Update) *tgbotapi.User {
+	switch {
+	case update.Message != nil:
+		return update.Message.From
+	case update.EditedMessage != nil:
+		return update.EditedMessage.From
+	case update.InlineQuery != nil:
+		return update.InlineQuery.From
+	case update.CallbackQuery != nil:
+		return update.CallbackQuery.From
+	}
+
+	return nil
+}
+
+// updateChat чат, в котором пришло обновление, nil для инлайн запросов
+func updateChat(update tgbotapi.Update) *tgbotapi.Chat {
+	switch {
+	case update.Message != nil:
+		return update.Message.Chat
+	case update.EditedMessage != nil:
+		return update.EditedMessage.Chat
+	case update.ChannelPost != nil:
+		return update.ChannelPost.Chat
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost.Chat
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		return update.CallbackQuery.Message.Chat
+	}
+
+	return nil
+}
+
+// authMiddleware пропускает только пользователей с ролью, а посты только из разрешенных каналов.
+// Команды из publicCommands доступны всем. Остальные обновления молча пропускаются
+func authMiddleware(
+	roleOf func(user *tgbotapi.User) role,
+	allowedChannels map[int64]bool,
+	publicCommands map[string]bool,
+) middleware {
+	return func(next updateHandler) updateHandler {
+		return func(update tgbotapi.Update) (bool, error) {
+			if msg := update.Message; msg != nil && msg.IsCommand() && publicCommands[msg.Command()] {
+				return next(update)
+			}
+
+			if update.ChannelPost != nil || update.EditedChannelPost != nil {
+				if chat := updateChat(update); chat == nil || !allowedChannels[chat.ID] {
+					return false, nil
+				}
+
+				return next(update)
+			}
+
+			if roleOf(updateSender(update)) < roleViewer {
+				return false, nil
+			}
+
+			return next(update)
+		}
+	}
+}
+
+// requireRole пропустит обновление к обработчику, только если у отправителя роль не ниже minRole,
+// иначе обновление молча пропускается, как в authMiddleware
+func requireRole(roleOf func(user *tgbotapi.User) role, minRole role, next updateHandler) updateHandler {
+	return func(update tgbotapi.Update) (bool, error) {
+		if roleOf(updateSender(update)) < minRole {
+			return false, nil
+		}
+
+		return next(update)
+	}
+}
+
+// logMiddleware пишет в лог, что за обновление пришло и чем закончилась обработка
+func logMiddleware(next updateHandler) updateHandler {
+	return func(update tgbotapi.Update) (bool, error) {
+		kind := updateRoute(update)
+		if kind == "" {
+			kind = "unknown"
+		}
+
+		ok, err := next(update)
+
+		switch {
+		case err != nil:
+			log.Printf("Обновление #%d (%s): ошибка: %s\n", update.UpdateID, kind, err)
+		case !ok:
+			log.Printf("Обновление #%d (%s) пропущено\n", update.UpdateID, kind)
+		}
+
+		return ok, err
+	}
+}
+
+// recoverMiddleware превращает панику обработчика в ошибку, чтобы одно кривое обновление не роняло бота.
+// Стек пишется только в лог, в алерт уходит короткая ошибка
+func recoverMiddleware(next updateHandler) updateHandler {
+	return func(update tgbotapi.Update) (ok bool, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Паника при обработке обновления #%d: %v\n%s", update.UpdateID, r, debug.Stack())
+
+				name := string(updateRoute(update))
+				if update.Message != nil && update.Message.IsCommand() {
+					name = "/" + update.Message.Command()
+				}
+
+				ok = true
+				err = fmt.Errorf("panic in handler %s: %v", name, r)
+			}
+		}()
+
+		return next(update)
+	}
+}
+
+// alertMiddleware отправляет ошибку обработчика алертом с id обновления и отправителем, дальше ошибка не идет
+func alertMiddleware(send func(err error)) middleware {
+	return func(next updateHandler) updateHandler {
+		return func(update tgbotapi.Update) (bool, error) {
+			ok, err := next(update)
+			if err != nil {
+				err = withAlertContext(err, "update #%d (%s)", update.UpdateID, updateRoute(update))
+				if sender := updateSender(update); sender != nil {
+					err = withAlertContext(err, "от @%s (%d)", sender.UserName, sender.ID)
+				}
+
+				send(err)
+
+				return true, nil
+			}
+
+			return ok, nil
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Handle(t *testing.T) {
+	t.Parallel()
+
+	user := &tgbotapi.User{UserName: "user"}
+	stranger := &tgbotapi.User{UserName: "stranger"}
+	tagger := &tgbotapi.User{UserName: "tagger"}
+	viewer := &tgbotapi.User{UserName: "viewer"}
+	roles := map[string]role{"user": roleAdmin, "tagger": roleTagger, "viewer": roleViewer}
+	channel := &tgbotapi.Chat{ID: -100}
+
+	type args struct {
+		update tgbotapi.Update
+	}
+	type want struct {
+		ok bool
+		// called обработчики, которые были вызваны, по порядку
+		called []string
+		alerts int
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"should route command by name",
+			args{commandUpdate("user", "/alias list")},
+			want{ok: true, called: []string{"alias"}},
+		},
+		{
+			"should skip unknown command",
+			args{commandUpdate("user", "/help")},
+			want{},
+		},
+		{
+			"should route public command from any user",
+			args{commandUpdate("stranger", "/start tag_Y2F0")},
+			want{ok: true, called: []string{"start"}},
+		},
+		{
+			"should skip not public command from not allowed user",
+			args{commandUpdate("stranger", "/alias list")},
+			want{},
+		},
+		{
+			"should route reply to handlers until one takes it",
+			args{tgbotapi.Update{Message: &tgbotapi.Message{
+				From:           user,
+				Text:           "#cat",
+				ReplyToMessage: &tgbotapi.Message{},
+			}}},
+			want{ok: true, called: []string{"reply_skip", "reply"}},
+		},
+		{
+			"should route edited reply",
+			args{tgbotapi.Update{EditedMessage: &tgbotapi.Message{
+				From:           user,
+				Text:           "#cat",
+				ReplyToMessage: &tgbotapi.Message{},
+			}}},
+			want{ok: true, called: []string{"reply_skip", "reply"}},
+		},
+		{
+			"should skip plain message",
+			args{tgbotapi.Update{Message: &tgbotapi.Message{From: user, Text: "#cat"}}},
+			want{},
+		},
+		{
+			"should route inline query",
+			args{tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{From: user}}},
+			want{ok: true, called: []string{"inline"}},
+		},
+		{
+			"should route callback query",
+			args{tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{From: user}}},
+			want{ok: true, called: []string{"callback"}},
+		},
+		{
+			"should route channel post",
+			args{tgbotapi.Update{ChannelPost: &tgbotapi.Message{Chat: channel}}},
+			want{ok: true, called: []string{"channel_post"}},
+		},
+		{
+			"should skip post from other channel",
+			args{tgbotapi.Update{ChannelPost: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: -200}}}},
+			want{},
+		},
+		{
+			"should route inline query from viewer",
+			args{tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{From: viewer}}},
+			want{ok: true, called: []string{"inline"}},
+		},
+		{
+			"should skip reply from viewer",
+			args{tgbotapi.Update{Message: &tgbotapi.Message{
+				From:           viewer,
+				Text:           "#cat",
+				ReplyToMessage: &tgbotapi.Message{},
+			}}},
+			want{},
+		},
+		{
+			"should route reply from tagger",
+			args{tgbotapi.Update{Message: &tgbotapi.Message{
+				From:           tagger,
+				Text:           "#cat",
+				ReplyToMessage: &tgbotapi.Message{},
+			}}},
+			want{ok: true, called: []string{"reply_skip", "reply"}},
+		},
+		{
+			"should skip callback query from viewer",
+			args{tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{From: viewer}}},
+			want{},
+		},
+		{
+			"should skip admin command from tagger",
+			args{commandUpdate("tagger", "/alias list")},
+			want{},
+		},
+		{
+			"should skip not allowed user",
+			args{tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{From: stranger}}},
+			want{},
+		},
+		{
+			"should send alert on error",
+			args{commandUpdate("user", "/fail")},
+			want{ok: true, called: []string{"fail"}, alerts: 1},
+		},
+		{
+			"should recover from panic and send alert",
+			args{commandUpdate("user", "/panic")},
+			want{ok: true, called: []string{"panic"}, alerts: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var called []string
+			handler := func(name string, ok bool) updateHandler {
+				return func(update tgbotapi.Update) (bool, error) {
+					called = append(called, name)
+
+					return ok, nil
+				}
+			}
+			var alerts []error
+
+			r := newRouter()
+			r.Use(
+				alertMiddleware(func(err error) { alerts = append(alerts, err) }),
+				logMiddleware,
+				recoverMiddleware,
+				authMiddleware(
+					func(user *tgbotapi.User) role {
+						if user == nil {
+							return roleNone
+						}
+
+						return roles[user.UserName]
+					},
+					map[int64]bool{channel.ID: true},
+					map[string]bool{"start": true},
+				),
+			)
+			roleOf := func(user *tgbotapi.User) role {
+				return roles[user.UserName]
+			}
+			r.Command("alias", requireRole(roleOf, roleAdmin, handler("alias", true)))
+			r.Command("start", handler("start", true))
+			r.Command("fail", func(update tgbotapi.Update) (bool, error) {
+				called = append(called, "fail")
+
+				return true, errors.New("fail")
+			})
+			r.Command("panic", func(update tgbotapi.Update) (bool, error) {
+				called = append(called, "panic")
+
+				panic("panic")
+			})
+			r.Reply(requireRole(roleOf, roleTagger, handler("reply_skip", false)))
+			r.Reply(requireRole(roleOf, roleTagger, handler("reply", true)))
+			r.Reply(requireRole(roleOf, roleTagger, handler("reply_never", true)))
+			r.InlineQuery(handler("inline", true))
+			r.CallbackQuery(requireRole(roleOf, roleTagger, handler("callback", true)))
+			r.ChannelPost(handler("channel_post", true))
+
+			ok, err := r.Handle(tt.args.update)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.ok, ok)
+			assert.Equal(t, tt.want.called, called)
+			assert.Len(t, alerts, tt.want.alerts)
+		})
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	handler := recoverMiddleware(func(update tgbotapi.Update) (bool, error) {
+		panic("boom")
+	})
+
+	ok, err := handler(commandUpdate("user", "/panic"))
+	assert.True(t, ok)
+	assert.EqualError(t, err, "panic in handler /panic: boom", "stack should go only to the log")
+}
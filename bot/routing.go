@@ -0,0 +1,311 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/cyhalothrin/gifkoskladbot/api"
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+// mainChannelName имя основного канала ChannelID для @канала в подписи и правил
+const mainChannelName = "main"
+
+// channelTokenRe @канал в подписи, отдельным словом
+var channelTokenRe = regexp.MustCompile(`(^|\s)@(\S+)`)
+
+// channelRoutes выбирает, в какие каналы отправить гифку
+type channelRoutes struct {
+	mainID int64
+	// byName id каналов по имени, вместе с основным
+	byName map[string]int64
+	rules  []channelRule
+}
+
+type channelRule struct {
+	chatID  int64
+	tags    map[string]bool
+	userIDs map[int]bool
+}
+
+func newChannelRoutes(conf config.Config, normalizer *tagnorm.Normalizer) (*channelRoutes, error) {
+	c := &channelRoutes{
+		mainID: conf.ChannelID,
+		byName: map[string]int64{mainChannelName: conf.ChannelID},
+	}
+
+	for _, ch := range conf.Routing.Channels {
+		name := strings.ToLower(strings.TrimPrefix(ch.Name, "@"))
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("routing: у канала %d нет имени", ch.ID)
+		case ch.ID == 0:
+			return nil, fmt.Errorf("routing: у канала %s нет id", name)
+		case ch.ID == conf.ChannelID:
+			return nil, fmt.Errorf("routing: канал %s это основной канал, он называется %s", name, mainChannelName)
+		}
+		if _, ok := c.byName[name]; ok {
+			return nil, fmt.Errorf("routing: канал %s указан дважды", name)
+		}
+
+		c.byName[name] = ch.ID
+	}
+
+	for i, r := range conf.Routing.Rules {
+		chatID, ok := c.byName[strings.ToLower(strings.TrimPrefix(r.Channel, "@"))]
+		if !ok {
+			return nil, fmt.Errorf("routing: в правиле #%d нет такого канала %q", i+1, r.Channel)
+		}
+
+		rule := channelRule{chatID: chatID}
+		if len(r.Tags) > 0 {
+			rule.tags = make(map[string]bool, len(r.Tags))
+			for _, tag := range normalizer.Tags(r.Tags) {
+				rule.tags[tag] = true
+			}
+		}
+		if len(r.UserIDs) > 0 {
+			rule.userIDs = make(map[int]bool, len(r.UserIDs))
+			for _, id := range r.UserIDs {
+				rule.userIDs[id] = true
+			}
+		}
+
+		c.rules = append(c.rules, rule)
+	}
+
+	return c, nil
+}
+
+// channelIDs все каналы, куда бот отправляет гифки
+func (c *channelRoutes) channelIDs() map[int64]bool {
+	ids := make(map[int64]bool, len(c.byName))
+	for _, id := range c.byName {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+// extract вырежет из подписи @каналы и вернет их id по порядку без повторов.
+// Пока дополнительных каналов нет, подпись не трогается
+func (c *channelRoutes) extract(text string) ([]int64, string, error) {
+	if len(c.byName) == 1 {
+		return nil, text, nil
+	}
+
+	var channels []int64
+	var unknown []string
+
+	text = channelTokenRe.ReplaceAllStringFunc(text, func(token string) string {
+		match := channelTokenRe.FindStringSubmatch(token)
+
+		chatID, ok := c.byName[strings.ToLower(match[2])]
+		if !ok {
+			unknown = append(unknown, "@"+match[2])
+
+			return token
+		}
+		if !containsChannel(channels, chatID) {
+			channels = append(channels, chatID)
+		}
+
+		return match[1]
+	})
+
+	if len(unknown) > 0 {
+		return nil, text, fmt.Errorf("нет канала %s, есть: %s", strings.Join(unknown, ", "), c.names())
+	}
+
+	return channels, text, nil
+}
+
+// route канал новой гифки по правилам, по умолчанию основной
+func (c *channelRoutes) route(tags []string, author *tgbotapi.User) int64 {
+	for _, rule := range c.rules {
+		if rule.userIDs != nil && (author == nil || !rule.userIDs[author.ID]) {
+			continue
+		}
+		if rule.tags != nil && !rule.matchTags(tags) {
+			continue
+		}
+
+		return rule.chatID
+	}
+
+	return c.mainID
+}
+
+func (r channelRule) matchTags(tags []string) bool {
+	for _, tag := range tags {
+		if r.tags[tag] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *channelRoutes) names() string {
+	names := make([]string, 0, len(c.byName))
+	for name := range c.byName {
+		names = append(names, "@"+name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// targetChannels куда отправить гифку: в каналы из подписи, иначе туда, где она уже есть или ждет отправки,
+// а новую по правилам
+func (u *UpdatesHandler) targetChannels(fileID string, tags []string, explicit []int64, author *tgbotapi.User) []int64 {
+	if len(explicit) > 0 {
+		return explicit
+	}
+
+	if pending, ok := u.pendingChannels[fileID]; ok {
+		return pending
+	}
+	if sent, ok := u.sentAnimations[fileID]; ok {
+		if posted := u.postedChannels(sent); len(posted) > 0 {
+			return posted
+		}
+	}
+
+	return []int64{u.channels.route(tags, author)}
+}
+
+// postedChannels каналы, где у гифки есть сообщение, основной первым
+func (u *UpdatesHandler) postedChannels(anim *storage.SentAnimation) []int64 {
+	var channels []int64
+	if anim.MessageID != 0 {
+		channels = append(channels, u.conf.ChannelID)
+	}
+
+	other := make([]int64, 0, len(anim.ChannelMessages))
+	for chatID := range anim.ChannelMessages {
+		other = append(other, chatID)
+	}
+	sort.Slice(other, func(i, j int) bool {
+		return other[i] < other[j]
+	})
+
+	return append(channels, other...)
+}
+
+func (u *UpdatesHandler) messageIn(anim *storage.SentAnimation, chatID int64) int {
+	if chatID == u.conf.ChannelID {
+		return anim.MessageID
+	}
+
+	return anim.ChannelMessages[chatID]
+}
+
+// setMessageIn запомнит сообщение гифки в канале, 0 - сообщения в канале больше нет
+func (u *UpdatesHandler) setMessageIn(anim *storage.SentAnimation, chatID int64, messageID int) {
+	if chatID == u.conf.ChannelID {
+		anim.MessageID = messageID
+
+		return
+	}
+
+	if messageID == 0 {
+		delete(anim.ChannelMessages, chatID)
+		if len(anim.ChannelMessages) == 0 {
+			anim.ChannelMessages = nil
+		}
+
+		return
+	}
+
+	if anim.ChannelMessages == nil {
+		anim.ChannelMessages = make(map[int64]int)
+	}
+	anim.ChannelMessages[chatID] = messageID
+}
+
+// publishTo отредактирует подпись гифки в канале, а если сообщения там нет или его удалили, отправит заново.
+// Подпись, которая уже такая, как надо (повтор после частичной отправки), не ошибка
+func (u *UpdatesHandler) publishTo(chatID int64, messageID int, fileID, caption string) (int, error) {
+	if messageID != 0 {
+		err := u.api.EditCaption(chatID, messageID, caption, u.captionFormat.parseMode)
+		if errors.Is(err, api.ErrNotModified) {
+			return messageID, nil
+		}
+		if !errors.Is(err, api.ErrNotFound) {
+			return messageID, err
+		}
+		// сообщение из канала было удалено
+	}
+
+	return u.api.SendAnimation(chatID, fileID, caption, u.captionFormat.parseMode)
+}
+
+// unpublishFrom удалит гифку из канала, куда она больше не должна попадать
+func (u *UpdatesHandler) unpublishFrom(chatID int64, messageID int) error {
+	err := u.api.DeleteMessage(chatID, messageID)
+	if errors.Is(err, api.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// messagesOf копия сообщений гифки в каналах, без подписи
+func messagesOf(anim *storage.SentAnimation) *storage.SentAnimation {
+	result := &storage.SentAnimation{MessageID: anim.MessageID}
+	for chatID, messageID := range anim.ChannelMessages {
+		if result.ChannelMessages == nil {
+			result.ChannelMessages = make(map[int64]int, len(anim.ChannelMessages))
+		}
+		result.ChannelMessages[chatID] = messageID
+	}
+
+	return result
+}
+
+// sameMessages у гифок одни и те же сообщения во всех каналах
+func sameMessages(a, b *storage.SentAnimation) bool {
+	if a.MessageID != b.MessageID || len(a.ChannelMessages) != len(b.ChannelMessages) {
+		return false
+	}
+
+	for chatID, messageID := range a.ChannelMessages {
+		if b.ChannelMessages[chatID] != messageID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameChannels каналы совпадают без учета порядка
+func sameChannels(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, chatID := range a {
+		if !containsChannel(b, chatID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsChannel(channels []int64, chatID int64) bool {
+	for _, ch := range channels {
+		if ch == chatID {
+			return true
+		}
+	}
+
+	return false
+}
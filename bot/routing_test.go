@@ -0,0 +1,432 @@
+package bot
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/api"
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+const (
+	mainChannelID     = 1000
+	internalChannelID = -200
+)
+
+func routingConfig() config.Config {
+	return config.Config{
+		ChannelID:    mainChannelID,
+		AllowedUsers: []string{"user"},
+		Routing: config.Routing{
+			Channels: []config.RoutingChannel{{Name: "Internal", ID: internalChannelID}},
+			Rules: []config.RoutingRule{
+				{Channel: "internal", Tags: []string{"NSFW"}},
+				{Channel: "@internal", UserIDs: []int{7}},
+			},
+		},
+	}
+}
+
+func Test_newChannelRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		routing config.Routing
+		wantErr bool
+	}{
+		{"no routing", config.Routing{}, false},
+		{"channels and rules", routingConfig().Routing, false},
+		{
+			"channel without name",
+			config.Routing{Channels: []config.RoutingChannel{{ID: -1}}},
+			true,
+		},
+		{
+			"channel without id",
+			config.Routing{Channels: []config.RoutingChannel{{Name: "internal"}}},
+			true,
+		},
+		{
+			"main channel twice",
+			config.Routing{Channels: []config.RoutingChannel{{Name: "public", ID: mainChannelID}}},
+			true,
+		},
+		{
+			"reserved name",
+			config.Routing{Channels: []config.RoutingChannel{{Name: "main", ID: -1}}},
+			true,
+		},
+		{
+			"duplicate name",
+			config.Routing{Channels: []config.RoutingChannel{{Name: "a", ID: -1}, {Name: "A", ID: -2}}},
+			true,
+		},
+		{
+			"rule with unknown channel",
+			config.Routing{Rules: []config.RoutingRule{{Channel: "internal"}}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := config.Config{ChannelID: mainChannelID, Routing: tt.routing}
+			_, err := newChannelRoutes(conf, tagnorm.New(config.TagNormalization{}))
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestChannelRoutes_extract(t *testing.T) {
+	t.Parallel()
+
+	routes, err := newChannelRoutes(routingConfig(), tagnorm.New(config.TagNormalization{}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		text         string
+		wantChannels []int64
+		wantText     string
+		wantErr      string
+	}{
+		{"no channels", "#cat кот", nil, "#cat кот", ""},
+		{"one channel", "#cat @internal кот", []int64{internalChannelID}, "#cat  кот", ""},
+		{"channel first", "@internal #cat", []int64{internalChannelID}, " #cat", ""},
+		{"without duplicates", "@main #cat @internal @main", []int64{mainChannelID, internalChannelID}, " #cat  ", ""},
+		{"not a separate word", "mail@internal #cat", nil, "mail@internal #cat", ""},
+		{"unknown channel", "#cat @public", nil, "", "нет канала @public, есть: @internal, @main"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels, text, err := routes.extract(tt.text)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChannels, channels)
+			assert.Equal(t, tt.wantText, text)
+		})
+	}
+
+	t.Run("ignore tokens without extra channels", func(t *testing.T) {
+		t.Parallel()
+
+		routes, err := newChannelRoutes(config.Config{ChannelID: mainChannelID}, tagnorm.New(config.TagNormalization{}))
+		assert.NoError(t, err)
+
+		channels, text, err := routes.extract("#cat @public")
+		assert.NoError(t, err)
+		assert.Nil(t, channels)
+		assert.Equal(t, "#cat @public", text)
+	})
+}
+
+func TestChannelRoutes_route(t *testing.T) {
+	t.Parallel()
+
+	routes, err := newChannelRoutes(routingConfig(), tagnorm.New(config.TagNormalization{}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		tags   []string
+		author *tgbotapi.User
+		want   int64
+	}{
+		{"by tag", []string{"#cat", "#nsfw"}, &tgbotapi.User{ID: 1}, internalChannelID},
+		{"by sender", []string{"#cat"}, &tgbotapi.User{ID: 7}, internalChannelID},
+		{"default", []string{"#cat"}, &tgbotapi.User{ID: 1}, mainChannelID},
+		{"without author", []string{"#cat"}, nil, mainChannelID},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, routes.route(tt.tags, tt.author))
+		})
+	}
+}
+
+func TestUpdatesHandler_routeAnimations(t *testing.T) {
+	t.Parallel()
+
+	captionUpdate := func(userID int, text string) tgbotapi.Update {
+		return tgbotapi.Update{Message: &tgbotapi.Message{
+			MessageID: 5,
+			From:      &tgbotapi.User{ID: userID, UserName: "user"},
+			Chat:      &tgbotapi.Chat{ID: 100},
+			Date:      1600000000,
+			Text:      text,
+			ReplyToMessage: &tgbotapi.Message{
+				Animation: &tgbotapi.ChatAnimation{FileID: "file_1"},
+			},
+		}}
+	}
+
+	type args struct {
+		sent   map[string]*storage.SentAnimation
+		update tgbotapi.Update
+	}
+	tests := []struct {
+		name string
+		args args
+		api  func(mc *minimock.Controller) telegramBotAPI
+		want *storage.SentAnimation
+	}{
+		{
+			"should send new gif to channel from caption",
+			args{update: captionUpdate(1, "#cat @internal")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendAnimationMock.Expect(internalChannelID, "file_1", "#cat", "").Return(7, nil)
+			},
+			&storage.SentAnimation{
+				FileID:          "file_1",
+				Tags:            []string{"#cat"},
+				ChannelMessages: map[int64]int{internalChannelID: 7},
+			},
+		},
+		{
+			"should send new gif by tag rule",
+			args{update: captionUpdate(1, "#cat #nsfw")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendAnimationMock.Expect(internalChannelID, "file_1", "#cat #nsfw", "").Return(7, nil)
+			},
+			&storage.SentAnimation{
+				FileID:          "file_1",
+				Tags:            []string{"#cat", "#nsfw"},
+				ChannelMessages: map[int64]int{internalChannelID: 7},
+			},
+		},
+		{
+			"should send new gif by sender rule",
+			args{update: captionUpdate(7, "#cat")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendAnimationMock.Expect(internalChannelID, "file_1", "#cat", "").Return(7, nil)
+			},
+			&storage.SentAnimation{
+				FileID:          "file_1",
+				Tags:            []string{"#cat"},
+				ChannelMessages: map[int64]int{internalChannelID: 7},
+			},
+		},
+		{
+			"should send new gif to main channel by default",
+			args{update: captionUpdate(1, "#cat")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendAnimationMock.Expect(mainChannelID, "file_1", "#cat", "").Return(3, nil)
+			},
+			&storage.SentAnimation{MessageID: 3, FileID: "file_1", Tags: []string{"#cat"}},
+		},
+		{
+			"should send new gif to several channels",
+			args{update: captionUpdate(1, "#cat @main @internal")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				mock := NewTelegramBotAPIMock(mc)
+				mock.SendAnimationMock.When(mainChannelID, "file_1", "#cat", "").Then(3, nil)
+				mock.SendAnimationMock.When(internalChannelID, "file_1", "#cat", "").Then(7, nil)
+
+				return mock
+			},
+			&storage.SentAnimation{
+				MessageID:       3,
+				FileID:          "file_1",
+				Tags:            []string{"#cat"},
+				ChannelMessages: map[int64]int{internalChannelID: 7},
+			},
+		},
+		{
+			"should edit gif in its channel without rules",
+			args{
+				sent: map[string]*storage.SentAnimation{
+					"file_1": {FileID: "file_1", Tags: []string{"#cat"}, ChannelMessages: map[int64]int{internalChannelID: 7}},
+				},
+				update: captionUpdate(1, "+#dog"),
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					EditCaptionMock.Expect(internalChannelID, 7, "#cat #dog", "").Return(nil)
+			},
+			&storage.SentAnimation{
+				FileID:          "file_1",
+				Tags:            []string{"#cat", "#dog"},
+				ChannelMessages: map[int64]int{internalChannelID: 7},
+			},
+		},
+		{
+			"should move gif to other channel",
+			args{
+				sent: map[string]*storage.SentAnimation{
+					"file_1": {MessageID: 3, FileID: "file_1", Tags: []string{"#cat"}},
+				},
+				update: captionUpdate(1, "@internal"),
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendAnimationMock.Expect(internalChannelID, "file_1", "#cat", "").Return(7, nil).
+					DeleteMessageMock.Expect(mainChannelID, 3).Return(nil)
+			},
+			&storage.SentAnimation{
+				FileID:          "file_1",
+				Tags:            []string{"#cat"},
+				ChannelMessages: map[int64]int{internalChannelID: 7},
+			},
+		},
+		{
+			"should move gif even if old message was deleted",
+			args{
+				sent: map[string]*storage.SentAnimation{
+					"file_1": {FileID: "file_1", Tags: []string{"#cat"}, ChannelMessages: map[int64]int{internalChannelID: 7}},
+				},
+				update: captionUpdate(1, "#cat #dog @main"),
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendAnimationMock.Expect(mainChannelID, "file_1", "#cat #dog", "").Return(3, nil).
+					DeleteMessageMock.Expect(internalChannelID, 7).Return(api.ErrNotFound)
+			},
+			&storage.SentAnimation{MessageID: 3, FileID: "file_1", Tags: []string{"#cat", "#dog"}},
+		},
+		{
+			"should reply on unknown channel",
+			args{update: captionUpdate(1, "#cat @public")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendReplyMock.
+					Expect(100, 5, "Не понял подпись: нет канала @public, есть: @internal, @main\n\n"+captionUsage).
+					Return(6, nil)
+			},
+			nil,
+		},
+		{
+			"should reply on channel without tags for new gif",
+			args{update: captionUpdate(1, "@internal")},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendReplyMock.
+					Expect(100, 5, "Не понял подпись: у гифки еще нет тегов, напиши их вместе с каналом\n\n"+captionUsage).
+					Return(6, nil)
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			store := NewGifkoskladMetaStorageMock(mc).
+				GetTagsAliasesMock.Return(nil).
+				GetUserRolesMock.Return(nil).
+				GetPendingTyposMock.Return(nil).
+				GetSentAnimationsMock.Return(tt.args.sent).
+				GetTagsMock.Return([]string{"#cat", "#dog", "#nsfw"})
+			if tt.want != nil {
+				store.AddSentAnimationsMock.Expect(map[string]*storage.SentAnimation{"file_1": tt.want}).Return()
+				store.AddTagChangesMock.Return()
+			}
+
+			u := NewUpdatesHandler(routingConfig(), store, nil, tt.api(mc))
+
+			ok, err := u.handleAnimationCaption(tt.args.update)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			assert.NoError(t, u.PublishAnimations())
+			if tt.want != nil {
+				assert.Equal(t, tt.want, u.sentAnimations["file_1"])
+			}
+		})
+	}
+}
+
+func TestUpdatesHandler_publishAnimationsPartly(t *testing.T) {
+	t.Parallel()
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	store, err := storage.NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+
+	update := tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID:      5,
+		From:           &tgbotapi.User{ID: 1, UserName: "user"},
+		Chat:           &tgbotapi.Chat{ID: 100},
+		Date:           1600000000,
+		Text:           "#cat @main @internal",
+		ReplyToMessage: &tgbotapi.Message{Animation: &tgbotapi.ChatAnimation{FileID: "file_1"}},
+	}}
+
+	sent := make(map[int64]int)
+	tgAPI := NewTelegramBotAPIMock(mc).
+		SendAnimationMock.Set(func(chatID int64, fileID, caption, parseMode string) (int, error) {
+		sent[chatID]++
+		if chatID == internalChannelID && sent[chatID] == 1 {
+			return 0, errors.New("Bad Gateway")
+		}
+
+		if chatID == mainChannelID {
+			return 1, nil
+		}
+
+		return 7, nil
+	}).
+		EditCaptionMock.Expect(mainChannelID, 1, "#cat", "").Return(api.ErrNotModified)
+	alert := NewAlerterMock(mc).SendMock.Return(nil)
+
+	u := NewUpdatesHandler(routingConfig(), store, alert, tgAPI)
+	ok, err := u.handleAnimationCaption(update)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Error(t, u.PublishAnimations(), "second channel failed")
+	assert.Equal(t,
+		&storage.SentAnimation{MessageID: 1, FileID: "file_1", Tags: []string{"#cat"}},
+		store.GetSentAnimations()["file_1"],
+		"message in the first channel should be saved",
+	)
+
+	// обновление придет еще раз
+	u = NewUpdatesHandler(routingConfig(), store, alert, tgAPI)
+	ok, err = u.handleAnimationCaption(update)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, u.PublishAnimations())
+	assert.Equal(t,
+		&storage.SentAnimation{
+			MessageID:       1,
+			FileID:          "file_1",
+			Tags:            []string{"#cat"},
+			ChannelMessages: map[int64]int{internalChannelID: 7},
+		},
+		store.GetSentAnimations()["file_1"],
+	)
+	assert.Equal(t, map[int64]int{mainChannelID: 1, internalChannelID: 2}, sent, "first channel should not get a duplicate")
+}
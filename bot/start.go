@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/cyhalothrin/gifkoskladbot/search"
+)
+
+// startGifsLimit столько гифок пришлет ссылка на тег, остальные можно найти инлайн
+const startGifsLimit = 10
+
+// startGreeting ответ на ссылку тому, кому гифки по ней не положены
+const startGreeting = "Привет! Гифки по ссылкам на теги присылаются только участникам"
+
+// handleStartCommand ссылки из списка тегов: /start tag_<тег> пришлет последние гифки с тегом.
+// Команда доступна всем, но гифки по ссылке только тем, кто может искать (viewer и выше),
+// если в конфиге ссылки не открыты для всех
+func (u *UpdatesHandler) handleStartCommand(update tgbotapi.Update) (bool, error) {
+	message := update.Message
+	if message == nil || !message.IsCommand() {
+		return false, nil
+	}
+
+	payload := strings.TrimSpace(message.CommandArguments())
+	if payload == "" {
+		return true, u.replyStart(message.Chat.ID, u.startHint(""))
+	}
+
+	if u.conf.TagsIndex.PublicLinks {
+		return u.handleStartTag(update)
+	}
+
+	ok, err := requireRole(u.userRole, roleViewer, u.handleStartTag)(update)
+	if !ok {
+		return true, u.replyStart(message.Chat.ID, startGreeting)
+	}
+
+	return true, err
+}
+
+// handleStartTag пришлет последние гифки с тегом из ссылки
+func (u *UpdatesHandler) handleStartTag(update tgbotapi.Update) (bool, error) {
+	message := update.Message
+	payload := strings.TrimSpace(message.CommandArguments())
+
+	tags := make([]string, 0, len(u.uniqueTags))
+	for tag := range u.uniqueTags {
+		tags = append(tags, tag)
+	}
+
+	tag, ok := parseStartTagPayload(payload, tags)
+	if ok {
+		// ссылка могла быть собрана до смены правил нормализации или набрана руками
+		tag, ok = u.normalizer.Tag(tag)
+	}
+	if alias, isAlias := u.tagsAliases[tag]; ok && isAlias {
+		tag = alias
+	}
+	if !ok || !u.uniqueTags[tag] {
+		return true, u.replyStart(message.Chat.ID, "Такого тега нет.\n"+u.startHint(""))
+	}
+
+	total := u.index.Count(tag)
+	found := u.index.Search(search.Tag(tag), search.WithLimit(startGifsLimit))
+	if len(found) == 0 {
+		return true, u.replyStart(message.Chat.ID, fmt.Sprintf("С тегом %s гифок пока нет", tag))
+	}
+
+	for _, anim := range found {
+		caption, err := u.captionFormat.format(anim)
+		if err != nil {
+			return true, fmt.Errorf("подпись гифки по ссылке на тег %s: %w", tag, err)
+		}
+		if _, err := u.api.SendAnimation(message.Chat.ID, anim.FileID, caption, u.captionFormat.parseMode); err != nil {
+			return true, fmt.Errorf("отправка гифки по ссылке на тег %s: %w", tag, err)
+		}
+	}
+
+	if total > len(found) {
+		return true, u.replyStart(
+			message.Chat.ID,
+			fmt.Sprintf("Это последние %d из %d.\n%s", len(found), total, u.startHint(tag)),
+		)
+	}
+
+	return true, nil
+}
+
+func (u *UpdatesHandler) replyStart(chatID int64, text string) error {
+	if _, err := u.api.SendMessage(chatID, text, ""); err != nil {
+		return fmt.Errorf("ответ на /start: %w", err)
+	}
+
+	return nil
+}
+
+// startHint подсказка про инлайн поиск, если бот знает свое имя
+func (u *UpdatesHandler) startHint(tag string) string {
+	if u.tagsFormat.botUsername == "" {
+		return "Гифки можно искать по тегам в инлайн режиме бота"
+	}
+	if tag == "" {
+		tag = "#тег"
+	}
+
+	return fmt.Sprintf("Гифки ищутся в любом чате: @%s %s", u.tagsFormat.botUsername, tag)
+}
@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func TestUpdatesHandler_handleStartCommand(t *testing.T) {
+	t.Parallel()
+
+	animations := map[string]*storage.SentAnimation{
+		"file_1": {FileID: "file_1", MessageID: 1, Tags: []string{"#cat", "#funny"}},
+		"file_2": {FileID: "file_2", MessageID: 2, Tags: []string{"#cat"}},
+		"file_3": {FileID: "file_3", MessageID: 3, Tags: []string{"#dog"}},
+		"file_4": {FileID: "file_4", MessageID: 4, Tags: []string{"#ежик"}},
+	}
+	for i := 1; i <= startGifsLimit+1; i++ {
+		fileID := fmt.Sprintf("many_%02d", i)
+		animations[fileID] = &storage.SentAnimation{FileID: fileID, MessageID: 100 + i, Tags: []string{"#кот"}}
+	}
+
+	type want struct {
+		// sent fileID отправленных гифок по порядку
+		sent  []string
+		reply string
+	}
+	viewer := map[int]string{42: "viewer"}
+	tests := []struct {
+		name  string
+		text  string
+		roles map[int]string
+		// public ссылки открыты всем в конфиге
+		public bool
+		want   want
+	}{
+		{
+			"should send gifs by tag link newest first",
+			"/start " + startTagPayload("#cat"),
+			viewer,
+			false,
+			want{sent: []string{"file_2", "file_1"}},
+		},
+		{
+			"should send last gifs and hint about others",
+			"/start " + startTagPayload("#кот"),
+			viewer,
+			false,
+			want{
+				sent: func() []string {
+					var fileIDs []string
+					for i := startGifsLimit + 1; i > 1; i-- {
+						fileIDs = append(fileIDs, fmt.Sprintf("many_%02d", i))
+					}
+
+					return fileIDs
+				}(),
+				reply: "Это последние 10 из 11.\nГифки ищутся в любом чате: @gifbot #кот",
+			},
+		},
+		{
+			"should normalize tag from link",
+			"/start " + startTagPayload("#Ёжик"),
+			viewer,
+			false,
+			want{sent: []string{"file_4"}},
+		},
+		{
+			"should replace alias from link",
+			"/start " + startTagPayload("#котэ"),
+			viewer,
+			false,
+			want{sent: []string{"file_2", "file_1"}},
+		},
+		{
+			"should reply on unknown tag",
+			"/start " + startTagPayload("#fox"),
+			viewer,
+			false,
+			want{reply: "Такого тега нет.\nГифки ищутся в любом чате: @gifbot #тег"},
+		},
+		{
+			"should greet user without role instead of sending gifs",
+			"/start " + startTagPayload("#cat"),
+			nil,
+			false,
+			want{reply: startGreeting},
+		},
+		{
+			"should send gifs by public link to user without role",
+			"/start " + startTagPayload("#cat"),
+			nil,
+			true,
+			want{sent: []string{"file_2", "file_1"}},
+		},
+		{
+			"should reply on start without link",
+			"/start",
+			viewer,
+			false,
+			want{reply: "Гифки ищутся в любом чате: @gifbot #тег"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			store := NewGifkoskladMetaStorageMock(mc).
+				GetTagsAliasesMock.Return(map[string]string{"#котэ": "#cat"}).
+				GetUserRolesMock.Return(tt.roles).
+				GetPendingTyposMock.Return(nil).
+				GetSentAnimationsMock.Return(animations).
+				GetTagsMock.Return([]string{"#cat", "#dog", "#funny", "#ежик", "#кот"})
+
+			var sent []string
+			api := NewTelegramBotAPIMock(mc)
+			if len(tt.want.sent) > 0 {
+				api.SendAnimationMock.Set(func(chatID int64, fileID string, caption string, parseMode string) (int, error) {
+					assert.Equal(t, int64(100), chatID)
+					sent = append(sent, fileID)
+
+					return 1, nil
+				})
+			}
+			if tt.want.reply != "" {
+				api.SendMessageMock.Expect(100, tt.want.reply, "").Return(2, nil)
+			}
+
+			conf := config.Config{
+				HostUsername:     "gifbot",
+				TagNormalization: config.TagNormalization{FoldYo: true},
+				TagsIndex:        config.TagsIndex{PublicLinks: tt.public},
+			}
+			u := NewUpdatesHandler(conf, store, nil, api)
+
+			ok, err := u.handleStartCommand(commandUpdate("stranger", tt.text))
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.sent, sent)
+		})
+	}
+}
@@ -10,4 +10,15 @@ type GifkoskladMetaStorage interface {
 	GetSentAnimations() map[string]*storage.SentAnimation
 	// AddSentAnimations adds new sent animations to storage
 	AddSentAnimations(map[string]*storage.SentAnimation)
+	// AddTagChanges дописывает изменения в историю тегов гифок
+	AddTagChanges(changes ...*storage.TagChange)
+	// GetTagsIndex сообщения списка тегов в канале по порядку
+	GetTagsIndex() []*storage.TagsIndexMessage
+	SetTagsIndex([]*storage.TagsIndexMessage)
+	// GetUserRoles роли пользователей, выданные через /role, по id в телеге
+	GetUserRoles() map[int]string
+	SetUserRoles(map[int]string)
+	// GetPendingTypos подписи, которые ждут ответа на подсказку про опечатки, по id подсказки
+	GetPendingTypos() map[string]*storage.PendingTypos
+	SetPendingTypos(map[string]*storage.PendingTypos)
 }
@@ -0,0 +1,128 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cyhalothrin/gifkoskladbot/api"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// tagsIndexMessageLimit больше символов в одном сообщении телега не пропустит
+const tagsIndexMessageLimit = 4096
+
+// splitTagsIndex разобьет список на части не длиннее limit символов, строки не разрываются.
+// Пустые строки между группами остаются, а на границах частей выкидываются.
+// Строка длиннее limit выкидывается целиком
+func splitTagsIndex(text string, limit int) []string {
+	var parts []string
+	var part strings.Builder
+	partLen := 0
+	// blank пустых строк перед следующей строкой
+	blank := 0
+
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			if partLen > 0 {
+				blank++
+			}
+
+			continue
+		}
+
+		lineLen := utf8.RuneCountInString(line)
+		if lineLen > limit {
+			// обрезать нельзя: в строке разметка, с половиной ссылки телега не примет всю часть
+			log.Printf("Строка списка тегов длиннее %d символов, пропущена: %s\n", limit, line)
+
+			continue
+		}
+
+		sepLen := 1 + blank
+		blank = 0
+		if partLen > 0 && partLen+sepLen+lineLen > limit {
+			parts = append(parts, part.String())
+			part.Reset()
+			partLen = 0
+		}
+
+		if partLen > 0 {
+			part.WriteString(strings.Repeat("\n", sepLen))
+			partLen += sepLen
+		}
+		part.WriteString(line)
+		partLen += lineLen
+	}
+
+	if partLen > 0 {
+		parts = append(parts, part.String())
+	}
+
+	return parts
+}
+
+// syncTagsIndex приведет сообщения списка в канале к parts: редактирует только изменившиеся части,
+// недостающие отправляет, лишние удаляет. Первое сообщение списка закрепляется.
+// Вернет актуальные сообщения, даже если по дороге была ошибка
+func (u *UpdatesHandler) syncTagsIndex(
+	index []*storage.TagsIndexMessage,
+	parts []string,
+	parseMode string,
+) ([]*storage.TagsIndexMessage, error) {
+	result := make([]*storage.TagsIndexMessage, 0, len(parts))
+
+	for i, part := range parts {
+		if i < len(index) {
+			msg := index[i]
+			if msg.Text == part {
+				result = append(result, msg)
+
+				continue
+			}
+
+			err := u.api.EditMessage(u.conf.ChannelID, msg.MessageID, part, parseMode)
+			if err == nil {
+				result = append(result, &storage.TagsIndexMessage{MessageID: msg.MessageID, Text: part})
+
+				continue
+			}
+			if !errors.Is(err, api.ErrNotFound) {
+				return append(result, index[i:]...), fmt.Errorf("редактирование списка тегов #%d: %w", msg.MessageID, err)
+			}
+			// сообщение удалили руками, отправим заново
+		}
+
+		newID, err := u.api.SendMessage(u.conf.ChannelID, part, parseMode)
+		if err != nil {
+			return appendRest(result, index, i+1), fmt.Errorf("отправка списка тегов: %w", err)
+		}
+		result = append(result, &storage.TagsIndexMessage{MessageID: newID, Text: part})
+
+		if i == 0 {
+			if err := u.api.PinMessage(u.conf.ChannelID, newID); err != nil {
+				return appendRest(result, index, i+1), fmt.Errorf("пин сообщения #%d: %w", newID, err)
+			}
+		}
+	}
+
+	// список стал короче
+	for i := len(parts); i < len(index); i++ {
+		err := u.api.DeleteMessage(u.conf.ChannelID, index[i].MessageID)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return append(result, index[i:]...), fmt.Errorf("удаление списка тегов #%d: %w", index[i].MessageID, err)
+		}
+	}
+
+	return result, nil
+}
+
+func appendRest(result, index []*storage.TagsIndexMessage, from int) []*storage.TagsIndexMessage {
+	if from >= len(index) {
+		return result
+	}
+
+	return append(result, index[from:]...)
+}
@@ -0,0 +1,248 @@
+package bot
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+const (
+	tagsIndexGroupByLetter   = "letter"
+	tagsIndexGroupByCategory = "category"
+
+	// tagsIndexOtherGroup сюда попадают теги без категории
+	tagsIndexOtherGroup = "Остальное"
+
+	// startTagPrefix начало параметра /start для ссылки на тег
+	startTagPrefix = "tag_"
+	// startTagHashPrefix для тегов, которые не влезают в параметр /start целиком
+	startTagHashPrefix = "tagh_"
+	// startPayloadLimit длиннее параметр /start телега не примет
+	startPayloadLimit = 64
+)
+
+// defaultTagsIndexTemplate группы через пустую строку, в каждой теги с количеством гифок
+const defaultTagsIndexTemplate = `{{range $i, $g := .Groups}}{{if $i}}
+{{end}}{{with $g.Name}}{{bold .}}
+{{end}}{{range $g.Tags}}{{link .Tag .Link}} {{count .Count}}
+{{end}}{{end}}`
+
+// tagsIndexData данные для шаблона списка тегов
+type tagsIndexData struct {
+	Groups []tagsIndexGroup
+	// TagsCount всего тегов
+	TagsCount int
+}
+
+type tagsIndexGroup struct {
+	// Name пустое, если список без групп
+	Name string
+	Tags []tagsIndexEntry
+}
+
+type tagsIndexEntry struct {
+	Tag   string
+	Count int
+	// Link ссылка в бота, которая покажет гифки с тегом, пустая если не указан HostUsername
+	Link string
+}
+
+// tagsIndexFormatter собирает текст списка тегов по шаблону и разметке из конфига
+type tagsIndexFormatter struct {
+	conf        config.TagsIndex
+	botUsername string
+	tmpl        *template.Template
+	parseMode   string
+}
+
+func newTagsIndexFormatter(conf config.Config) (*tagsIndexFormatter, error) {
+	f := &tagsIndexFormatter{
+		conf:        conf.TagsIndex,
+		botUsername: strings.TrimPrefix(conf.HostUsername, "@"),
+	}
+
+	m, ok := parseMarkup(conf.TagsIndex.Format)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный формат списка тегов '%s'", conf.TagsIndex.Format)
+	}
+	f.parseMode = string(m)
+
+	text := conf.TagsIndex.Template
+	if text == "" {
+		text = defaultTagsIndexTemplate
+	}
+
+	tmpl, err := template.New("tags_index").Funcs(template.FuncMap{
+		"esc":   f.escape,
+		"bold":  f.bold,
+		"link":  f.link,
+		"count": f.count,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("шаблон списка тегов: %w", err)
+	}
+	f.tmpl = tmpl
+
+	return f, nil
+}
+
+// format текст списка, count сколько гифок с тегом
+func (f *tagsIndexFormatter) format(tags []string, count func(tag string) int) (string, error) {
+	data := tagsIndexData{
+		Groups:    f.group(tags, count),
+		TagsCount: len(tags),
+	}
+
+	var b strings.Builder
+	if err := f.tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("шаблон списка тегов: %w", err)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+func (f *tagsIndexFormatter) group(tags []string, count func(tag string) int) []tagsIndexGroup {
+	entry := func(tag string) tagsIndexEntry {
+		return tagsIndexEntry{Tag: tag, Count: count(tag), Link: f.tagLink(tag)}
+	}
+
+	switch f.conf.GroupBy {
+	case tagsIndexGroupByLetter:
+		var groups []tagsIndexGroup
+		for _, tag := range tags {
+			name := tagFirstLetter(tag)
+			if len(groups) == 0 || groups[len(groups)-1].Name != name {
+				groups = append(groups, tagsIndexGroup{Name: name})
+			}
+			groups[len(groups)-1].Tags = append(groups[len(groups)-1].Tags, entry(tag))
+		}
+
+		return groups
+	case tagsIndexGroupByCategory:
+		known := make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			known[tag] = true
+		}
+
+		var groups []tagsIndexGroup
+		categorized := make(map[string]bool)
+		for _, category := range f.conf.Categories {
+			group := tagsIndexGroup{Name: category.Name}
+			for _, tag := range category.Tags {
+				if known[tag] && !categorized[tag] {
+					group.Tags = append(group.Tags, entry(tag))
+					categorized[tag] = true
+				}
+			}
+			if len(group.Tags) > 0 {
+				groups = append(groups, group)
+			}
+		}
+
+		other := tagsIndexGroup{Name: tagsIndexOtherGroup}
+		for _, tag := range tags {
+			if !categorized[tag] {
+				other.Tags = append(other.Tags, entry(tag))
+			}
+		}
+		if len(other.Tags) > 0 {
+			groups = append(groups, other)
+		}
+
+		return groups
+	}
+
+	group := tagsIndexGroup{}
+	for _, tag := range tags {
+		group.Tags = append(group.Tags, entry(tag))
+	}
+
+	return []tagsIndexGroup{group}
+}
+
+func (f *tagsIndexFormatter) tagLink(tag string) string {
+	if f.botUsername == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("https://t.me/%s?start=%s", f.botUsername, startTagPayload(tag))
+}
+
+func (f *tagsIndexFormatter) escape(text string) string {
+	return markup(f.parseMode).escape(text)
+}
+
+func (f *tagsIndexFormatter) bold(text string) string {
+	return markup(f.parseMode).bold(text)
+}
+
+func (f *tagsIndexFormatter) link(text, url string) string {
+	return markup(f.parseMode).link(text, url)
+}
+
+func (f *tagsIndexFormatter) count(n int) string {
+	return f.escape("(" + strconv.Itoa(n) + ")")
+}
+
+// tagFirstLetter заглавная первая буква тега без #
+func tagFirstLetter(tag string) string {
+	r, _ := utf8.DecodeRuneInString(strings.TrimPrefix(tag, "#"))
+	if r == utf8.RuneError {
+		return "#"
+	}
+
+	return string(unicode.ToUpper(r))
+}
+
+// startTagPayload параметр /start для тега. В параметре можно только латиницу, цифры, _ и -,
+// поэтому тег кодируется в base64, а слишком длинный заменяется хешем
+func startTagPayload(tag string) string {
+	payload := startTagPrefix + base64.RawURLEncoding.EncodeToString([]byte(strings.TrimPrefix(tag, "#")))
+	if len(payload) <= startPayloadLimit {
+		return payload
+	}
+
+	return startTagHashPrefix + tagHash(tag)
+}
+
+// parseStartTagPayload тег из параметра /start, tags нужны, чтобы найти тег по хешу
+func parseStartTagPayload(payload string, tags []string) (string, bool) {
+	if strings.HasPrefix(payload, startTagHashPrefix) {
+		hash := strings.TrimPrefix(payload, startTagHashPrefix)
+		sorted := append([]string(nil), tags...)
+		sort.Strings(sorted)
+		for _, tag := range sorted {
+			if tagHash(tag) == hash {
+				return tag, true
+			}
+		}
+
+		return "", false
+	}
+
+	if !strings.HasPrefix(payload, startTagPrefix) {
+		return "", false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(payload, startTagPrefix))
+	if err != nil || len(decoded) == 0 {
+		return "", false
+	}
+
+	return "#" + string(decoded), true
+}
+
+func tagHash(tag string) string {
+	h := fnv.New64a()
+	h.Write([]byte(tag))
+
+	return strconv.FormatUint(h.Sum64(), 36)
+}
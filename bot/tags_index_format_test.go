@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+func TestTagsIndexFormatter_format(t *testing.T) {
+	t.Parallel()
+
+	tags := []string{"#cat", "#cow", "#dog", "#кот"}
+	counts := map[string]int{"#cat": 3, "#cow": 1, "#dog": 12, "#кот": 2}
+
+	tests := []struct {
+		name    string
+		conf    config.Config
+		want    string
+		wantErr bool
+	}{
+		{
+			"plain without groups",
+			config.Config{HostUsername: "gifbot"},
+			"#cat (3)\n#cow (1)\n#dog (12)\n#кот (2)",
+			false,
+		},
+		{
+			"html by letter with links",
+			config.Config{
+				HostUsername: "@gifbot",
+				TagsIndex:    config.TagsIndex{Format: "html", GroupBy: "letter"},
+			},
+			`<b>C</b>
+<a href="https://t.me/gifbot?start=tag_Y2F0">#cat</a> (3)
+<a href="https://t.me/gifbot?start=tag_Y293">#cow</a> (1)
+
+<b>D</b>
+<a href="https://t.me/gifbot?start=tag_ZG9n">#dog</a> (12)
+
+<b>К</b>
+<a href="https://t.me/gifbot?start=tag_0LrQvtGC">#кот</a> (2)`,
+			false,
+		},
+		{
+			"markdownv2 by category without bot name",
+			config.Config{TagsIndex: config.TagsIndex{
+				Format:     "MarkdownV2",
+				GroupBy:    "category",
+				Categories: []config.TagsCategory{{Name: "Звери.", Tags: []string{"#cat", "#dog", "#fox"}}},
+			}},
+			"*Звери\\.*\n\\#cat \\(3\\)\n\\#dog \\(12\\)\n\n*Остальное*\n\\#cow \\(1\\)\n\\#кот \\(2\\)",
+			false,
+		},
+		{
+			"markdownv2 link",
+			config.Config{
+				HostUsername: "gifbot",
+				TagsIndex:    config.TagsIndex{Format: "markdownv2", Template: `{{range .Groups}}{{range .Tags}}{{link .Tag .Link}}{{end}}{{end}}`},
+			},
+			"[\\#cat](https://t.me/gifbot?start=tag_Y2F0)[\\#cow](https://t.me/gifbot?start=tag_Y293)" +
+				"[\\#dog](https://t.me/gifbot?start=tag_ZG9n)[\\#кот](https://t.me/gifbot?start=tag_0LrQvtGC)",
+			false,
+		},
+		{
+			"custom template",
+			config.Config{TagsIndex: config.TagsIndex{
+				Template: `Тегов: {{.TagsCount}}{{range .Groups}}{{range .Tags}} {{esc .Tag}}={{.Count}}{{end}}{{end}}`,
+			}},
+			"Тегов: 4 #cat=3 #cow=1 #dog=12 #кот=2",
+			false,
+		},
+		{
+			"unknown format",
+			config.Config{TagsIndex: config.TagsIndex{Format: "bbcode"}},
+			"",
+			true,
+		},
+		{
+			"broken template",
+			config.Config{TagsIndex: config.TagsIndex{Template: "{{range .Groups}"}},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := newTagsIndexFormatter(tt.conf)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			got, err := f.format(tags, func(tag string) int { return counts[tag] })
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_escapeMarkdownV2(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `\#a\_b \(1\) 1\.5\!`, escapeMarkdownV2("#a_b (1) 1.5!"))
+	assert.Equal(t, "кот", escapeMarkdownV2("кот"))
+}
+
+func Test_startTagPayload(t *testing.T) {
+	t.Parallel()
+
+	longTag := "#" + strings.Repeat("очень_длинный_тег", 4)
+	tags := []string{"#cat", "#кот", longTag}
+
+	for _, tag := range tags {
+		payload := startTagPayload(tag)
+		assert.True(t, len(payload) <= startPayloadLimit, payload)
+
+		got, ok := parseStartTagPayload(payload, tags)
+		assert.True(t, ok, tag)
+		assert.Equal(t, tag, got)
+	}
+
+	assert.True(t, strings.HasPrefix(startTagPayload(longTag), startTagHashPrefix))
+
+	_, ok := parseStartTagPayload("tag_!!!", tags)
+	assert.False(t, ok)
+	_, ok = parseStartTagPayload(startTagHashPrefix+"unknown", tags)
+	assert.False(t, ok)
+	_, ok = parseStartTagPayload("hello", tags)
+	assert.False(t, ok)
+}
@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/api"
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func Test_splitTagsIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"empty", "", 10, nil},
+		{"fits in one", "#a\n#b\n#c", 10, []string{"#a\n#b\n#c"}},
+		{"split by lines", "#aaa\n#bbb\n#ccc", 9, []string{"#aaa\n#bbb", "#ccc"}},
+		{"count runes not bytes", "#кот\n#пёс", 9, []string{"#кот\n#пёс"}},
+		{"drop too long line", "#aaaaaaaaaaaa\n#b", 5, []string{"#b"}},
+		{
+			"drop too long linked tag whole",
+			"#a\n" + markup(tgbotapi.ModeHTML).link("#"+strings.Repeat("a", 20), "https://t.me/gifbot?start=t_abc") + "\n#b",
+			40,
+			[]string{"#a\n#b"},
+		},
+		{"keep blank lines inside part", "A\n#a\n\nB\n#b", 20, []string{"A\n#a\n\nB\n#b"}},
+		{"trim blank lines on split", "A\n#a\n\nB\n#b", 5, []string{"A\n#a", "B\n#b"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, splitTagsIndex(tt.text, tt.limit))
+		})
+	}
+}
+
+func TestUpdatesHandler_syncTagsIndex(t *testing.T) {
+	t.Parallel()
+
+	const channelID = 10001
+
+	type args struct {
+		index []*storage.TagsIndexMessage
+		parts []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		api     func(mc *minimock.Controller) telegramBotAPI
+		want    []*storage.TagsIndexMessage
+		wantErr bool
+	}{
+		{
+			"should edit only changed parts",
+			args{
+				index: []*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}, {MessageID: 2, Text: "#b"}},
+				parts: []string{"#a", "#b\n#c"},
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).EditMessageMock.Expect(channelID, 2, "#b\n#c", "").Return(nil)
+			},
+			[]*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}, {MessageID: 2, Text: "#b\n#c"}},
+			false,
+		},
+		{
+			"should send new parts without pin",
+			args{
+				index: []*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}},
+				parts: []string{"#a", "#b"},
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).SendMessageMock.Expect(channelID, "#b", "").Return(3, nil)
+			},
+			[]*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}, {MessageID: 3, Text: "#b"}},
+			false,
+		},
+		{
+			"should resend deleted first part and pin it",
+			args{
+				index: []*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}},
+				parts: []string{"#a\n#b"},
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					EditMessageMock.Expect(channelID, 1, "#a\n#b", "").Return(api.ErrNotFound).
+					SendMessageMock.Expect(channelID, "#a\n#b", "").Return(5, nil).
+					PinMessageMock.Expect(channelID, 5).Return(nil)
+			},
+			[]*storage.TagsIndexMessage{{MessageID: 5, Text: "#a\n#b"}},
+			false,
+		},
+		{
+			"should delete extra parts",
+			args{
+				index: []*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}, {MessageID: 2, Text: "#b"}},
+				parts: []string{"#a"},
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).DeleteMessageMock.Expect(channelID, 2).Return(nil)
+			},
+			[]*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}},
+			false,
+		},
+		{
+			"should keep old messages on edit error",
+			args{
+				index: []*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}, {MessageID: 2, Text: "#b"}},
+				parts: []string{"#a1", "#b1"},
+			},
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).EditMessageMock.Expect(channelID, 1, "#a1", "").Return(errors.New("timeout"))
+			},
+			[]*storage.TagsIndexMessage{{MessageID: 1, Text: "#a"}, {MessageID: 2, Text: "#b"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			u := &UpdatesHandler{
+				api:  tt.api(mc),
+				conf: config.Config{ChannelID: channelID},
+			}
+
+			got, err := u.syncTagsIndex(tt.args.index, tt.args.parts, "")
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
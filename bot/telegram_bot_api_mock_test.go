@@ -2,7 +2,7 @@ package bot
 
 // Code generated by http://github.com/gojuno/minimock (3.0.8). DO NOT EDIT.
 
-//go:generate minimock -i github.com/cyhalothrin/gifkoskladbot/bot.telegramBotAPI -o ./bot\telegram_bot_api_mock_test.go
+//go:generate minimock -i github.com/cyhalothrin/gifkoskladbot/bot.telegramBotAPI -o ./bot/telegram_bot_api_mock_test.go
 
 import (
 	"sync"
@@ -17,20 +17,38 @@ import (
 type TelegramBotAPIMock struct {
 	t minimock.Tester
 
-	funcEditMessage          func(chatID int64, messageID int, text string) (err error)
-	inspectFuncEditMessage   func(chatID int64, messageID int, text string)
+	funcAnswerCallbackQuery          func(callbackID string, text string) (err error)
+	inspectFuncAnswerCallbackQuery   func(callbackID string, text string)
+	afterAnswerCallbackQueryCounter  uint64
+	beforeAnswerCallbackQueryCounter uint64
+	AnswerCallbackQueryMock          mTelegramBotAPIMockAnswerCallbackQuery
+
+	funcAnswerInlineQuery          func(inline tgbotapi.InlineConfig) (err error)
+	inspectFuncAnswerInlineQuery   func(inline tgbotapi.InlineConfig)
+	afterAnswerInlineQueryCounter  uint64
+	beforeAnswerInlineQueryCounter uint64
+	AnswerInlineQueryMock          mTelegramBotAPIMockAnswerInlineQuery
+
+	funcDeleteMessage          func(chatID int64, messageID int) (err error)
+	inspectFuncDeleteMessage   func(chatID int64, messageID int)
+	afterDeleteMessageCounter  uint64
+	beforeDeleteMessageCounter uint64
+	DeleteMessageMock          mTelegramBotAPIMockDeleteMessage
+
+	funcEditCaption          func(chatID int64, messageID int, caption string, parseMode string) (err error)
+	inspectFuncEditCaption   func(chatID int64, messageID int, caption string, parseMode string)
+	afterEditCaptionCounter  uint64
+	beforeEditCaptionCounter uint64
+	EditCaptionMock          mTelegramBotAPIMockEditCaption
+
+	funcEditMessage          func(chatID int64, messageID int, text string, parseMode string) (err error)
+	inspectFuncEditMessage   func(chatID int64, messageID int, text string, parseMode string)
 	afterEditMessageCounter  uint64
 	beforeEditMessageCounter uint64
 	EditMessageMock          mTelegramBotAPIMockEditMessage
 
-	funcGetChatPinnedMessageID          func(chatID int64) (i1 int, err error)
-	inspectFuncGetChatPinnedMessageID   func(chatID int64)
-	afterGetChatPinnedMessageIDCounter  uint64
-	beforeGetChatPinnedMessageIDCounter uint64
-	GetChatPinnedMessageIDMock          mTelegramBotAPIMockGetChatPinnedMessageID
-
-	funcGetUpdates          func() (ua1 []tgbotapi.Update, err error)
-	inspectFuncGetUpdates   func()
+	funcGetUpdates          func(offset int) (ua1 []tgbotapi.Update, err error)
+	inspectFuncGetUpdates   func(offset int)
 	afterGetUpdatesCounter  uint64
 	beforeGetUpdatesCounter uint64
 	GetUpdatesMock          mTelegramBotAPIMockGetUpdates
@@ -41,17 +59,29 @@ type TelegramBotAPIMock struct {
 	beforePinMessageCounter uint64
 	PinMessageMock          mTelegramBotAPIMockPinMessage
 
-	funcSendAnimation          func(chatID int64, fileID string, caption string) (i1 int, err error)
-	inspectFuncSendAnimation   func(chatID int64, fileID string, caption string)
+	funcSendAnimation          func(chatID int64, fileID string, caption string, parseMode string) (i1 int, err error)
+	inspectFuncSendAnimation   func(chatID int64, fileID string, caption string, parseMode string)
 	afterSendAnimationCounter  uint64
 	beforeSendAnimationCounter uint64
 	SendAnimationMock          mTelegramBotAPIMockSendAnimation
 
-	funcSendMessage          func(chatID int64, text string) (i1 int, err error)
-	inspectFuncSendMessage   func(chatID int64, text string)
+	funcSendMessage          func(chatID int64, text string, parseMode string) (i1 int, err error)
+	inspectFuncSendMessage   func(chatID int64, text string, parseMode string)
 	afterSendMessageCounter  uint64
 	beforeSendMessageCounter uint64
 	SendMessageMock          mTelegramBotAPIMockSendMessage
+
+	funcSendReply          func(chatID int64, replyToID int, text string) (i1 int, err error)
+	inspectFuncSendReply   func(chatID int64, replyToID int, text string)
+	afterSendReplyCounter  uint64
+	beforeSendReplyCounter uint64
+	SendReplyMock          mTelegramBotAPIMockSendReply
+
+	funcSendReplyKeyboard          func(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) (i1 int, err error)
+	inspectFuncSendReplyKeyboard   func(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup)
+	afterSendReplyKeyboardCounter  uint64
+	beforeSendReplyKeyboardCounter uint64
+	SendReplyKeyboardMock          mTelegramBotAPIMockSendReplyKeyboard
 }
 
 // NewTelegramBotAPIMock returns a mock for telegramBotAPI
@@ -61,456 +91,1122 @@ func NewTelegramBotAPIMock(t minimock.Tester) *TelegramBotAPIMock {
 		controller.RegisterMocker(m)
 	}
 
-	m.EditMessageMock = mTelegramBotAPIMockEditMessage{mock: m}
-	m.EditMessageMock.callArgs = []*TelegramBotAPIMockEditMessageParams{}
+	m.AnswerCallbackQueryMock = mTelegramBotAPIMockAnswerCallbackQuery{mock: m}
+	m.AnswerCallbackQueryMock.callArgs = []*TelegramBotAPIMockAnswerCallbackQueryParams{}
+
+	m.AnswerInlineQueryMock = mTelegramBotAPIMockAnswerInlineQuery{mock: m}
+	m.AnswerInlineQueryMock.callArgs = []*TelegramBotAPIMockAnswerInlineQueryParams{}
+
+	m.DeleteMessageMock = mTelegramBotAPIMockDeleteMessage{mock: m}
+	m.DeleteMessageMock.callArgs = []*TelegramBotAPIMockDeleteMessageParams{}
+
+	m.EditCaptionMock = mTelegramBotAPIMockEditCaption{mock: m}
+	m.EditCaptionMock.callArgs = []*TelegramBotAPIMockEditCaptionParams{}
+
+	m.EditMessageMock = mTelegramBotAPIMockEditMessage{mock: m}
+	m.EditMessageMock.callArgs = []*TelegramBotAPIMockEditMessageParams{}
+
+	m.GetUpdatesMock = mTelegramBotAPIMockGetUpdates{mock: m}
+	m.GetUpdatesMock.callArgs = []*TelegramBotAPIMockGetUpdatesParams{}
+
+	m.PinMessageMock = mTelegramBotAPIMockPinMessage{mock: m}
+	m.PinMessageMock.callArgs = []*TelegramBotAPIMockPinMessageParams{}
+
+	m.SendAnimationMock = mTelegramBotAPIMockSendAnimation{mock: m}
+	m.SendAnimationMock.callArgs = []*TelegramBotAPIMockSendAnimationParams{}
+
+	m.SendMessageMock = mTelegramBotAPIMockSendMessage{mock: m}
+	m.SendMessageMock.callArgs = []*TelegramBotAPIMockSendMessageParams{}
+
+	m.SendReplyMock = mTelegramBotAPIMockSendReply{mock: m}
+	m.SendReplyMock.callArgs = []*TelegramBotAPIMockSendReplyParams{}
+
+	m.SendReplyKeyboardMock = mTelegramBotAPIMockSendReplyKeyboard{mock: m}
+	m.SendReplyKeyboardMock.callArgs = []*TelegramBotAPIMockSendReplyKeyboardParams{}
+
+	return m
+}
+
+type mTelegramBotAPIMockAnswerCallbackQuery struct {
+	mock               *TelegramBotAPIMock
+	defaultExpectation *TelegramBotAPIMockAnswerCallbackQueryExpectation
+	expectations       []*TelegramBotAPIMockAnswerCallbackQueryExpectation
+
+	callArgs []*TelegramBotAPIMockAnswerCallbackQueryParams
+	mutex    sync.RWMutex
+}
+
+// TelegramBotAPIMockAnswerCallbackQueryExpectation specifies expectation struct of the telegramBotAPI.AnswerCallbackQuery
+type TelegramBotAPIMockAnswerCallbackQueryExpectation struct {
+	mock    *TelegramBotAPIMock
+	params  *TelegramBotAPIMockAnswerCallbackQueryParams
+	results *TelegramBotAPIMockAnswerCallbackQueryResults
+	Counter uint64
+}
+
+// TelegramBotAPIMockAnswerCallbackQueryParams contains parameters of the telegramBotAPI.AnswerCallbackQuery
+type TelegramBotAPIMockAnswerCallbackQueryParams struct {
+	callbackID string
+	text       string
+}
+
+// TelegramBotAPIMockAnswerCallbackQueryResults contains results of the telegramBotAPI.AnswerCallbackQuery
+type TelegramBotAPIMockAnswerCallbackQueryResults struct {
+	err error
+}
+
+// Expect sets up expected params for telegramBotAPI.AnswerCallbackQuery
+func (mmAnswerCallbackQuery *mTelegramBotAPIMockAnswerCallbackQuery) Expect(callbackID string, text string) *mTelegramBotAPIMockAnswerCallbackQuery {
+	if mmAnswerCallbackQuery.mock.funcAnswerCallbackQuery != nil {
+		mmAnswerCallbackQuery.mock.t.Fatalf("TelegramBotAPIMock.AnswerCallbackQuery mock is already set by Set")
+	}
+
+	if mmAnswerCallbackQuery.defaultExpectation == nil {
+		mmAnswerCallbackQuery.defaultExpectation = &TelegramBotAPIMockAnswerCallbackQueryExpectation{}
+	}
+
+	mmAnswerCallbackQuery.defaultExpectation.params = &TelegramBotAPIMockAnswerCallbackQueryParams{callbackID, text}
+	for _, e := range mmAnswerCallbackQuery.expectations {
+		if minimock.Equal(e.params, mmAnswerCallbackQuery.defaultExpectation.params) {
+			mmAnswerCallbackQuery.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAnswerCallbackQuery.defaultExpectation.params)
+		}
+	}
+
+	return mmAnswerCallbackQuery
+}
+
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.AnswerCallbackQuery
+func (mmAnswerCallbackQuery *mTelegramBotAPIMockAnswerCallbackQuery) Inspect(f func(callbackID string, text string)) *mTelegramBotAPIMockAnswerCallbackQuery {
+	if mmAnswerCallbackQuery.mock.inspectFuncAnswerCallbackQuery != nil {
+		mmAnswerCallbackQuery.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.AnswerCallbackQuery")
+	}
+
+	mmAnswerCallbackQuery.mock.inspectFuncAnswerCallbackQuery = f
+
+	return mmAnswerCallbackQuery
+}
+
+// Return sets up results that will be returned by telegramBotAPI.AnswerCallbackQuery
+func (mmAnswerCallbackQuery *mTelegramBotAPIMockAnswerCallbackQuery) Return(err error) *TelegramBotAPIMock {
+	if mmAnswerCallbackQuery.mock.funcAnswerCallbackQuery != nil {
+		mmAnswerCallbackQuery.mock.t.Fatalf("TelegramBotAPIMock.AnswerCallbackQuery mock is already set by Set")
+	}
+
+	if mmAnswerCallbackQuery.defaultExpectation == nil {
+		mmAnswerCallbackQuery.defaultExpectation = &TelegramBotAPIMockAnswerCallbackQueryExpectation{mock: mmAnswerCallbackQuery.mock}
+	}
+	mmAnswerCallbackQuery.defaultExpectation.results = &TelegramBotAPIMockAnswerCallbackQueryResults{err}
+	return mmAnswerCallbackQuery.mock
+}
+
+// Set uses given function f to mock the telegramBotAPI.AnswerCallbackQuery method
+func (mmAnswerCallbackQuery *mTelegramBotAPIMockAnswerCallbackQuery) Set(f func(callbackID string, text string) (err error)) *TelegramBotAPIMock {
+	if mmAnswerCallbackQuery.defaultExpectation != nil {
+		mmAnswerCallbackQuery.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.AnswerCallbackQuery method")
+	}
+
+	if len(mmAnswerCallbackQuery.expectations) > 0 {
+		mmAnswerCallbackQuery.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.AnswerCallbackQuery method")
+	}
+
+	mmAnswerCallbackQuery.mock.funcAnswerCallbackQuery = f
+	return mmAnswerCallbackQuery.mock
+}
+
+// When sets expectation for the telegramBotAPI.AnswerCallbackQuery which will trigger the result defined by the following
+// Then helper
+func (mmAnswerCallbackQuery *mTelegramBotAPIMockAnswerCallbackQuery) When(callbackID string, text string) *TelegramBotAPIMockAnswerCallbackQueryExpectation {
+	if mmAnswerCallbackQuery.mock.funcAnswerCallbackQuery != nil {
+		mmAnswerCallbackQuery.mock.t.Fatalf("TelegramBotAPIMock.AnswerCallbackQuery mock is already set by Set")
+	}
+
+	expectation := &TelegramBotAPIMockAnswerCallbackQueryExpectation{
+		mock:   mmAnswerCallbackQuery.mock,
+		params: &TelegramBotAPIMockAnswerCallbackQueryParams{callbackID, text},
+	}
+	mmAnswerCallbackQuery.expectations = append(mmAnswerCallbackQuery.expectations, expectation)
+	return expectation
+}
+
+// Then sets up telegramBotAPI.AnswerCallbackQuery return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockAnswerCallbackQueryExpectation) Then(err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockAnswerCallbackQueryResults{err}
+	return e.mock
+}
+
+// AnswerCallbackQuery implements telegramBotAPI
+func (mmAnswerCallbackQuery *TelegramBotAPIMock) AnswerCallbackQuery(callbackID string, text string) (err error) {
+	mm_atomic.AddUint64(&mmAnswerCallbackQuery.beforeAnswerCallbackQueryCounter, 1)
+	defer mm_atomic.AddUint64(&mmAnswerCallbackQuery.afterAnswerCallbackQueryCounter, 1)
+
+	if mmAnswerCallbackQuery.inspectFuncAnswerCallbackQuery != nil {
+		mmAnswerCallbackQuery.inspectFuncAnswerCallbackQuery(callbackID, text)
+	}
+
+	mm_params := &TelegramBotAPIMockAnswerCallbackQueryParams{callbackID, text}
+
+	// Record call args
+	mmAnswerCallbackQuery.AnswerCallbackQueryMock.mutex.Lock()
+	mmAnswerCallbackQuery.AnswerCallbackQueryMock.callArgs = append(mmAnswerCallbackQuery.AnswerCallbackQueryMock.callArgs, mm_params)
+	mmAnswerCallbackQuery.AnswerCallbackQueryMock.mutex.Unlock()
+
+	for _, e := range mmAnswerCallbackQuery.AnswerCallbackQueryMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmAnswerCallbackQuery.AnswerCallbackQueryMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAnswerCallbackQuery.AnswerCallbackQueryMock.defaultExpectation.Counter, 1)
+		mm_want := mmAnswerCallbackQuery.AnswerCallbackQueryMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockAnswerCallbackQueryParams{callbackID, text}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAnswerCallbackQuery.t.Errorf("TelegramBotAPIMock.AnswerCallbackQuery got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmAnswerCallbackQuery.AnswerCallbackQueryMock.defaultExpectation.results
+		if mm_results == nil {
+			mmAnswerCallbackQuery.t.Fatal("No results are set for the TelegramBotAPIMock.AnswerCallbackQuery")
+		}
+		return (*mm_results).err
+	}
+	if mmAnswerCallbackQuery.funcAnswerCallbackQuery != nil {
+		return mmAnswerCallbackQuery.funcAnswerCallbackQuery(callbackID, text)
+	}
+	mmAnswerCallbackQuery.t.Fatalf("Unexpected call to TelegramBotAPIMock.AnswerCallbackQuery. %v %v", callbackID, text)
+	return
+}
+
+// AnswerCallbackQueryAfterCounter returns a count of finished TelegramBotAPIMock.AnswerCallbackQuery invocations
+func (mmAnswerCallbackQuery *TelegramBotAPIMock) AnswerCallbackQueryAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAnswerCallbackQuery.afterAnswerCallbackQueryCounter)
+}
+
+// AnswerCallbackQueryBeforeCounter returns a count of TelegramBotAPIMock.AnswerCallbackQuery invocations
+func (mmAnswerCallbackQuery *TelegramBotAPIMock) AnswerCallbackQueryBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAnswerCallbackQuery.beforeAnswerCallbackQueryCounter)
+}
+
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.AnswerCallbackQuery.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAnswerCallbackQuery *mTelegramBotAPIMockAnswerCallbackQuery) Calls() []*TelegramBotAPIMockAnswerCallbackQueryParams {
+	mmAnswerCallbackQuery.mutex.RLock()
+
+	argCopy := make([]*TelegramBotAPIMockAnswerCallbackQueryParams, len(mmAnswerCallbackQuery.callArgs))
+	copy(argCopy, mmAnswerCallbackQuery.callArgs)
+
+	mmAnswerCallbackQuery.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAnswerCallbackQueryDone returns true if the count of the AnswerCallbackQuery invocations corresponds
+// the number of defined expectations
+func (m *TelegramBotAPIMock) MinimockAnswerCallbackQueryDone() bool {
+	for _, e := range m.AnswerCallbackQueryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AnswerCallbackQueryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAnswerCallbackQueryCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAnswerCallbackQuery != nil && mm_atomic.LoadUint64(&m.afterAnswerCallbackQueryCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockAnswerCallbackQueryInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockAnswerCallbackQueryInspect() {
+	for _, e := range m.AnswerCallbackQueryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.AnswerCallbackQuery with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AnswerCallbackQueryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAnswerCallbackQueryCounter) < 1 {
+		if m.AnswerCallbackQueryMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.AnswerCallbackQuery")
+		} else {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.AnswerCallbackQuery with params: %#v", *m.AnswerCallbackQueryMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAnswerCallbackQuery != nil && mm_atomic.LoadUint64(&m.afterAnswerCallbackQueryCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.AnswerCallbackQuery")
+	}
+}
+
+type mTelegramBotAPIMockAnswerInlineQuery struct {
+	mock               *TelegramBotAPIMock
+	defaultExpectation *TelegramBotAPIMockAnswerInlineQueryExpectation
+	expectations       []*TelegramBotAPIMockAnswerInlineQueryExpectation
+
+	callArgs []*TelegramBotAPIMockAnswerInlineQueryParams
+	mutex    sync.RWMutex
+}
+
+// TelegramBotAPIMockAnswerInlineQueryExpectation specifies expectation struct of the telegramBotAPI.AnswerInlineQuery
+type TelegramBotAPIMockAnswerInlineQueryExpectation struct {
+	mock    *TelegramBotAPIMock
+	params  *TelegramBotAPIMockAnswerInlineQueryParams
+	results *TelegramBotAPIMockAnswerInlineQueryResults
+	Counter uint64
+}
+
+// TelegramBotAPIMockAnswerInlineQueryParams contains parameters of the telegramBotAPI.AnswerInlineQuery
+type TelegramBotAPIMockAnswerInlineQueryParams struct {
+	inline tgbotapi.InlineConfig
+}
+
+// TelegramBotAPIMockAnswerInlineQueryResults contains results of the telegramBotAPI.AnswerInlineQuery
+type TelegramBotAPIMockAnswerInlineQueryResults struct {
+	err error
+}
+
+// Expect sets up expected params for telegramBotAPI.AnswerInlineQuery
+func (mmAnswerInlineQuery *mTelegramBotAPIMockAnswerInlineQuery) Expect(inline tgbotapi.InlineConfig) *mTelegramBotAPIMockAnswerInlineQuery {
+	if mmAnswerInlineQuery.mock.funcAnswerInlineQuery != nil {
+		mmAnswerInlineQuery.mock.t.Fatalf("TelegramBotAPIMock.AnswerInlineQuery mock is already set by Set")
+	}
+
+	if mmAnswerInlineQuery.defaultExpectation == nil {
+		mmAnswerInlineQuery.defaultExpectation = &TelegramBotAPIMockAnswerInlineQueryExpectation{}
+	}
+
+	mmAnswerInlineQuery.defaultExpectation.params = &TelegramBotAPIMockAnswerInlineQueryParams{inline}
+	for _, e := range mmAnswerInlineQuery.expectations {
+		if minimock.Equal(e.params, mmAnswerInlineQuery.defaultExpectation.params) {
+			mmAnswerInlineQuery.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAnswerInlineQuery.defaultExpectation.params)
+		}
+	}
+
+	return mmAnswerInlineQuery
+}
+
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.AnswerInlineQuery
+func (mmAnswerInlineQuery *mTelegramBotAPIMockAnswerInlineQuery) Inspect(f func(inline tgbotapi.InlineConfig)) *mTelegramBotAPIMockAnswerInlineQuery {
+	if mmAnswerInlineQuery.mock.inspectFuncAnswerInlineQuery != nil {
+		mmAnswerInlineQuery.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.AnswerInlineQuery")
+	}
+
+	mmAnswerInlineQuery.mock.inspectFuncAnswerInlineQuery = f
+
+	return mmAnswerInlineQuery
+}
+
+// Return sets up results that will be returned by telegramBotAPI.AnswerInlineQuery
+func (mmAnswerInlineQuery *mTelegramBotAPIMockAnswerInlineQuery) Return(err error) *TelegramBotAPIMock {
+	if mmAnswerInlineQuery.mock.funcAnswerInlineQuery != nil {
+		mmAnswerInlineQuery.mock.t.Fatalf("TelegramBotAPIMock.AnswerInlineQuery mock is already set by Set")
+	}
+
+	if mmAnswerInlineQuery.defaultExpectation == nil {
+		mmAnswerInlineQuery.defaultExpectation = &TelegramBotAPIMockAnswerInlineQueryExpectation{mock: mmAnswerInlineQuery.mock}
+	}
+	mmAnswerInlineQuery.defaultExpectation.results = &TelegramBotAPIMockAnswerInlineQueryResults{err}
+	return mmAnswerInlineQuery.mock
+}
+
+// Set uses given function f to mock the telegramBotAPI.AnswerInlineQuery method
+func (mmAnswerInlineQuery *mTelegramBotAPIMockAnswerInlineQuery) Set(f func(inline tgbotapi.InlineConfig) (err error)) *TelegramBotAPIMock {
+	if mmAnswerInlineQuery.defaultExpectation != nil {
+		mmAnswerInlineQuery.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.AnswerInlineQuery method")
+	}
+
+	if len(mmAnswerInlineQuery.expectations) > 0 {
+		mmAnswerInlineQuery.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.AnswerInlineQuery method")
+	}
+
+	mmAnswerInlineQuery.mock.funcAnswerInlineQuery = f
+	return mmAnswerInlineQuery.mock
+}
+
+// When sets expectation for the telegramBotAPI.AnswerInlineQuery which will trigger the result defined by the following
+// Then helper
+func (mmAnswerInlineQuery *mTelegramBotAPIMockAnswerInlineQuery) When(inline tgbotapi.InlineConfig) *TelegramBotAPIMockAnswerInlineQueryExpectation {
+	if mmAnswerInlineQuery.mock.funcAnswerInlineQuery != nil {
+		mmAnswerInlineQuery.mock.t.Fatalf("TelegramBotAPIMock.AnswerInlineQuery mock is already set by Set")
+	}
+
+	expectation := &TelegramBotAPIMockAnswerInlineQueryExpectation{
+		mock:   mmAnswerInlineQuery.mock,
+		params: &TelegramBotAPIMockAnswerInlineQueryParams{inline},
+	}
+	mmAnswerInlineQuery.expectations = append(mmAnswerInlineQuery.expectations, expectation)
+	return expectation
+}
+
+// Then sets up telegramBotAPI.AnswerInlineQuery return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockAnswerInlineQueryExpectation) Then(err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockAnswerInlineQueryResults{err}
+	return e.mock
+}
+
+// AnswerInlineQuery implements telegramBotAPI
+func (mmAnswerInlineQuery *TelegramBotAPIMock) AnswerInlineQuery(inline tgbotapi.InlineConfig) (err error) {
+	mm_atomic.AddUint64(&mmAnswerInlineQuery.beforeAnswerInlineQueryCounter, 1)
+	defer mm_atomic.AddUint64(&mmAnswerInlineQuery.afterAnswerInlineQueryCounter, 1)
+
+	if mmAnswerInlineQuery.inspectFuncAnswerInlineQuery != nil {
+		mmAnswerInlineQuery.inspectFuncAnswerInlineQuery(inline)
+	}
+
+	mm_params := &TelegramBotAPIMockAnswerInlineQueryParams{inline}
+
+	// Record call args
+	mmAnswerInlineQuery.AnswerInlineQueryMock.mutex.Lock()
+	mmAnswerInlineQuery.AnswerInlineQueryMock.callArgs = append(mmAnswerInlineQuery.AnswerInlineQueryMock.callArgs, mm_params)
+	mmAnswerInlineQuery.AnswerInlineQueryMock.mutex.Unlock()
+
+	for _, e := range mmAnswerInlineQuery.AnswerInlineQueryMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmAnswerInlineQuery.AnswerInlineQueryMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAnswerInlineQuery.AnswerInlineQueryMock.defaultExpectation.Counter, 1)
+		mm_want := mmAnswerInlineQuery.AnswerInlineQueryMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockAnswerInlineQueryParams{inline}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAnswerInlineQuery.t.Errorf("TelegramBotAPIMock.AnswerInlineQuery got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmAnswerInlineQuery.AnswerInlineQueryMock.defaultExpectation.results
+		if mm_results == nil {
+			mmAnswerInlineQuery.t.Fatal("No results are set for the TelegramBotAPIMock.AnswerInlineQuery")
+		}
+		return (*mm_results).err
+	}
+	if mmAnswerInlineQuery.funcAnswerInlineQuery != nil {
+		return mmAnswerInlineQuery.funcAnswerInlineQuery(inline)
+	}
+	mmAnswerInlineQuery.t.Fatalf("Unexpected call to TelegramBotAPIMock.AnswerInlineQuery. %v", inline)
+	return
+}
+
+// AnswerInlineQueryAfterCounter returns a count of finished TelegramBotAPIMock.AnswerInlineQuery invocations
+func (mmAnswerInlineQuery *TelegramBotAPIMock) AnswerInlineQueryAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAnswerInlineQuery.afterAnswerInlineQueryCounter)
+}
+
+// AnswerInlineQueryBeforeCounter returns a count of TelegramBotAPIMock.AnswerInlineQuery invocations
+func (mmAnswerInlineQuery *TelegramBotAPIMock) AnswerInlineQueryBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAnswerInlineQuery.beforeAnswerInlineQueryCounter)
+}
+
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.AnswerInlineQuery.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAnswerInlineQuery *mTelegramBotAPIMockAnswerInlineQuery) Calls() []*TelegramBotAPIMockAnswerInlineQueryParams {
+	mmAnswerInlineQuery.mutex.RLock()
+
+	argCopy := make([]*TelegramBotAPIMockAnswerInlineQueryParams, len(mmAnswerInlineQuery.callArgs))
+	copy(argCopy, mmAnswerInlineQuery.callArgs)
+
+	mmAnswerInlineQuery.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAnswerInlineQueryDone returns true if the count of the AnswerInlineQuery invocations corresponds
+// the number of defined expectations
+func (m *TelegramBotAPIMock) MinimockAnswerInlineQueryDone() bool {
+	for _, e := range m.AnswerInlineQueryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AnswerInlineQueryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAnswerInlineQueryCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAnswerInlineQuery != nil && mm_atomic.LoadUint64(&m.afterAnswerInlineQueryCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockAnswerInlineQueryInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockAnswerInlineQueryInspect() {
+	for _, e := range m.AnswerInlineQueryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.AnswerInlineQuery with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AnswerInlineQueryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAnswerInlineQueryCounter) < 1 {
+		if m.AnswerInlineQueryMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.AnswerInlineQuery")
+		} else {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.AnswerInlineQuery with params: %#v", *m.AnswerInlineQueryMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAnswerInlineQuery != nil && mm_atomic.LoadUint64(&m.afterAnswerInlineQueryCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.AnswerInlineQuery")
+	}
+}
+
+type mTelegramBotAPIMockDeleteMessage struct {
+	mock               *TelegramBotAPIMock
+	defaultExpectation *TelegramBotAPIMockDeleteMessageExpectation
+	expectations       []*TelegramBotAPIMockDeleteMessageExpectation
+
+	callArgs []*TelegramBotAPIMockDeleteMessageParams
+	mutex    sync.RWMutex
+}
+
+// TelegramBotAPIMockDeleteMessageExpectation specifies expectation struct of the telegramBotAPI.DeleteMessage
+type TelegramBotAPIMockDeleteMessageExpectation struct {
+	mock    *TelegramBotAPIMock
+	params  *TelegramBotAPIMockDeleteMessageParams
+	results *TelegramBotAPIMockDeleteMessageResults
+	Counter uint64
+}
+
+// TelegramBotAPIMockDeleteMessageParams contains parameters of the telegramBotAPI.DeleteMessage
+type TelegramBotAPIMockDeleteMessageParams struct {
+	chatID    int64
+	messageID int
+}
+
+// TelegramBotAPIMockDeleteMessageResults contains results of the telegramBotAPI.DeleteMessage
+type TelegramBotAPIMockDeleteMessageResults struct {
+	err error
+}
+
+// Expect sets up expected params for telegramBotAPI.DeleteMessage
+func (mmDeleteMessage *mTelegramBotAPIMockDeleteMessage) Expect(chatID int64, messageID int) *mTelegramBotAPIMockDeleteMessage {
+	if mmDeleteMessage.mock.funcDeleteMessage != nil {
+		mmDeleteMessage.mock.t.Fatalf("TelegramBotAPIMock.DeleteMessage mock is already set by Set")
+	}
+
+	if mmDeleteMessage.defaultExpectation == nil {
+		mmDeleteMessage.defaultExpectation = &TelegramBotAPIMockDeleteMessageExpectation{}
+	}
+
+	mmDeleteMessage.defaultExpectation.params = &TelegramBotAPIMockDeleteMessageParams{chatID, messageID}
+	for _, e := range mmDeleteMessage.expectations {
+		if minimock.Equal(e.params, mmDeleteMessage.defaultExpectation.params) {
+			mmDeleteMessage.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteMessage.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteMessage
+}
+
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.DeleteMessage
+func (mmDeleteMessage *mTelegramBotAPIMockDeleteMessage) Inspect(f func(chatID int64, messageID int)) *mTelegramBotAPIMockDeleteMessage {
+	if mmDeleteMessage.mock.inspectFuncDeleteMessage != nil {
+		mmDeleteMessage.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.DeleteMessage")
+	}
+
+	mmDeleteMessage.mock.inspectFuncDeleteMessage = f
+
+	return mmDeleteMessage
+}
+
+// Return sets up results that will be returned by telegramBotAPI.DeleteMessage
+func (mmDeleteMessage *mTelegramBotAPIMockDeleteMessage) Return(err error) *TelegramBotAPIMock {
+	if mmDeleteMessage.mock.funcDeleteMessage != nil {
+		mmDeleteMessage.mock.t.Fatalf("TelegramBotAPIMock.DeleteMessage mock is already set by Set")
+	}
+
+	if mmDeleteMessage.defaultExpectation == nil {
+		mmDeleteMessage.defaultExpectation = &TelegramBotAPIMockDeleteMessageExpectation{mock: mmDeleteMessage.mock}
+	}
+	mmDeleteMessage.defaultExpectation.results = &TelegramBotAPIMockDeleteMessageResults{err}
+	return mmDeleteMessage.mock
+}
+
+// Set uses given function f to mock the telegramBotAPI.DeleteMessage method
+func (mmDeleteMessage *mTelegramBotAPIMockDeleteMessage) Set(f func(chatID int64, messageID int) (err error)) *TelegramBotAPIMock {
+	if mmDeleteMessage.defaultExpectation != nil {
+		mmDeleteMessage.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.DeleteMessage method")
+	}
+
+	if len(mmDeleteMessage.expectations) > 0 {
+		mmDeleteMessage.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.DeleteMessage method")
+	}
+
+	mmDeleteMessage.mock.funcDeleteMessage = f
+	return mmDeleteMessage.mock
+}
+
+// When sets expectation for the telegramBotAPI.DeleteMessage which will trigger the result defined by the following
+// Then helper
+func (mmDeleteMessage *mTelegramBotAPIMockDeleteMessage) When(chatID int64, messageID int) *TelegramBotAPIMockDeleteMessageExpectation {
+	if mmDeleteMessage.mock.funcDeleteMessage != nil {
+		mmDeleteMessage.mock.t.Fatalf("TelegramBotAPIMock.DeleteMessage mock is already set by Set")
+	}
+
+	expectation := &TelegramBotAPIMockDeleteMessageExpectation{
+		mock:   mmDeleteMessage.mock,
+		params: &TelegramBotAPIMockDeleteMessageParams{chatID, messageID},
+	}
+	mmDeleteMessage.expectations = append(mmDeleteMessage.expectations, expectation)
+	return expectation
+}
+
+// Then sets up telegramBotAPI.DeleteMessage return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockDeleteMessageExpectation) Then(err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockDeleteMessageResults{err}
+	return e.mock
+}
+
+// DeleteMessage implements telegramBotAPI
+func (mmDeleteMessage *TelegramBotAPIMock) DeleteMessage(chatID int64, messageID int) (err error) {
+	mm_atomic.AddUint64(&mmDeleteMessage.beforeDeleteMessageCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteMessage.afterDeleteMessageCounter, 1)
+
+	if mmDeleteMessage.inspectFuncDeleteMessage != nil {
+		mmDeleteMessage.inspectFuncDeleteMessage(chatID, messageID)
+	}
+
+	mm_params := &TelegramBotAPIMockDeleteMessageParams{chatID, messageID}
+
+	// Record call args
+	mmDeleteMessage.DeleteMessageMock.mutex.Lock()
+	mmDeleteMessage.DeleteMessageMock.callArgs = append(mmDeleteMessage.DeleteMessageMock.callArgs, mm_params)
+	mmDeleteMessage.DeleteMessageMock.mutex.Unlock()
+
+	for _, e := range mmDeleteMessage.DeleteMessageMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteMessage.DeleteMessageMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteMessage.DeleteMessageMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteMessage.DeleteMessageMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockDeleteMessageParams{chatID, messageID}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteMessage.t.Errorf("TelegramBotAPIMock.DeleteMessage got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteMessage.DeleteMessageMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteMessage.t.Fatal("No results are set for the TelegramBotAPIMock.DeleteMessage")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteMessage.funcDeleteMessage != nil {
+		return mmDeleteMessage.funcDeleteMessage(chatID, messageID)
+	}
+	mmDeleteMessage.t.Fatalf("Unexpected call to TelegramBotAPIMock.DeleteMessage. %v %v", chatID, messageID)
+	return
+}
+
+// DeleteMessageAfterCounter returns a count of finished TelegramBotAPIMock.DeleteMessage invocations
+func (mmDeleteMessage *TelegramBotAPIMock) DeleteMessageAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteMessage.afterDeleteMessageCounter)
+}
 
-	m.GetChatPinnedMessageIDMock = mTelegramBotAPIMockGetChatPinnedMessageID{mock: m}
-	m.GetChatPinnedMessageIDMock.callArgs = []*TelegramBotAPIMockGetChatPinnedMessageIDParams{}
+// DeleteMessageBeforeCounter returns a count of TelegramBotAPIMock.DeleteMessage invocations
+func (mmDeleteMessage *TelegramBotAPIMock) DeleteMessageBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteMessage.beforeDeleteMessageCounter)
+}
 
-	m.GetUpdatesMock = mTelegramBotAPIMockGetUpdates{mock: m}
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.DeleteMessage.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteMessage *mTelegramBotAPIMockDeleteMessage) Calls() []*TelegramBotAPIMockDeleteMessageParams {
+	mmDeleteMessage.mutex.RLock()
 
-	m.PinMessageMock = mTelegramBotAPIMockPinMessage{mock: m}
-	m.PinMessageMock.callArgs = []*TelegramBotAPIMockPinMessageParams{}
+	argCopy := make([]*TelegramBotAPIMockDeleteMessageParams, len(mmDeleteMessage.callArgs))
+	copy(argCopy, mmDeleteMessage.callArgs)
 
-	m.SendAnimationMock = mTelegramBotAPIMockSendAnimation{mock: m}
-	m.SendAnimationMock.callArgs = []*TelegramBotAPIMockSendAnimationParams{}
+	mmDeleteMessage.mutex.RUnlock()
 
-	m.SendMessageMock = mTelegramBotAPIMockSendMessage{mock: m}
-	m.SendMessageMock.callArgs = []*TelegramBotAPIMockSendMessageParams{}
+	return argCopy
+}
 
-	return m
+// MinimockDeleteMessageDone returns true if the count of the DeleteMessage invocations corresponds
+// the number of defined expectations
+func (m *TelegramBotAPIMock) MinimockDeleteMessageDone() bool {
+	for _, e := range m.DeleteMessageMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteMessageMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteMessageCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteMessage != nil && mm_atomic.LoadUint64(&m.afterDeleteMessageCounter) < 1 {
+		return false
+	}
+	return true
 }
 
-type mTelegramBotAPIMockEditMessage struct {
+// MinimockDeleteMessageInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockDeleteMessageInspect() {
+	for _, e := range m.DeleteMessageMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.DeleteMessage with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteMessageMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteMessageCounter) < 1 {
+		if m.DeleteMessageMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.DeleteMessage")
+		} else {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.DeleteMessage with params: %#v", *m.DeleteMessageMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteMessage != nil && mm_atomic.LoadUint64(&m.afterDeleteMessageCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.DeleteMessage")
+	}
+}
+
+type mTelegramBotAPIMockEditCaption struct {
 	mock               *TelegramBotAPIMock
-	defaultExpectation *TelegramBotAPIMockEditMessageExpectation
-	expectations       []*TelegramBotAPIMockEditMessageExpectation
+	defaultExpectation *TelegramBotAPIMockEditCaptionExpectation
+	expectations       []*TelegramBotAPIMockEditCaptionExpectation
 
-	callArgs []*TelegramBotAPIMockEditMessageParams
+	callArgs []*TelegramBotAPIMockEditCaptionParams
 	mutex    sync.RWMutex
 }
 
-// TelegramBotAPIMockEditMessageExpectation specifies expectation struct of the telegramBotAPI.EditMessage
-type TelegramBotAPIMockEditMessageExpectation struct {
+// TelegramBotAPIMockEditCaptionExpectation specifies expectation struct of the telegramBotAPI.EditCaption
+type TelegramBotAPIMockEditCaptionExpectation struct {
 	mock    *TelegramBotAPIMock
-	params  *TelegramBotAPIMockEditMessageParams
-	results *TelegramBotAPIMockEditMessageResults
+	params  *TelegramBotAPIMockEditCaptionParams
+	results *TelegramBotAPIMockEditCaptionResults
 	Counter uint64
 }
 
-// TelegramBotAPIMockEditMessageParams contains parameters of the telegramBotAPI.EditMessage
-type TelegramBotAPIMockEditMessageParams struct {
+// TelegramBotAPIMockEditCaptionParams contains parameters of the telegramBotAPI.EditCaption
+type TelegramBotAPIMockEditCaptionParams struct {
 	chatID    int64
 	messageID int
-	text      string
+	caption   string
+	parseMode string
 }
 
-// TelegramBotAPIMockEditMessageResults contains results of the telegramBotAPI.EditMessage
-type TelegramBotAPIMockEditMessageResults struct {
+// TelegramBotAPIMockEditCaptionResults contains results of the telegramBotAPI.EditCaption
+type TelegramBotAPIMockEditCaptionResults struct {
 	err error
 }
 
-// Expect sets up expected params for telegramBotAPI.EditMessage
-func (mmEditMessage *mTelegramBotAPIMockEditMessage) Expect(chatID int64, messageID int, text string) *mTelegramBotAPIMockEditMessage {
-	if mmEditMessage.mock.funcEditMessage != nil {
-		mmEditMessage.mock.t.Fatalf("TelegramBotAPIMock.EditMessage mock is already set by Set")
+// Expect sets up expected params for telegramBotAPI.EditCaption
+func (mmEditCaption *mTelegramBotAPIMockEditCaption) Expect(chatID int64, messageID int, caption string, parseMode string) *mTelegramBotAPIMockEditCaption {
+	if mmEditCaption.mock.funcEditCaption != nil {
+		mmEditCaption.mock.t.Fatalf("TelegramBotAPIMock.EditCaption mock is already set by Set")
 	}
 
-	if mmEditMessage.defaultExpectation == nil {
-		mmEditMessage.defaultExpectation = &TelegramBotAPIMockEditMessageExpectation{}
+	if mmEditCaption.defaultExpectation == nil {
+		mmEditCaption.defaultExpectation = &TelegramBotAPIMockEditCaptionExpectation{}
 	}
 
-	mmEditMessage.defaultExpectation.params = &TelegramBotAPIMockEditMessageParams{chatID, messageID, text}
-	for _, e := range mmEditMessage.expectations {
-		if minimock.Equal(e.params, mmEditMessage.defaultExpectation.params) {
-			mmEditMessage.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEditMessage.defaultExpectation.params)
+	mmEditCaption.defaultExpectation.params = &TelegramBotAPIMockEditCaptionParams{chatID, messageID, caption, parseMode}
+	for _, e := range mmEditCaption.expectations {
+		if minimock.Equal(e.params, mmEditCaption.defaultExpectation.params) {
+			mmEditCaption.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEditCaption.defaultExpectation.params)
 		}
 	}
 
-	return mmEditMessage
+	return mmEditCaption
 }
 
-// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.EditMessage
-func (mmEditMessage *mTelegramBotAPIMockEditMessage) Inspect(f func(chatID int64, messageID int, text string)) *mTelegramBotAPIMockEditMessage {
-	if mmEditMessage.mock.inspectFuncEditMessage != nil {
-		mmEditMessage.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.EditMessage")
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.EditCaption
+func (mmEditCaption *mTelegramBotAPIMockEditCaption) Inspect(f func(chatID int64, messageID int, caption string, parseMode string)) *mTelegramBotAPIMockEditCaption {
+	if mmEditCaption.mock.inspectFuncEditCaption != nil {
+		mmEditCaption.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.EditCaption")
 	}
 
-	mmEditMessage.mock.inspectFuncEditMessage = f
+	mmEditCaption.mock.inspectFuncEditCaption = f
 
-	return mmEditMessage
+	return mmEditCaption
 }
 
-// Return sets up results that will be returned by telegramBotAPI.EditMessage
-func (mmEditMessage *mTelegramBotAPIMockEditMessage) Return(err error) *TelegramBotAPIMock {
-	if mmEditMessage.mock.funcEditMessage != nil {
-		mmEditMessage.mock.t.Fatalf("TelegramBotAPIMock.EditMessage mock is already set by Set")
+// Return sets up results that will be returned by telegramBotAPI.EditCaption
+func (mmEditCaption *mTelegramBotAPIMockEditCaption) Return(err error) *TelegramBotAPIMock {
+	if mmEditCaption.mock.funcEditCaption != nil {
+		mmEditCaption.mock.t.Fatalf("TelegramBotAPIMock.EditCaption mock is already set by Set")
 	}
 
-	if mmEditMessage.defaultExpectation == nil {
-		mmEditMessage.defaultExpectation = &TelegramBotAPIMockEditMessageExpectation{mock: mmEditMessage.mock}
+	if mmEditCaption.defaultExpectation == nil {
+		mmEditCaption.defaultExpectation = &TelegramBotAPIMockEditCaptionExpectation{mock: mmEditCaption.mock}
 	}
-	mmEditMessage.defaultExpectation.results = &TelegramBotAPIMockEditMessageResults{err}
-	return mmEditMessage.mock
+	mmEditCaption.defaultExpectation.results = &TelegramBotAPIMockEditCaptionResults{err}
+	return mmEditCaption.mock
 }
 
-//Set uses given function f to mock the telegramBotAPI.EditMessage method
-func (mmEditMessage *mTelegramBotAPIMockEditMessage) Set(f func(chatID int64, messageID int, text string) (err error)) *TelegramBotAPIMock {
-	if mmEditMessage.defaultExpectation != nil {
-		mmEditMessage.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.EditMessage method")
+// Set uses given function f to mock the telegramBotAPI.EditCaption method
+func (mmEditCaption *mTelegramBotAPIMockEditCaption) Set(f func(chatID int64, messageID int, caption string, parseMode string) (err error)) *TelegramBotAPIMock {
+	if mmEditCaption.defaultExpectation != nil {
+		mmEditCaption.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.EditCaption method")
 	}
 
-	if len(mmEditMessage.expectations) > 0 {
-		mmEditMessage.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.EditMessage method")
+	if len(mmEditCaption.expectations) > 0 {
+		mmEditCaption.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.EditCaption method")
 	}
 
-	mmEditMessage.mock.funcEditMessage = f
-	return mmEditMessage.mock
+	mmEditCaption.mock.funcEditCaption = f
+	return mmEditCaption.mock
 }
 
-// When sets expectation for the telegramBotAPI.EditMessage which will trigger the result defined by the following
+// When sets expectation for the telegramBotAPI.EditCaption which will trigger the result defined by the following
 // Then helper
-func (mmEditMessage *mTelegramBotAPIMockEditMessage) When(chatID int64, messageID int, text string) *TelegramBotAPIMockEditMessageExpectation {
-	if mmEditMessage.mock.funcEditMessage != nil {
-		mmEditMessage.mock.t.Fatalf("TelegramBotAPIMock.EditMessage mock is already set by Set")
+func (mmEditCaption *mTelegramBotAPIMockEditCaption) When(chatID int64, messageID int, caption string, parseMode string) *TelegramBotAPIMockEditCaptionExpectation {
+	if mmEditCaption.mock.funcEditCaption != nil {
+		mmEditCaption.mock.t.Fatalf("TelegramBotAPIMock.EditCaption mock is already set by Set")
 	}
 
-	expectation := &TelegramBotAPIMockEditMessageExpectation{
-		mock:   mmEditMessage.mock,
-		params: &TelegramBotAPIMockEditMessageParams{chatID, messageID, text},
+	expectation := &TelegramBotAPIMockEditCaptionExpectation{
+		mock:   mmEditCaption.mock,
+		params: &TelegramBotAPIMockEditCaptionParams{chatID, messageID, caption, parseMode},
 	}
-	mmEditMessage.expectations = append(mmEditMessage.expectations, expectation)
+	mmEditCaption.expectations = append(mmEditCaption.expectations, expectation)
 	return expectation
 }
 
-// Then sets up telegramBotAPI.EditMessage return parameters for the expectation previously defined by the When method
-func (e *TelegramBotAPIMockEditMessageExpectation) Then(err error) *TelegramBotAPIMock {
-	e.results = &TelegramBotAPIMockEditMessageResults{err}
+// Then sets up telegramBotAPI.EditCaption return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockEditCaptionExpectation) Then(err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockEditCaptionResults{err}
 	return e.mock
 }
 
-// EditMessage implements telegramBotAPI
-func (mmEditMessage *TelegramBotAPIMock) EditMessage(chatID int64, messageID int, text string) (err error) {
-	mm_atomic.AddUint64(&mmEditMessage.beforeEditMessageCounter, 1)
-	defer mm_atomic.AddUint64(&mmEditMessage.afterEditMessageCounter, 1)
+// EditCaption implements telegramBotAPI
+func (mmEditCaption *TelegramBotAPIMock) EditCaption(chatID int64, messageID int, caption string, parseMode string) (err error) {
+	mm_atomic.AddUint64(&mmEditCaption.beforeEditCaptionCounter, 1)
+	defer mm_atomic.AddUint64(&mmEditCaption.afterEditCaptionCounter, 1)
 
-	if mmEditMessage.inspectFuncEditMessage != nil {
-		mmEditMessage.inspectFuncEditMessage(chatID, messageID, text)
+	if mmEditCaption.inspectFuncEditCaption != nil {
+		mmEditCaption.inspectFuncEditCaption(chatID, messageID, caption, parseMode)
 	}
 
-	mm_params := &TelegramBotAPIMockEditMessageParams{chatID, messageID, text}
+	mm_params := &TelegramBotAPIMockEditCaptionParams{chatID, messageID, caption, parseMode}
 
 	// Record call args
-	mmEditMessage.EditMessageMock.mutex.Lock()
-	mmEditMessage.EditMessageMock.callArgs = append(mmEditMessage.EditMessageMock.callArgs, mm_params)
-	mmEditMessage.EditMessageMock.mutex.Unlock()
+	mmEditCaption.EditCaptionMock.mutex.Lock()
+	mmEditCaption.EditCaptionMock.callArgs = append(mmEditCaption.EditCaptionMock.callArgs, mm_params)
+	mmEditCaption.EditCaptionMock.mutex.Unlock()
 
-	for _, e := range mmEditMessage.EditMessageMock.expectations {
+	for _, e := range mmEditCaption.EditCaptionMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
 			return e.results.err
 		}
 	}
 
-	if mmEditMessage.EditMessageMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmEditMessage.EditMessageMock.defaultExpectation.Counter, 1)
-		mm_want := mmEditMessage.EditMessageMock.defaultExpectation.params
-		mm_got := TelegramBotAPIMockEditMessageParams{chatID, messageID, text}
+	if mmEditCaption.EditCaptionMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmEditCaption.EditCaptionMock.defaultExpectation.Counter, 1)
+		mm_want := mmEditCaption.EditCaptionMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockEditCaptionParams{chatID, messageID, caption, parseMode}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmEditMessage.t.Errorf("TelegramBotAPIMock.EditMessage got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmEditCaption.t.Errorf("TelegramBotAPIMock.EditCaption got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmEditMessage.EditMessageMock.defaultExpectation.results
+		mm_results := mmEditCaption.EditCaptionMock.defaultExpectation.results
 		if mm_results == nil {
-			mmEditMessage.t.Fatal("No results are set for the TelegramBotAPIMock.EditMessage")
+			mmEditCaption.t.Fatal("No results are set for the TelegramBotAPIMock.EditCaption")
 		}
 		return (*mm_results).err
 	}
-	if mmEditMessage.funcEditMessage != nil {
-		return mmEditMessage.funcEditMessage(chatID, messageID, text)
+	if mmEditCaption.funcEditCaption != nil {
+		return mmEditCaption.funcEditCaption(chatID, messageID, caption, parseMode)
 	}
-	mmEditMessage.t.Fatalf("Unexpected call to TelegramBotAPIMock.EditMessage. %v %v %v", chatID, messageID, text)
+	mmEditCaption.t.Fatalf("Unexpected call to TelegramBotAPIMock.EditCaption. %v %v %v %v", chatID, messageID, caption, parseMode)
 	return
 }
 
-// EditMessageAfterCounter returns a count of finished TelegramBotAPIMock.EditMessage invocations
-func (mmEditMessage *TelegramBotAPIMock) EditMessageAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmEditMessage.afterEditMessageCounter)
+// EditCaptionAfterCounter returns a count of finished TelegramBotAPIMock.EditCaption invocations
+func (mmEditCaption *TelegramBotAPIMock) EditCaptionAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEditCaption.afterEditCaptionCounter)
 }
 
-// EditMessageBeforeCounter returns a count of TelegramBotAPIMock.EditMessage invocations
-func (mmEditMessage *TelegramBotAPIMock) EditMessageBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmEditMessage.beforeEditMessageCounter)
+// EditCaptionBeforeCounter returns a count of TelegramBotAPIMock.EditCaption invocations
+func (mmEditCaption *TelegramBotAPIMock) EditCaptionBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEditCaption.beforeEditCaptionCounter)
 }
 
-// Calls returns a list of arguments used in each call to TelegramBotAPIMock.EditMessage.
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.EditCaption.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmEditMessage *mTelegramBotAPIMockEditMessage) Calls() []*TelegramBotAPIMockEditMessageParams {
-	mmEditMessage.mutex.RLock()
+func (mmEditCaption *mTelegramBotAPIMockEditCaption) Calls() []*TelegramBotAPIMockEditCaptionParams {
+	mmEditCaption.mutex.RLock()
 
-	argCopy := make([]*TelegramBotAPIMockEditMessageParams, len(mmEditMessage.callArgs))
-	copy(argCopy, mmEditMessage.callArgs)
+	argCopy := make([]*TelegramBotAPIMockEditCaptionParams, len(mmEditCaption.callArgs))
+	copy(argCopy, mmEditCaption.callArgs)
 
-	mmEditMessage.mutex.RUnlock()
+	mmEditCaption.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockEditMessageDone returns true if the count of the EditMessage invocations corresponds
+// MinimockEditCaptionDone returns true if the count of the EditCaption invocations corresponds
 // the number of defined expectations
-func (m *TelegramBotAPIMock) MinimockEditMessageDone() bool {
-	for _, e := range m.EditMessageMock.expectations {
+func (m *TelegramBotAPIMock) MinimockEditCaptionDone() bool {
+	for _, e := range m.EditCaptionMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.EditMessageMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
+	if m.EditCaptionMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEditCaptionCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcEditMessage != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
+	if m.funcEditCaption != nil && mm_atomic.LoadUint64(&m.afterEditCaptionCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockEditMessageInspect logs each unmet expectation
-func (m *TelegramBotAPIMock) MinimockEditMessageInspect() {
-	for _, e := range m.EditMessageMock.expectations {
+// MinimockEditCaptionInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockEditCaptionInspect() {
+	for _, e := range m.EditCaptionMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to TelegramBotAPIMock.EditMessage with params: %#v", *e.params)
+			m.t.Errorf("Expected call to TelegramBotAPIMock.EditCaption with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.EditMessageMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
-		if m.EditMessageMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to TelegramBotAPIMock.EditMessage")
+	if m.EditCaptionMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEditCaptionCounter) < 1 {
+		if m.EditCaptionMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.EditCaption")
 		} else {
-			m.t.Errorf("Expected call to TelegramBotAPIMock.EditMessage with params: %#v", *m.EditMessageMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to TelegramBotAPIMock.EditCaption with params: %#v", *m.EditCaptionMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcEditMessage != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
-		m.t.Error("Expected call to TelegramBotAPIMock.EditMessage")
+	if m.funcEditCaption != nil && mm_atomic.LoadUint64(&m.afterEditCaptionCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.EditCaption")
 	}
 }
 
-type mTelegramBotAPIMockGetChatPinnedMessageID struct {
+type mTelegramBotAPIMockEditMessage struct {
 	mock               *TelegramBotAPIMock
-	defaultExpectation *TelegramBotAPIMockGetChatPinnedMessageIDExpectation
-	expectations       []*TelegramBotAPIMockGetChatPinnedMessageIDExpectation
+	defaultExpectation *TelegramBotAPIMockEditMessageExpectation
+	expectations       []*TelegramBotAPIMockEditMessageExpectation
 
-	callArgs []*TelegramBotAPIMockGetChatPinnedMessageIDParams
+	callArgs []*TelegramBotAPIMockEditMessageParams
 	mutex    sync.RWMutex
 }
 
-// TelegramBotAPIMockGetChatPinnedMessageIDExpectation specifies expectation struct of the telegramBotAPI.GetChatPinnedMessageID
-type TelegramBotAPIMockGetChatPinnedMessageIDExpectation struct {
+// TelegramBotAPIMockEditMessageExpectation specifies expectation struct of the telegramBotAPI.EditMessage
+type TelegramBotAPIMockEditMessageExpectation struct {
 	mock    *TelegramBotAPIMock
-	params  *TelegramBotAPIMockGetChatPinnedMessageIDParams
-	results *TelegramBotAPIMockGetChatPinnedMessageIDResults
+	params  *TelegramBotAPIMockEditMessageParams
+	results *TelegramBotAPIMockEditMessageResults
 	Counter uint64
 }
 
-// TelegramBotAPIMockGetChatPinnedMessageIDParams contains parameters of the telegramBotAPI.GetChatPinnedMessageID
-type TelegramBotAPIMockGetChatPinnedMessageIDParams struct {
-	chatID int64
+// TelegramBotAPIMockEditMessageParams contains parameters of the telegramBotAPI.EditMessage
+type TelegramBotAPIMockEditMessageParams struct {
+	chatID    int64
+	messageID int
+	text      string
+	parseMode string
 }
 
-// TelegramBotAPIMockGetChatPinnedMessageIDResults contains results of the telegramBotAPI.GetChatPinnedMessageID
-type TelegramBotAPIMockGetChatPinnedMessageIDResults struct {
-	i1  int
+// TelegramBotAPIMockEditMessageResults contains results of the telegramBotAPI.EditMessage
+type TelegramBotAPIMockEditMessageResults struct {
 	err error
 }
 
-// Expect sets up expected params for telegramBotAPI.GetChatPinnedMessageID
-func (mmGetChatPinnedMessageID *mTelegramBotAPIMockGetChatPinnedMessageID) Expect(chatID int64) *mTelegramBotAPIMockGetChatPinnedMessageID {
-	if mmGetChatPinnedMessageID.mock.funcGetChatPinnedMessageID != nil {
-		mmGetChatPinnedMessageID.mock.t.Fatalf("TelegramBotAPIMock.GetChatPinnedMessageID mock is already set by Set")
+// Expect sets up expected params for telegramBotAPI.EditMessage
+func (mmEditMessage *mTelegramBotAPIMockEditMessage) Expect(chatID int64, messageID int, text string, parseMode string) *mTelegramBotAPIMockEditMessage {
+	if mmEditMessage.mock.funcEditMessage != nil {
+		mmEditMessage.mock.t.Fatalf("TelegramBotAPIMock.EditMessage mock is already set by Set")
 	}
 
-	if mmGetChatPinnedMessageID.defaultExpectation == nil {
-		mmGetChatPinnedMessageID.defaultExpectation = &TelegramBotAPIMockGetChatPinnedMessageIDExpectation{}
+	if mmEditMessage.defaultExpectation == nil {
+		mmEditMessage.defaultExpectation = &TelegramBotAPIMockEditMessageExpectation{}
 	}
 
-	mmGetChatPinnedMessageID.defaultExpectation.params = &TelegramBotAPIMockGetChatPinnedMessageIDParams{chatID}
-	for _, e := range mmGetChatPinnedMessageID.expectations {
-		if minimock.Equal(e.params, mmGetChatPinnedMessageID.defaultExpectation.params) {
-			mmGetChatPinnedMessageID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetChatPinnedMessageID.defaultExpectation.params)
+	mmEditMessage.defaultExpectation.params = &TelegramBotAPIMockEditMessageParams{chatID, messageID, text, parseMode}
+	for _, e := range mmEditMessage.expectations {
+		if minimock.Equal(e.params, mmEditMessage.defaultExpectation.params) {
+			mmEditMessage.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEditMessage.defaultExpectation.params)
 		}
 	}
 
-	return mmGetChatPinnedMessageID
+	return mmEditMessage
 }
 
-// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.GetChatPinnedMessageID
-func (mmGetChatPinnedMessageID *mTelegramBotAPIMockGetChatPinnedMessageID) Inspect(f func(chatID int64)) *mTelegramBotAPIMockGetChatPinnedMessageID {
-	if mmGetChatPinnedMessageID.mock.inspectFuncGetChatPinnedMessageID != nil {
-		mmGetChatPinnedMessageID.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.GetChatPinnedMessageID")
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.EditMessage
+func (mmEditMessage *mTelegramBotAPIMockEditMessage) Inspect(f func(chatID int64, messageID int, text string, parseMode string)) *mTelegramBotAPIMockEditMessage {
+	if mmEditMessage.mock.inspectFuncEditMessage != nil {
+		mmEditMessage.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.EditMessage")
 	}
 
-	mmGetChatPinnedMessageID.mock.inspectFuncGetChatPinnedMessageID = f
+	mmEditMessage.mock.inspectFuncEditMessage = f
 
-	return mmGetChatPinnedMessageID
+	return mmEditMessage
 }
 
-// Return sets up results that will be returned by telegramBotAPI.GetChatPinnedMessageID
-func (mmGetChatPinnedMessageID *mTelegramBotAPIMockGetChatPinnedMessageID) Return(i1 int, err error) *TelegramBotAPIMock {
-	if mmGetChatPinnedMessageID.mock.funcGetChatPinnedMessageID != nil {
-		mmGetChatPinnedMessageID.mock.t.Fatalf("TelegramBotAPIMock.GetChatPinnedMessageID mock is already set by Set")
+// Return sets up results that will be returned by telegramBotAPI.EditMessage
+func (mmEditMessage *mTelegramBotAPIMockEditMessage) Return(err error) *TelegramBotAPIMock {
+	if mmEditMessage.mock.funcEditMessage != nil {
+		mmEditMessage.mock.t.Fatalf("TelegramBotAPIMock.EditMessage mock is already set by Set")
 	}
 
-	if mmGetChatPinnedMessageID.defaultExpectation == nil {
-		mmGetChatPinnedMessageID.defaultExpectation = &TelegramBotAPIMockGetChatPinnedMessageIDExpectation{mock: mmGetChatPinnedMessageID.mock}
+	if mmEditMessage.defaultExpectation == nil {
+		mmEditMessage.defaultExpectation = &TelegramBotAPIMockEditMessageExpectation{mock: mmEditMessage.mock}
 	}
-	mmGetChatPinnedMessageID.defaultExpectation.results = &TelegramBotAPIMockGetChatPinnedMessageIDResults{i1, err}
-	return mmGetChatPinnedMessageID.mock
+	mmEditMessage.defaultExpectation.results = &TelegramBotAPIMockEditMessageResults{err}
+	return mmEditMessage.mock
 }
 
-//Set uses given function f to mock the telegramBotAPI.GetChatPinnedMessageID method
-func (mmGetChatPinnedMessageID *mTelegramBotAPIMockGetChatPinnedMessageID) Set(f func(chatID int64) (i1 int, err error)) *TelegramBotAPIMock {
-	if mmGetChatPinnedMessageID.defaultExpectation != nil {
-		mmGetChatPinnedMessageID.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.GetChatPinnedMessageID method")
+// Set uses given function f to mock the telegramBotAPI.EditMessage method
+func (mmEditMessage *mTelegramBotAPIMockEditMessage) Set(f func(chatID int64, messageID int, text string, parseMode string) (err error)) *TelegramBotAPIMock {
+	if mmEditMessage.defaultExpectation != nil {
+		mmEditMessage.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.EditMessage method")
 	}
 
-	if len(mmGetChatPinnedMessageID.expectations) > 0 {
-		mmGetChatPinnedMessageID.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.GetChatPinnedMessageID method")
+	if len(mmEditMessage.expectations) > 0 {
+		mmEditMessage.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.EditMessage method")
 	}
 
-	mmGetChatPinnedMessageID.mock.funcGetChatPinnedMessageID = f
-	return mmGetChatPinnedMessageID.mock
+	mmEditMessage.mock.funcEditMessage = f
+	return mmEditMessage.mock
 }
 
-// When sets expectation for the telegramBotAPI.GetChatPinnedMessageID which will trigger the result defined by the following
+// When sets expectation for the telegramBotAPI.EditMessage which will trigger the result defined by the following
 // Then helper
-func (mmGetChatPinnedMessageID *mTelegramBotAPIMockGetChatPinnedMessageID) When(chatID int64) *TelegramBotAPIMockGetChatPinnedMessageIDExpectation {
-	if mmGetChatPinnedMessageID.mock.funcGetChatPinnedMessageID != nil {
-		mmGetChatPinnedMessageID.mock.t.Fatalf("TelegramBotAPIMock.GetChatPinnedMessageID mock is already set by Set")
+func (mmEditMessage *mTelegramBotAPIMockEditMessage) When(chatID int64, messageID int, text string, parseMode string) *TelegramBotAPIMockEditMessageExpectation {
+	if mmEditMessage.mock.funcEditMessage != nil {
+		mmEditMessage.mock.t.Fatalf("TelegramBotAPIMock.EditMessage mock is already set by Set")
 	}
 
-	expectation := &TelegramBotAPIMockGetChatPinnedMessageIDExpectation{
-		mock:   mmGetChatPinnedMessageID.mock,
-		params: &TelegramBotAPIMockGetChatPinnedMessageIDParams{chatID},
+	expectation := &TelegramBotAPIMockEditMessageExpectation{
+		mock:   mmEditMessage.mock,
+		params: &TelegramBotAPIMockEditMessageParams{chatID, messageID, text, parseMode},
 	}
-	mmGetChatPinnedMessageID.expectations = append(mmGetChatPinnedMessageID.expectations, expectation)
+	mmEditMessage.expectations = append(mmEditMessage.expectations, expectation)
 	return expectation
 }
 
-// Then sets up telegramBotAPI.GetChatPinnedMessageID return parameters for the expectation previously defined by the When method
-func (e *TelegramBotAPIMockGetChatPinnedMessageIDExpectation) Then(i1 int, err error) *TelegramBotAPIMock {
-	e.results = &TelegramBotAPIMockGetChatPinnedMessageIDResults{i1, err}
+// Then sets up telegramBotAPI.EditMessage return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockEditMessageExpectation) Then(err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockEditMessageResults{err}
 	return e.mock
 }
 
-// GetChatPinnedMessageID implements telegramBotAPI
-func (mmGetChatPinnedMessageID *TelegramBotAPIMock) GetChatPinnedMessageID(chatID int64) (i1 int, err error) {
-	mm_atomic.AddUint64(&mmGetChatPinnedMessageID.beforeGetChatPinnedMessageIDCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetChatPinnedMessageID.afterGetChatPinnedMessageIDCounter, 1)
+// EditMessage implements telegramBotAPI
+func (mmEditMessage *TelegramBotAPIMock) EditMessage(chatID int64, messageID int, text string, parseMode string) (err error) {
+	mm_atomic.AddUint64(&mmEditMessage.beforeEditMessageCounter, 1)
+	defer mm_atomic.AddUint64(&mmEditMessage.afterEditMessageCounter, 1)
 
-	if mmGetChatPinnedMessageID.inspectFuncGetChatPinnedMessageID != nil {
-		mmGetChatPinnedMessageID.inspectFuncGetChatPinnedMessageID(chatID)
+	if mmEditMessage.inspectFuncEditMessage != nil {
+		mmEditMessage.inspectFuncEditMessage(chatID, messageID, text, parseMode)
 	}
 
-	mm_params := &TelegramBotAPIMockGetChatPinnedMessageIDParams{chatID}
+	mm_params := &TelegramBotAPIMockEditMessageParams{chatID, messageID, text, parseMode}
 
 	// Record call args
-	mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.mutex.Lock()
-	mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.callArgs = append(mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.callArgs, mm_params)
-	mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.mutex.Unlock()
+	mmEditMessage.EditMessageMock.mutex.Lock()
+	mmEditMessage.EditMessageMock.callArgs = append(mmEditMessage.EditMessageMock.callArgs, mm_params)
+	mmEditMessage.EditMessageMock.mutex.Unlock()
 
-	for _, e := range mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.expectations {
+	for _, e := range mmEditMessage.EditMessageMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.i1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.defaultExpectation.params
-		mm_got := TelegramBotAPIMockGetChatPinnedMessageIDParams{chatID}
+	if mmEditMessage.EditMessageMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmEditMessage.EditMessageMock.defaultExpectation.Counter, 1)
+		mm_want := mmEditMessage.EditMessageMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockEditMessageParams{chatID, messageID, text, parseMode}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetChatPinnedMessageID.t.Errorf("TelegramBotAPIMock.GetChatPinnedMessageID got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmEditMessage.t.Errorf("TelegramBotAPIMock.EditMessage got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetChatPinnedMessageID.GetChatPinnedMessageIDMock.defaultExpectation.results
+		mm_results := mmEditMessage.EditMessageMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetChatPinnedMessageID.t.Fatal("No results are set for the TelegramBotAPIMock.GetChatPinnedMessageID")
+			mmEditMessage.t.Fatal("No results are set for the TelegramBotAPIMock.EditMessage")
 		}
-		return (*mm_results).i1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmGetChatPinnedMessageID.funcGetChatPinnedMessageID != nil {
-		return mmGetChatPinnedMessageID.funcGetChatPinnedMessageID(chatID)
+	if mmEditMessage.funcEditMessage != nil {
+		return mmEditMessage.funcEditMessage(chatID, messageID, text, parseMode)
 	}
-	mmGetChatPinnedMessageID.t.Fatalf("Unexpected call to TelegramBotAPIMock.GetChatPinnedMessageID. %v", chatID)
+	mmEditMessage.t.Fatalf("Unexpected call to TelegramBotAPIMock.EditMessage. %v %v %v %v", chatID, messageID, text, parseMode)
 	return
 }
 
-// GetChatPinnedMessageIDAfterCounter returns a count of finished TelegramBotAPIMock.GetChatPinnedMessageID invocations
-func (mmGetChatPinnedMessageID *TelegramBotAPIMock) GetChatPinnedMessageIDAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetChatPinnedMessageID.afterGetChatPinnedMessageIDCounter)
+// EditMessageAfterCounter returns a count of finished TelegramBotAPIMock.EditMessage invocations
+func (mmEditMessage *TelegramBotAPIMock) EditMessageAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEditMessage.afterEditMessageCounter)
 }
 
-// GetChatPinnedMessageIDBeforeCounter returns a count of TelegramBotAPIMock.GetChatPinnedMessageID invocations
-func (mmGetChatPinnedMessageID *TelegramBotAPIMock) GetChatPinnedMessageIDBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetChatPinnedMessageID.beforeGetChatPinnedMessageIDCounter)
+// EditMessageBeforeCounter returns a count of TelegramBotAPIMock.EditMessage invocations
+func (mmEditMessage *TelegramBotAPIMock) EditMessageBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEditMessage.beforeEditMessageCounter)
 }
 
-// Calls returns a list of arguments used in each call to TelegramBotAPIMock.GetChatPinnedMessageID.
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.EditMessage.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetChatPinnedMessageID *mTelegramBotAPIMockGetChatPinnedMessageID) Calls() []*TelegramBotAPIMockGetChatPinnedMessageIDParams {
-	mmGetChatPinnedMessageID.mutex.RLock()
+func (mmEditMessage *mTelegramBotAPIMockEditMessage) Calls() []*TelegramBotAPIMockEditMessageParams {
+	mmEditMessage.mutex.RLock()
 
-	argCopy := make([]*TelegramBotAPIMockGetChatPinnedMessageIDParams, len(mmGetChatPinnedMessageID.callArgs))
-	copy(argCopy, mmGetChatPinnedMessageID.callArgs)
+	argCopy := make([]*TelegramBotAPIMockEditMessageParams, len(mmEditMessage.callArgs))
+	copy(argCopy, mmEditMessage.callArgs)
 
-	mmGetChatPinnedMessageID.mutex.RUnlock()
+	mmEditMessage.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetChatPinnedMessageIDDone returns true if the count of the GetChatPinnedMessageID invocations corresponds
+// MinimockEditMessageDone returns true if the count of the EditMessage invocations corresponds
 // the number of defined expectations
-func (m *TelegramBotAPIMock) MinimockGetChatPinnedMessageIDDone() bool {
-	for _, e := range m.GetChatPinnedMessageIDMock.expectations {
+func (m *TelegramBotAPIMock) MinimockEditMessageDone() bool {
+	for _, e := range m.EditMessageMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetChatPinnedMessageIDMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetChatPinnedMessageIDCounter) < 1 {
+	if m.EditMessageMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetChatPinnedMessageID != nil && mm_atomic.LoadUint64(&m.afterGetChatPinnedMessageIDCounter) < 1 {
+	if m.funcEditMessage != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockGetChatPinnedMessageIDInspect logs each unmet expectation
-func (m *TelegramBotAPIMock) MinimockGetChatPinnedMessageIDInspect() {
-	for _, e := range m.GetChatPinnedMessageIDMock.expectations {
+// MinimockEditMessageInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockEditMessageInspect() {
+	for _, e := range m.EditMessageMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to TelegramBotAPIMock.GetChatPinnedMessageID with params: %#v", *e.params)
+			m.t.Errorf("Expected call to TelegramBotAPIMock.EditMessage with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetChatPinnedMessageIDMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetChatPinnedMessageIDCounter) < 1 {
-		if m.GetChatPinnedMessageIDMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to TelegramBotAPIMock.GetChatPinnedMessageID")
+	if m.EditMessageMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
+		if m.EditMessageMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.EditMessage")
 		} else {
-			m.t.Errorf("Expected call to TelegramBotAPIMock.GetChatPinnedMessageID with params: %#v", *m.GetChatPinnedMessageIDMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to TelegramBotAPIMock.EditMessage with params: %#v", *m.EditMessageMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetChatPinnedMessageID != nil && mm_atomic.LoadUint64(&m.afterGetChatPinnedMessageIDCounter) < 1 {
-		m.t.Error("Expected call to TelegramBotAPIMock.GetChatPinnedMessageID")
+	if m.funcEditMessage != nil && mm_atomic.LoadUint64(&m.afterEditMessageCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.EditMessage")
 	}
 }
 
@@ -518,16 +1214,24 @@ type mTelegramBotAPIMockGetUpdates struct {
 	mock               *TelegramBotAPIMock
 	defaultExpectation *TelegramBotAPIMockGetUpdatesExpectation
 	expectations       []*TelegramBotAPIMockGetUpdatesExpectation
+
+	callArgs []*TelegramBotAPIMockGetUpdatesParams
+	mutex    sync.RWMutex
 }
 
 // TelegramBotAPIMockGetUpdatesExpectation specifies expectation struct of the telegramBotAPI.GetUpdates
 type TelegramBotAPIMockGetUpdatesExpectation struct {
-	mock *TelegramBotAPIMock
-
+	mock    *TelegramBotAPIMock
+	params  *TelegramBotAPIMockGetUpdatesParams
 	results *TelegramBotAPIMockGetUpdatesResults
 	Counter uint64
 }
 
+// TelegramBotAPIMockGetUpdatesParams contains parameters of the telegramBotAPI.GetUpdates
+type TelegramBotAPIMockGetUpdatesParams struct {
+	offset int
+}
+
 // TelegramBotAPIMockGetUpdatesResults contains results of the telegramBotAPI.GetUpdates
 type TelegramBotAPIMockGetUpdatesResults struct {
 	ua1 []tgbotapi.Update
@@ -535,7 +1239,7 @@ type TelegramBotAPIMockGetUpdatesResults struct {
 }
 
 // Expect sets up expected params for telegramBotAPI.GetUpdates
-func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Expect() *mTelegramBotAPIMockGetUpdates {
+func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Expect(offset int) *mTelegramBotAPIMockGetUpdates {
 	if mmGetUpdates.mock.funcGetUpdates != nil {
 		mmGetUpdates.mock.t.Fatalf("TelegramBotAPIMock.GetUpdates mock is already set by Set")
 	}
@@ -544,11 +1248,18 @@ func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Expect() *mTelegramBotAPIMock
 		mmGetUpdates.defaultExpectation = &TelegramBotAPIMockGetUpdatesExpectation{}
 	}
 
+	mmGetUpdates.defaultExpectation.params = &TelegramBotAPIMockGetUpdatesParams{offset}
+	for _, e := range mmGetUpdates.expectations {
+		if minimock.Equal(e.params, mmGetUpdates.defaultExpectation.params) {
+			mmGetUpdates.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetUpdates.defaultExpectation.params)
+		}
+	}
+
 	return mmGetUpdates
 }
 
 // Inspect accepts an inspector function that has same arguments as the telegramBotAPI.GetUpdates
-func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Inspect(f func()) *mTelegramBotAPIMockGetUpdates {
+func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Inspect(f func(offset int)) *mTelegramBotAPIMockGetUpdates {
 	if mmGetUpdates.mock.inspectFuncGetUpdates != nil {
 		mmGetUpdates.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.GetUpdates")
 	}
@@ -571,8 +1282,8 @@ func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Return(ua1 []tgbotapi.Update,
 	return mmGetUpdates.mock
 }
 
-//Set uses given function f to mock the telegramBotAPI.GetUpdates method
-func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Set(f func() (ua1 []tgbotapi.Update, err error)) *TelegramBotAPIMock {
+// Set uses given function f to mock the telegramBotAPI.GetUpdates method
+func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Set(f func(offset int) (ua1 []tgbotapi.Update, err error)) *TelegramBotAPIMock {
 	if mmGetUpdates.defaultExpectation != nil {
 		mmGetUpdates.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.GetUpdates method")
 	}
@@ -585,17 +1296,57 @@ func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Set(f func() (ua1 []tgbotapi.
 	return mmGetUpdates.mock
 }
 
+// When sets expectation for the telegramBotAPI.GetUpdates which will trigger the result defined by the following
+// Then helper
+func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) When(offset int) *TelegramBotAPIMockGetUpdatesExpectation {
+	if mmGetUpdates.mock.funcGetUpdates != nil {
+		mmGetUpdates.mock.t.Fatalf("TelegramBotAPIMock.GetUpdates mock is already set by Set")
+	}
+
+	expectation := &TelegramBotAPIMockGetUpdatesExpectation{
+		mock:   mmGetUpdates.mock,
+		params: &TelegramBotAPIMockGetUpdatesParams{offset},
+	}
+	mmGetUpdates.expectations = append(mmGetUpdates.expectations, expectation)
+	return expectation
+}
+
+// Then sets up telegramBotAPI.GetUpdates return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockGetUpdatesExpectation) Then(ua1 []tgbotapi.Update, err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockGetUpdatesResults{ua1, err}
+	return e.mock
+}
+
 // GetUpdates implements telegramBotAPI
-func (mmGetUpdates *TelegramBotAPIMock) GetUpdates() (ua1 []tgbotapi.Update, err error) {
+func (mmGetUpdates *TelegramBotAPIMock) GetUpdates(offset int) (ua1 []tgbotapi.Update, err error) {
 	mm_atomic.AddUint64(&mmGetUpdates.beforeGetUpdatesCounter, 1)
 	defer mm_atomic.AddUint64(&mmGetUpdates.afterGetUpdatesCounter, 1)
 
-	if mmGetUpdates.inspectFuncGetUpdates != nil {
-		mmGetUpdates.inspectFuncGetUpdates()
+	if mmGetUpdates.inspectFuncGetUpdates != nil {
+		mmGetUpdates.inspectFuncGetUpdates(offset)
+	}
+
+	mm_params := &TelegramBotAPIMockGetUpdatesParams{offset}
+
+	// Record call args
+	mmGetUpdates.GetUpdatesMock.mutex.Lock()
+	mmGetUpdates.GetUpdatesMock.callArgs = append(mmGetUpdates.GetUpdatesMock.callArgs, mm_params)
+	mmGetUpdates.GetUpdatesMock.mutex.Unlock()
+
+	for _, e := range mmGetUpdates.GetUpdatesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ua1, e.results.err
+		}
 	}
 
 	if mmGetUpdates.GetUpdatesMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmGetUpdates.GetUpdatesMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetUpdates.GetUpdatesMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockGetUpdatesParams{offset}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetUpdates.t.Errorf("TelegramBotAPIMock.GetUpdates got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
 
 		mm_results := mmGetUpdates.GetUpdatesMock.defaultExpectation.results
 		if mm_results == nil {
@@ -604,9 +1355,9 @@ func (mmGetUpdates *TelegramBotAPIMock) GetUpdates() (ua1 []tgbotapi.Update, err
 		return (*mm_results).ua1, (*mm_results).err
 	}
 	if mmGetUpdates.funcGetUpdates != nil {
-		return mmGetUpdates.funcGetUpdates()
+		return mmGetUpdates.funcGetUpdates(offset)
 	}
-	mmGetUpdates.t.Fatalf("Unexpected call to TelegramBotAPIMock.GetUpdates.")
+	mmGetUpdates.t.Fatalf("Unexpected call to TelegramBotAPIMock.GetUpdates. %v", offset)
 	return
 }
 
@@ -620,6 +1371,19 @@ func (mmGetUpdates *TelegramBotAPIMock) GetUpdatesBeforeCounter() uint64 {
 	return mm_atomic.LoadUint64(&mmGetUpdates.beforeGetUpdatesCounter)
 }
 
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.GetUpdates.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetUpdates *mTelegramBotAPIMockGetUpdates) Calls() []*TelegramBotAPIMockGetUpdatesParams {
+	mmGetUpdates.mutex.RLock()
+
+	argCopy := make([]*TelegramBotAPIMockGetUpdatesParams, len(mmGetUpdates.callArgs))
+	copy(argCopy, mmGetUpdates.callArgs)
+
+	mmGetUpdates.mutex.RUnlock()
+
+	return argCopy
+}
+
 // MinimockGetUpdatesDone returns true if the count of the GetUpdates invocations corresponds
 // the number of defined expectations
 func (m *TelegramBotAPIMock) MinimockGetUpdatesDone() bool {
@@ -644,13 +1408,17 @@ func (m *TelegramBotAPIMock) MinimockGetUpdatesDone() bool {
 func (m *TelegramBotAPIMock) MinimockGetUpdatesInspect() {
 	for _, e := range m.GetUpdatesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Error("Expected call to TelegramBotAPIMock.GetUpdates")
+			m.t.Errorf("Expected call to TelegramBotAPIMock.GetUpdates with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
 	if m.GetUpdatesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetUpdatesCounter) < 1 {
-		m.t.Error("Expected call to TelegramBotAPIMock.GetUpdates")
+		if m.GetUpdatesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.GetUpdates")
+		} else {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.GetUpdates with params: %#v", *m.GetUpdatesMock.defaultExpectation.params)
+		}
 	}
 	// if func was set then invocations count should be greater than zero
 	if m.funcGetUpdates != nil && mm_atomic.LoadUint64(&m.afterGetUpdatesCounter) < 1 {
@@ -730,7 +1498,7 @@ func (mmPinMessage *mTelegramBotAPIMockPinMessage) Return(err error) *TelegramBo
 	return mmPinMessage.mock
 }
 
-//Set uses given function f to mock the telegramBotAPI.PinMessage method
+// Set uses given function f to mock the telegramBotAPI.PinMessage method
 func (mmPinMessage *mTelegramBotAPIMockPinMessage) Set(f func(chatID int64, messageID int) (err error)) *TelegramBotAPIMock {
 	if mmPinMessage.defaultExpectation != nil {
 		mmPinMessage.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.PinMessage method")
@@ -893,9 +1661,10 @@ type TelegramBotAPIMockSendAnimationExpectation struct {
 
 // TelegramBotAPIMockSendAnimationParams contains parameters of the telegramBotAPI.SendAnimation
 type TelegramBotAPIMockSendAnimationParams struct {
-	chatID  int64
-	fileID  string
-	caption string
+	chatID    int64
+	fileID    string
+	caption   string
+	parseMode string
 }
 
 // TelegramBotAPIMockSendAnimationResults contains results of the telegramBotAPI.SendAnimation
@@ -905,7 +1674,7 @@ type TelegramBotAPIMockSendAnimationResults struct {
 }
 
 // Expect sets up expected params for telegramBotAPI.SendAnimation
-func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Expect(chatID int64, fileID string, caption string) *mTelegramBotAPIMockSendAnimation {
+func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Expect(chatID int64, fileID string, caption string, parseMode string) *mTelegramBotAPIMockSendAnimation {
 	if mmSendAnimation.mock.funcSendAnimation != nil {
 		mmSendAnimation.mock.t.Fatalf("TelegramBotAPIMock.SendAnimation mock is already set by Set")
 	}
@@ -914,7 +1683,7 @@ func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Expect(chatID int64, fi
 		mmSendAnimation.defaultExpectation = &TelegramBotAPIMockSendAnimationExpectation{}
 	}
 
-	mmSendAnimation.defaultExpectation.params = &TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption}
+	mmSendAnimation.defaultExpectation.params = &TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption, parseMode}
 	for _, e := range mmSendAnimation.expectations {
 		if minimock.Equal(e.params, mmSendAnimation.defaultExpectation.params) {
 			mmSendAnimation.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSendAnimation.defaultExpectation.params)
@@ -925,7 +1694,7 @@ func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Expect(chatID int64, fi
 }
 
 // Inspect accepts an inspector function that has same arguments as the telegramBotAPI.SendAnimation
-func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Inspect(f func(chatID int64, fileID string, caption string)) *mTelegramBotAPIMockSendAnimation {
+func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Inspect(f func(chatID int64, fileID string, caption string, parseMode string)) *mTelegramBotAPIMockSendAnimation {
 	if mmSendAnimation.mock.inspectFuncSendAnimation != nil {
 		mmSendAnimation.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.SendAnimation")
 	}
@@ -948,8 +1717,8 @@ func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Return(i1 int, err erro
 	return mmSendAnimation.mock
 }
 
-//Set uses given function f to mock the telegramBotAPI.SendAnimation method
-func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Set(f func(chatID int64, fileID string, caption string) (i1 int, err error)) *TelegramBotAPIMock {
+// Set uses given function f to mock the telegramBotAPI.SendAnimation method
+func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Set(f func(chatID int64, fileID string, caption string, parseMode string) (i1 int, err error)) *TelegramBotAPIMock {
 	if mmSendAnimation.defaultExpectation != nil {
 		mmSendAnimation.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.SendAnimation method")
 	}
@@ -964,14 +1733,14 @@ func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) Set(f func(chatID int64
 
 // When sets expectation for the telegramBotAPI.SendAnimation which will trigger the result defined by the following
 // Then helper
-func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) When(chatID int64, fileID string, caption string) *TelegramBotAPIMockSendAnimationExpectation {
+func (mmSendAnimation *mTelegramBotAPIMockSendAnimation) When(chatID int64, fileID string, caption string, parseMode string) *TelegramBotAPIMockSendAnimationExpectation {
 	if mmSendAnimation.mock.funcSendAnimation != nil {
 		mmSendAnimation.mock.t.Fatalf("TelegramBotAPIMock.SendAnimation mock is already set by Set")
 	}
 
 	expectation := &TelegramBotAPIMockSendAnimationExpectation{
 		mock:   mmSendAnimation.mock,
-		params: &TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption},
+		params: &TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption, parseMode},
 	}
 	mmSendAnimation.expectations = append(mmSendAnimation.expectations, expectation)
 	return expectation
@@ -984,15 +1753,15 @@ func (e *TelegramBotAPIMockSendAnimationExpectation) Then(i1 int, err error) *Te
 }
 
 // SendAnimation implements telegramBotAPI
-func (mmSendAnimation *TelegramBotAPIMock) SendAnimation(chatID int64, fileID string, caption string) (i1 int, err error) {
+func (mmSendAnimation *TelegramBotAPIMock) SendAnimation(chatID int64, fileID string, caption string, parseMode string) (i1 int, err error) {
 	mm_atomic.AddUint64(&mmSendAnimation.beforeSendAnimationCounter, 1)
 	defer mm_atomic.AddUint64(&mmSendAnimation.afterSendAnimationCounter, 1)
 
 	if mmSendAnimation.inspectFuncSendAnimation != nil {
-		mmSendAnimation.inspectFuncSendAnimation(chatID, fileID, caption)
+		mmSendAnimation.inspectFuncSendAnimation(chatID, fileID, caption, parseMode)
 	}
 
-	mm_params := &TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption}
+	mm_params := &TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption, parseMode}
 
 	// Record call args
 	mmSendAnimation.SendAnimationMock.mutex.Lock()
@@ -1009,7 +1778,7 @@ func (mmSendAnimation *TelegramBotAPIMock) SendAnimation(chatID int64, fileID st
 	if mmSendAnimation.SendAnimationMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmSendAnimation.SendAnimationMock.defaultExpectation.Counter, 1)
 		mm_want := mmSendAnimation.SendAnimationMock.defaultExpectation.params
-		mm_got := TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption}
+		mm_got := TelegramBotAPIMockSendAnimationParams{chatID, fileID, caption, parseMode}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmSendAnimation.t.Errorf("TelegramBotAPIMock.SendAnimation got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -1021,9 +1790,9 @@ func (mmSendAnimation *TelegramBotAPIMock) SendAnimation(chatID int64, fileID st
 		return (*mm_results).i1, (*mm_results).err
 	}
 	if mmSendAnimation.funcSendAnimation != nil {
-		return mmSendAnimation.funcSendAnimation(chatID, fileID, caption)
+		return mmSendAnimation.funcSendAnimation(chatID, fileID, caption, parseMode)
 	}
-	mmSendAnimation.t.Fatalf("Unexpected call to TelegramBotAPIMock.SendAnimation. %v %v %v", chatID, fileID, caption)
+	mmSendAnimation.t.Fatalf("Unexpected call to TelegramBotAPIMock.SendAnimation. %v %v %v %v", chatID, fileID, caption, parseMode)
 	return
 }
 
@@ -1111,8 +1880,9 @@ type TelegramBotAPIMockSendMessageExpectation struct {
 
 // TelegramBotAPIMockSendMessageParams contains parameters of the telegramBotAPI.SendMessage
 type TelegramBotAPIMockSendMessageParams struct {
-	chatID int64
-	text   string
+	chatID    int64
+	text      string
+	parseMode string
 }
 
 // TelegramBotAPIMockSendMessageResults contains results of the telegramBotAPI.SendMessage
@@ -1122,7 +1892,7 @@ type TelegramBotAPIMockSendMessageResults struct {
 }
 
 // Expect sets up expected params for telegramBotAPI.SendMessage
-func (mmSendMessage *mTelegramBotAPIMockSendMessage) Expect(chatID int64, text string) *mTelegramBotAPIMockSendMessage {
+func (mmSendMessage *mTelegramBotAPIMockSendMessage) Expect(chatID int64, text string, parseMode string) *mTelegramBotAPIMockSendMessage {
 	if mmSendMessage.mock.funcSendMessage != nil {
 		mmSendMessage.mock.t.Fatalf("TelegramBotAPIMock.SendMessage mock is already set by Set")
 	}
@@ -1131,7 +1901,7 @@ func (mmSendMessage *mTelegramBotAPIMockSendMessage) Expect(chatID int64, text s
 		mmSendMessage.defaultExpectation = &TelegramBotAPIMockSendMessageExpectation{}
 	}
 
-	mmSendMessage.defaultExpectation.params = &TelegramBotAPIMockSendMessageParams{chatID, text}
+	mmSendMessage.defaultExpectation.params = &TelegramBotAPIMockSendMessageParams{chatID, text, parseMode}
 	for _, e := range mmSendMessage.expectations {
 		if minimock.Equal(e.params, mmSendMessage.defaultExpectation.params) {
 			mmSendMessage.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSendMessage.defaultExpectation.params)
@@ -1142,7 +1912,7 @@ func (mmSendMessage *mTelegramBotAPIMockSendMessage) Expect(chatID int64, text s
 }
 
 // Inspect accepts an inspector function that has same arguments as the telegramBotAPI.SendMessage
-func (mmSendMessage *mTelegramBotAPIMockSendMessage) Inspect(f func(chatID int64, text string)) *mTelegramBotAPIMockSendMessage {
+func (mmSendMessage *mTelegramBotAPIMockSendMessage) Inspect(f func(chatID int64, text string, parseMode string)) *mTelegramBotAPIMockSendMessage {
 	if mmSendMessage.mock.inspectFuncSendMessage != nil {
 		mmSendMessage.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.SendMessage")
 	}
@@ -1165,8 +1935,8 @@ func (mmSendMessage *mTelegramBotAPIMockSendMessage) Return(i1 int, err error) *
 	return mmSendMessage.mock
 }
 
-//Set uses given function f to mock the telegramBotAPI.SendMessage method
-func (mmSendMessage *mTelegramBotAPIMockSendMessage) Set(f func(chatID int64, text string) (i1 int, err error)) *TelegramBotAPIMock {
+// Set uses given function f to mock the telegramBotAPI.SendMessage method
+func (mmSendMessage *mTelegramBotAPIMockSendMessage) Set(f func(chatID int64, text string, parseMode string) (i1 int, err error)) *TelegramBotAPIMock {
 	if mmSendMessage.defaultExpectation != nil {
 		mmSendMessage.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.SendMessage method")
 	}
@@ -1181,14 +1951,14 @@ func (mmSendMessage *mTelegramBotAPIMockSendMessage) Set(f func(chatID int64, te
 
 // When sets expectation for the telegramBotAPI.SendMessage which will trigger the result defined by the following
 // Then helper
-func (mmSendMessage *mTelegramBotAPIMockSendMessage) When(chatID int64, text string) *TelegramBotAPIMockSendMessageExpectation {
+func (mmSendMessage *mTelegramBotAPIMockSendMessage) When(chatID int64, text string, parseMode string) *TelegramBotAPIMockSendMessageExpectation {
 	if mmSendMessage.mock.funcSendMessage != nil {
 		mmSendMessage.mock.t.Fatalf("TelegramBotAPIMock.SendMessage mock is already set by Set")
 	}
 
 	expectation := &TelegramBotAPIMockSendMessageExpectation{
 		mock:   mmSendMessage.mock,
-		params: &TelegramBotAPIMockSendMessageParams{chatID, text},
+		params: &TelegramBotAPIMockSendMessageParams{chatID, text, parseMode},
 	}
 	mmSendMessage.expectations = append(mmSendMessage.expectations, expectation)
 	return expectation
@@ -1201,15 +1971,15 @@ func (e *TelegramBotAPIMockSendMessageExpectation) Then(i1 int, err error) *Tele
 }
 
 // SendMessage implements telegramBotAPI
-func (mmSendMessage *TelegramBotAPIMock) SendMessage(chatID int64, text string) (i1 int, err error) {
+func (mmSendMessage *TelegramBotAPIMock) SendMessage(chatID int64, text string, parseMode string) (i1 int, err error) {
 	mm_atomic.AddUint64(&mmSendMessage.beforeSendMessageCounter, 1)
 	defer mm_atomic.AddUint64(&mmSendMessage.afterSendMessageCounter, 1)
 
 	if mmSendMessage.inspectFuncSendMessage != nil {
-		mmSendMessage.inspectFuncSendMessage(chatID, text)
+		mmSendMessage.inspectFuncSendMessage(chatID, text, parseMode)
 	}
 
-	mm_params := &TelegramBotAPIMockSendMessageParams{chatID, text}
+	mm_params := &TelegramBotAPIMockSendMessageParams{chatID, text, parseMode}
 
 	// Record call args
 	mmSendMessage.SendMessageMock.mutex.Lock()
@@ -1226,7 +1996,7 @@ func (mmSendMessage *TelegramBotAPIMock) SendMessage(chatID int64, text string)
 	if mmSendMessage.SendMessageMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmSendMessage.SendMessageMock.defaultExpectation.Counter, 1)
 		mm_want := mmSendMessage.SendMessageMock.defaultExpectation.params
-		mm_got := TelegramBotAPIMockSendMessageParams{chatID, text}
+		mm_got := TelegramBotAPIMockSendMessageParams{chatID, text, parseMode}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmSendMessage.t.Errorf("TelegramBotAPIMock.SendMessage got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -1238,9 +2008,9 @@ func (mmSendMessage *TelegramBotAPIMock) SendMessage(chatID int64, text string)
 		return (*mm_results).i1, (*mm_results).err
 	}
 	if mmSendMessage.funcSendMessage != nil {
-		return mmSendMessage.funcSendMessage(chatID, text)
+		return mmSendMessage.funcSendMessage(chatID, text, parseMode)
 	}
-	mmSendMessage.t.Fatalf("Unexpected call to TelegramBotAPIMock.SendMessage. %v %v", chatID, text)
+	mmSendMessage.t.Fatalf("Unexpected call to TelegramBotAPIMock.SendMessage. %v %v %v", chatID, text, parseMode)
 	return
 }
 
@@ -1309,12 +2079,455 @@ func (m *TelegramBotAPIMock) MinimockSendMessageInspect() {
 	}
 }
 
+type mTelegramBotAPIMockSendReply struct {
+	mock               *TelegramBotAPIMock
+	defaultExpectation *TelegramBotAPIMockSendReplyExpectation
+	expectations       []*TelegramBotAPIMockSendReplyExpectation
+
+	callArgs []*TelegramBotAPIMockSendReplyParams
+	mutex    sync.RWMutex
+}
+
+// TelegramBotAPIMockSendReplyExpectation specifies expectation struct of the telegramBotAPI.SendReply
+type TelegramBotAPIMockSendReplyExpectation struct {
+	mock    *TelegramBotAPIMock
+	params  *TelegramBotAPIMockSendReplyParams
+	results *TelegramBotAPIMockSendReplyResults
+	Counter uint64
+}
+
+// TelegramBotAPIMockSendReplyParams contains parameters of the telegramBotAPI.SendReply
+type TelegramBotAPIMockSendReplyParams struct {
+	chatID    int64
+	replyToID int
+	text      string
+}
+
+// TelegramBotAPIMockSendReplyResults contains results of the telegramBotAPI.SendReply
+type TelegramBotAPIMockSendReplyResults struct {
+	i1  int
+	err error
+}
+
+// Expect sets up expected params for telegramBotAPI.SendReply
+func (mmSendReply *mTelegramBotAPIMockSendReply) Expect(chatID int64, replyToID int, text string) *mTelegramBotAPIMockSendReply {
+	if mmSendReply.mock.funcSendReply != nil {
+		mmSendReply.mock.t.Fatalf("TelegramBotAPIMock.SendReply mock is already set by Set")
+	}
+
+	if mmSendReply.defaultExpectation == nil {
+		mmSendReply.defaultExpectation = &TelegramBotAPIMockSendReplyExpectation{}
+	}
+
+	mmSendReply.defaultExpectation.params = &TelegramBotAPIMockSendReplyParams{chatID, replyToID, text}
+	for _, e := range mmSendReply.expectations {
+		if minimock.Equal(e.params, mmSendReply.defaultExpectation.params) {
+			mmSendReply.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSendReply.defaultExpectation.params)
+		}
+	}
+
+	return mmSendReply
+}
+
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.SendReply
+func (mmSendReply *mTelegramBotAPIMockSendReply) Inspect(f func(chatID int64, replyToID int, text string)) *mTelegramBotAPIMockSendReply {
+	if mmSendReply.mock.inspectFuncSendReply != nil {
+		mmSendReply.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.SendReply")
+	}
+
+	mmSendReply.mock.inspectFuncSendReply = f
+
+	return mmSendReply
+}
+
+// Return sets up results that will be returned by telegramBotAPI.SendReply
+func (mmSendReply *mTelegramBotAPIMockSendReply) Return(i1 int, err error) *TelegramBotAPIMock {
+	if mmSendReply.mock.funcSendReply != nil {
+		mmSendReply.mock.t.Fatalf("TelegramBotAPIMock.SendReply mock is already set by Set")
+	}
+
+	if mmSendReply.defaultExpectation == nil {
+		mmSendReply.defaultExpectation = &TelegramBotAPIMockSendReplyExpectation{mock: mmSendReply.mock}
+	}
+	mmSendReply.defaultExpectation.results = &TelegramBotAPIMockSendReplyResults{i1, err}
+	return mmSendReply.mock
+}
+
+// Set uses given function f to mock the telegramBotAPI.SendReply method
+func (mmSendReply *mTelegramBotAPIMockSendReply) Set(f func(chatID int64, replyToID int, text string) (i1 int, err error)) *TelegramBotAPIMock {
+	if mmSendReply.defaultExpectation != nil {
+		mmSendReply.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.SendReply method")
+	}
+
+	if len(mmSendReply.expectations) > 0 {
+		mmSendReply.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.SendReply method")
+	}
+
+	mmSendReply.mock.funcSendReply = f
+	return mmSendReply.mock
+}
+
+// When sets expectation for the telegramBotAPI.SendReply which will trigger the result defined by the following
+// Then helper
+func (mmSendReply *mTelegramBotAPIMockSendReply) When(chatID int64, replyToID int, text string) *TelegramBotAPIMockSendReplyExpectation {
+	if mmSendReply.mock.funcSendReply != nil {
+		mmSendReply.mock.t.Fatalf("TelegramBotAPIMock.SendReply mock is already set by Set")
+	}
+
+	expectation := &TelegramBotAPIMockSendReplyExpectation{
+		mock:   mmSendReply.mock,
+		params: &TelegramBotAPIMockSendReplyParams{chatID, replyToID, text},
+	}
+	mmSendReply.expectations = append(mmSendReply.expectations, expectation)
+	return expectation
+}
+
+// Then sets up telegramBotAPI.SendReply return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockSendReplyExpectation) Then(i1 int, err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockSendReplyResults{i1, err}
+	return e.mock
+}
+
+// SendReply implements telegramBotAPI
+func (mmSendReply *TelegramBotAPIMock) SendReply(chatID int64, replyToID int, text string) (i1 int, err error) {
+	mm_atomic.AddUint64(&mmSendReply.beforeSendReplyCounter, 1)
+	defer mm_atomic.AddUint64(&mmSendReply.afterSendReplyCounter, 1)
+
+	if mmSendReply.inspectFuncSendReply != nil {
+		mmSendReply.inspectFuncSendReply(chatID, replyToID, text)
+	}
+
+	mm_params := &TelegramBotAPIMockSendReplyParams{chatID, replyToID, text}
+
+	// Record call args
+	mmSendReply.SendReplyMock.mutex.Lock()
+	mmSendReply.SendReplyMock.callArgs = append(mmSendReply.SendReplyMock.callArgs, mm_params)
+	mmSendReply.SendReplyMock.mutex.Unlock()
+
+	for _, e := range mmSendReply.SendReplyMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmSendReply.SendReplyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSendReply.SendReplyMock.defaultExpectation.Counter, 1)
+		mm_want := mmSendReply.SendReplyMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockSendReplyParams{chatID, replyToID, text}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSendReply.t.Errorf("TelegramBotAPIMock.SendReply got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSendReply.SendReplyMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSendReply.t.Fatal("No results are set for the TelegramBotAPIMock.SendReply")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmSendReply.funcSendReply != nil {
+		return mmSendReply.funcSendReply(chatID, replyToID, text)
+	}
+	mmSendReply.t.Fatalf("Unexpected call to TelegramBotAPIMock.SendReply. %v %v %v", chatID, replyToID, text)
+	return
+}
+
+// SendReplyAfterCounter returns a count of finished TelegramBotAPIMock.SendReply invocations
+func (mmSendReply *TelegramBotAPIMock) SendReplyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSendReply.afterSendReplyCounter)
+}
+
+// SendReplyBeforeCounter returns a count of TelegramBotAPIMock.SendReply invocations
+func (mmSendReply *TelegramBotAPIMock) SendReplyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSendReply.beforeSendReplyCounter)
+}
+
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.SendReply.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSendReply *mTelegramBotAPIMockSendReply) Calls() []*TelegramBotAPIMockSendReplyParams {
+	mmSendReply.mutex.RLock()
+
+	argCopy := make([]*TelegramBotAPIMockSendReplyParams, len(mmSendReply.callArgs))
+	copy(argCopy, mmSendReply.callArgs)
+
+	mmSendReply.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSendReplyDone returns true if the count of the SendReply invocations corresponds
+// the number of defined expectations
+func (m *TelegramBotAPIMock) MinimockSendReplyDone() bool {
+	for _, e := range m.SendReplyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SendReplyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSendReplyCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSendReply != nil && mm_atomic.LoadUint64(&m.afterSendReplyCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSendReplyInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockSendReplyInspect() {
+	for _, e := range m.SendReplyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.SendReply with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SendReplyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSendReplyCounter) < 1 {
+		if m.SendReplyMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.SendReply")
+		} else {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.SendReply with params: %#v", *m.SendReplyMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSendReply != nil && mm_atomic.LoadUint64(&m.afterSendReplyCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.SendReply")
+	}
+}
+
+type mTelegramBotAPIMockSendReplyKeyboard struct {
+	mock               *TelegramBotAPIMock
+	defaultExpectation *TelegramBotAPIMockSendReplyKeyboardExpectation
+	expectations       []*TelegramBotAPIMockSendReplyKeyboardExpectation
+
+	callArgs []*TelegramBotAPIMockSendReplyKeyboardParams
+	mutex    sync.RWMutex
+}
+
+// TelegramBotAPIMockSendReplyKeyboardExpectation specifies expectation struct of the telegramBotAPI.SendReplyKeyboard
+type TelegramBotAPIMockSendReplyKeyboardExpectation struct {
+	mock    *TelegramBotAPIMock
+	params  *TelegramBotAPIMockSendReplyKeyboardParams
+	results *TelegramBotAPIMockSendReplyKeyboardResults
+	Counter uint64
+}
+
+// TelegramBotAPIMockSendReplyKeyboardParams contains parameters of the telegramBotAPI.SendReplyKeyboard
+type TelegramBotAPIMockSendReplyKeyboardParams struct {
+	chatID    int64
+	replyToID int
+	text      string
+	keyboard  tgbotapi.InlineKeyboardMarkup
+}
+
+// TelegramBotAPIMockSendReplyKeyboardResults contains results of the telegramBotAPI.SendReplyKeyboard
+type TelegramBotAPIMockSendReplyKeyboardResults struct {
+	i1  int
+	err error
+}
+
+// Expect sets up expected params for telegramBotAPI.SendReplyKeyboard
+func (mmSendReplyKeyboard *mTelegramBotAPIMockSendReplyKeyboard) Expect(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) *mTelegramBotAPIMockSendReplyKeyboard {
+	if mmSendReplyKeyboard.mock.funcSendReplyKeyboard != nil {
+		mmSendReplyKeyboard.mock.t.Fatalf("TelegramBotAPIMock.SendReplyKeyboard mock is already set by Set")
+	}
+
+	if mmSendReplyKeyboard.defaultExpectation == nil {
+		mmSendReplyKeyboard.defaultExpectation = &TelegramBotAPIMockSendReplyKeyboardExpectation{}
+	}
+
+	mmSendReplyKeyboard.defaultExpectation.params = &TelegramBotAPIMockSendReplyKeyboardParams{chatID, replyToID, text, keyboard}
+	for _, e := range mmSendReplyKeyboard.expectations {
+		if minimock.Equal(e.params, mmSendReplyKeyboard.defaultExpectation.params) {
+			mmSendReplyKeyboard.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSendReplyKeyboard.defaultExpectation.params)
+		}
+	}
+
+	return mmSendReplyKeyboard
+}
+
+// Inspect accepts an inspector function that has same arguments as the telegramBotAPI.SendReplyKeyboard
+func (mmSendReplyKeyboard *mTelegramBotAPIMockSendReplyKeyboard) Inspect(f func(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup)) *mTelegramBotAPIMockSendReplyKeyboard {
+	if mmSendReplyKeyboard.mock.inspectFuncSendReplyKeyboard != nil {
+		mmSendReplyKeyboard.mock.t.Fatalf("Inspect function is already set for TelegramBotAPIMock.SendReplyKeyboard")
+	}
+
+	mmSendReplyKeyboard.mock.inspectFuncSendReplyKeyboard = f
+
+	return mmSendReplyKeyboard
+}
+
+// Return sets up results that will be returned by telegramBotAPI.SendReplyKeyboard
+func (mmSendReplyKeyboard *mTelegramBotAPIMockSendReplyKeyboard) Return(i1 int, err error) *TelegramBotAPIMock {
+	if mmSendReplyKeyboard.mock.funcSendReplyKeyboard != nil {
+		mmSendReplyKeyboard.mock.t.Fatalf("TelegramBotAPIMock.SendReplyKeyboard mock is already set by Set")
+	}
+
+	if mmSendReplyKeyboard.defaultExpectation == nil {
+		mmSendReplyKeyboard.defaultExpectation = &TelegramBotAPIMockSendReplyKeyboardExpectation{mock: mmSendReplyKeyboard.mock}
+	}
+	mmSendReplyKeyboard.defaultExpectation.results = &TelegramBotAPIMockSendReplyKeyboardResults{i1, err}
+	return mmSendReplyKeyboard.mock
+}
+
+// Set uses given function f to mock the telegramBotAPI.SendReplyKeyboard method
+func (mmSendReplyKeyboard *mTelegramBotAPIMockSendReplyKeyboard) Set(f func(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) (i1 int, err error)) *TelegramBotAPIMock {
+	if mmSendReplyKeyboard.defaultExpectation != nil {
+		mmSendReplyKeyboard.mock.t.Fatalf("Default expectation is already set for the telegramBotAPI.SendReplyKeyboard method")
+	}
+
+	if len(mmSendReplyKeyboard.expectations) > 0 {
+		mmSendReplyKeyboard.mock.t.Fatalf("Some expectations are already set for the telegramBotAPI.SendReplyKeyboard method")
+	}
+
+	mmSendReplyKeyboard.mock.funcSendReplyKeyboard = f
+	return mmSendReplyKeyboard.mock
+}
+
+// When sets expectation for the telegramBotAPI.SendReplyKeyboard which will trigger the result defined by the following
+// Then helper
+func (mmSendReplyKeyboard *mTelegramBotAPIMockSendReplyKeyboard) When(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) *TelegramBotAPIMockSendReplyKeyboardExpectation {
+	if mmSendReplyKeyboard.mock.funcSendReplyKeyboard != nil {
+		mmSendReplyKeyboard.mock.t.Fatalf("TelegramBotAPIMock.SendReplyKeyboard mock is already set by Set")
+	}
+
+	expectation := &TelegramBotAPIMockSendReplyKeyboardExpectation{
+		mock:   mmSendReplyKeyboard.mock,
+		params: &TelegramBotAPIMockSendReplyKeyboardParams{chatID, replyToID, text, keyboard},
+	}
+	mmSendReplyKeyboard.expectations = append(mmSendReplyKeyboard.expectations, expectation)
+	return expectation
+}
+
+// Then sets up telegramBotAPI.SendReplyKeyboard return parameters for the expectation previously defined by the When method
+func (e *TelegramBotAPIMockSendReplyKeyboardExpectation) Then(i1 int, err error) *TelegramBotAPIMock {
+	e.results = &TelegramBotAPIMockSendReplyKeyboardResults{i1, err}
+	return e.mock
+}
+
+// SendReplyKeyboard implements telegramBotAPI
+func (mmSendReplyKeyboard *TelegramBotAPIMock) SendReplyKeyboard(chatID int64, replyToID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) (i1 int, err error) {
+	mm_atomic.AddUint64(&mmSendReplyKeyboard.beforeSendReplyKeyboardCounter, 1)
+	defer mm_atomic.AddUint64(&mmSendReplyKeyboard.afterSendReplyKeyboardCounter, 1)
+
+	if mmSendReplyKeyboard.inspectFuncSendReplyKeyboard != nil {
+		mmSendReplyKeyboard.inspectFuncSendReplyKeyboard(chatID, replyToID, text, keyboard)
+	}
+
+	mm_params := &TelegramBotAPIMockSendReplyKeyboardParams{chatID, replyToID, text, keyboard}
+
+	// Record call args
+	mmSendReplyKeyboard.SendReplyKeyboardMock.mutex.Lock()
+	mmSendReplyKeyboard.SendReplyKeyboardMock.callArgs = append(mmSendReplyKeyboard.SendReplyKeyboardMock.callArgs, mm_params)
+	mmSendReplyKeyboard.SendReplyKeyboardMock.mutex.Unlock()
+
+	for _, e := range mmSendReplyKeyboard.SendReplyKeyboardMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmSendReplyKeyboard.SendReplyKeyboardMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSendReplyKeyboard.SendReplyKeyboardMock.defaultExpectation.Counter, 1)
+		mm_want := mmSendReplyKeyboard.SendReplyKeyboardMock.defaultExpectation.params
+		mm_got := TelegramBotAPIMockSendReplyKeyboardParams{chatID, replyToID, text, keyboard}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSendReplyKeyboard.t.Errorf("TelegramBotAPIMock.SendReplyKeyboard got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSendReplyKeyboard.SendReplyKeyboardMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSendReplyKeyboard.t.Fatal("No results are set for the TelegramBotAPIMock.SendReplyKeyboard")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmSendReplyKeyboard.funcSendReplyKeyboard != nil {
+		return mmSendReplyKeyboard.funcSendReplyKeyboard(chatID, replyToID, text, keyboard)
+	}
+	mmSendReplyKeyboard.t.Fatalf("Unexpected call to TelegramBotAPIMock.SendReplyKeyboard. %v %v %v %v", chatID, replyToID, text, keyboard)
+	return
+}
+
+// SendReplyKeyboardAfterCounter returns a count of finished TelegramBotAPIMock.SendReplyKeyboard invocations
+func (mmSendReplyKeyboard *TelegramBotAPIMock) SendReplyKeyboardAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSendReplyKeyboard.afterSendReplyKeyboardCounter)
+}
+
+// SendReplyKeyboardBeforeCounter returns a count of TelegramBotAPIMock.SendReplyKeyboard invocations
+func (mmSendReplyKeyboard *TelegramBotAPIMock) SendReplyKeyboardBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSendReplyKeyboard.beforeSendReplyKeyboardCounter)
+}
+
+// Calls returns a list of arguments used in each call to TelegramBotAPIMock.SendReplyKeyboard.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSendReplyKeyboard *mTelegramBotAPIMockSendReplyKeyboard) Calls() []*TelegramBotAPIMockSendReplyKeyboardParams {
+	mmSendReplyKeyboard.mutex.RLock()
+
+	argCopy := make([]*TelegramBotAPIMockSendReplyKeyboardParams, len(mmSendReplyKeyboard.callArgs))
+	copy(argCopy, mmSendReplyKeyboard.callArgs)
+
+	mmSendReplyKeyboard.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSendReplyKeyboardDone returns true if the count of the SendReplyKeyboard invocations corresponds
+// the number of defined expectations
+func (m *TelegramBotAPIMock) MinimockSendReplyKeyboardDone() bool {
+	for _, e := range m.SendReplyKeyboardMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SendReplyKeyboardMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSendReplyKeyboardCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSendReplyKeyboard != nil && mm_atomic.LoadUint64(&m.afterSendReplyKeyboardCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSendReplyKeyboardInspect logs each unmet expectation
+func (m *TelegramBotAPIMock) MinimockSendReplyKeyboardInspect() {
+	for _, e := range m.SendReplyKeyboardMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.SendReplyKeyboard with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SendReplyKeyboardMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSendReplyKeyboardCounter) < 1 {
+		if m.SendReplyKeyboardMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TelegramBotAPIMock.SendReplyKeyboard")
+		} else {
+			m.t.Errorf("Expected call to TelegramBotAPIMock.SendReplyKeyboard with params: %#v", *m.SendReplyKeyboardMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSendReplyKeyboard != nil && mm_atomic.LoadUint64(&m.afterSendReplyKeyboardCounter) < 1 {
+		m.t.Error("Expected call to TelegramBotAPIMock.SendReplyKeyboard")
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *TelegramBotAPIMock) MinimockFinish() {
 	if !m.minimockDone() {
-		m.MinimockEditMessageInspect()
+		m.MinimockAnswerCallbackQueryInspect()
+
+		m.MinimockAnswerInlineQueryInspect()
+
+		m.MinimockDeleteMessageInspect()
 
-		m.MinimockGetChatPinnedMessageIDInspect()
+		m.MinimockEditCaptionInspect()
+
+		m.MinimockEditMessageInspect()
 
 		m.MinimockGetUpdatesInspect()
 
@@ -1323,6 +2536,10 @@ func (m *TelegramBotAPIMock) MinimockFinish() {
 		m.MinimockSendAnimationInspect()
 
 		m.MinimockSendMessageInspect()
+
+		m.MinimockSendReplyInspect()
+
+		m.MinimockSendReplyKeyboardInspect()
 		m.t.FailNow()
 	}
 }
@@ -1346,10 +2563,15 @@ func (m *TelegramBotAPIMock) MinimockWait(timeout mm_time.Duration) {
 func (m *TelegramBotAPIMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockAnswerCallbackQueryDone() &&
+		m.MinimockAnswerInlineQueryDone() &&
+		m.MinimockDeleteMessageDone() &&
+		m.MinimockEditCaptionDone() &&
 		m.MinimockEditMessageDone() &&
-		m.MinimockGetChatPinnedMessageIDDone() &&
 		m.MinimockGetUpdatesDone() &&
 		m.MinimockPinMessageDone() &&
 		m.MinimockSendAnimationDone() &&
-		m.MinimockSendMessageDone()
+		m.MinimockSendMessageDone() &&
+		m.MinimockSendReplyDone() &&
+		m.MinimockSendReplyKeyboardDone()
 }
@@ -0,0 +1,215 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/cyhalothrin/gifkoskladbot/similar"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+const (
+	// typoCallbackPrefix данные кнопок подсказки: typo:<id>:fix или typo:<id>:keep,
+	// id - сообщение с подписью в том же чате, что и подсказка
+	typoCallbackPrefix = "typo:"
+	typoActionFix      = "fix"
+	typoActionKeep     = "keep"
+	// pendingTyposTTL сколько подсказка ждет ответа, потом подпись придется отправить заново
+	pendingTyposTTL = 24 * time.Hour
+)
+
+// pendingTyposKey id подсказки в хранилище: чат и сообщение с подписью
+func pendingTyposKey(chatID int64, messageID int) string {
+	return fmt.Sprintf("%d:%d", chatID, messageID)
+}
+
+// loadPendingTypos подсказки, которые ждут ответа, без устаревших. Бот обычно запускается на одну пачку
+// обновлений, поэтому ответ на подсказку приходит уже в другой процесс
+func (u *UpdatesHandler) loadPendingTypos() map[string]*storage.PendingTypos {
+	pending := u.storage.GetPendingTypos()
+	if pending == nil {
+		pending = make(map[string]*storage.PendingTypos)
+	}
+
+	now := time.Now()
+	for key, p := range pending {
+		if now.Sub(p.AskedAt) > pendingTyposTTL {
+			delete(pending, key)
+		}
+	}
+
+	return pending
+}
+
+// findTypos новые теги, похожие на уже известные. Известными считаются теги из списка
+// и теги гифок, которые ждут отправки
+func (u *UpdatesHandler) findTypos(tags []string) []storage.TagTypo {
+	knownSet := make(map[string]bool, len(u.uniqueTags))
+	for tag := range u.uniqueTags {
+		knownSet[tag] = true
+	}
+	for _, anim := range u.animationsNewCaptions {
+		for _, tag := range anim.Tags {
+			if strings.HasPrefix(tag, "#") {
+				knownSet[tag] = true
+			}
+		}
+	}
+
+	known := make([]string, 0, len(knownSet))
+	for tag := range knownSet {
+		known = append(known, tag)
+	}
+	sort.Strings(known)
+
+	var typos []storage.TagTypo
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, "#") || knownSet[tag] {
+			continue
+		}
+
+		if suggestion, ok := similar.Closest(tag, known); ok {
+			typos = append(typos, storage.TagTypo{Tag: tag, Suggestion: suggestion})
+		}
+	}
+
+	return typos
+}
+
+// askAboutTypos спросит автора подписи, не опечатался ли он. Гифка не уйдет в канал, пока не ответят
+func (u *UpdatesHandler) askAboutTypos(message *tgbotapi.Message, pending *storage.PendingTypos) error {
+	u.dropPendingTypos(pending.FileID)
+
+	id := strconv.Itoa(message.MessageID)
+	key := pendingTyposKey(message.Chat.ID, message.MessageID)
+
+	lines := make([]string, 0, len(pending.Typos))
+	for _, typo := range pending.Typos {
+		lines = append(lines, fmt.Sprintf("%s => %s", typo.Tag, typo.Suggestion))
+	}
+
+	fixText := "Исправить"
+	keepText := "Оставить как есть"
+	if len(pending.Typos) == 1 {
+		fixText = pending.Typos[0].Suggestion
+		keepText = "Оставить " + pending.Typos[0].Tag
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(fixText, typoCallbackPrefix+id+":"+typoActionFix),
+		tgbotapi.NewInlineKeyboardButtonData(keepText, typoCallbackPrefix+id+":"+typoActionKeep),
+	))
+
+	text := "Может, имелось в виду:\n" + strings.Join(lines, "\n")
+	if _, err := u.api.SendReplyKeyboard(message.Chat.ID, message.MessageID, text, keyboard); err != nil {
+		return fmt.Errorf("подсказка про опечатки: %w", err)
+	}
+
+	pending.AskedAt = time.Now()
+	u.pendingTypos[key] = pending
+	u.storage.SetPendingTypos(u.pendingTypos)
+
+	return nil
+}
+
+// handleTypoCallback ответ на подсказку про опечатки: исправить теги или оставить новые
+func (u *UpdatesHandler) handleTypoCallback(update tgbotapi.Update) (bool, error) {
+	query := update.CallbackQuery
+	if query == nil || !strings.HasPrefix(query.Data, typoCallbackPrefix) {
+		return false, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(query.Data, typoCallbackPrefix), ":")
+	if len(parts) != 2 || (parts[1] != typoActionFix && parts[1] != typoActionKeep) {
+		return true, fmt.Errorf("кривые данные кнопки '%s'", query.Data)
+	}
+	messageID, err := strconv.Atoi(parts[0])
+	if err != nil || query.Message == nil {
+		return true, fmt.Errorf("кривые данные кнопки '%s'", query.Data)
+	}
+
+	key := pendingTyposKey(query.Message.Chat.ID, messageID)
+	pending, ok := u.pendingTypos[key]
+	if !ok {
+		if err := u.api.AnswerCallbackQuery(query.ID, "Подсказка устарела, отправь подпись еще раз"); err != nil {
+			return true, err
+		}
+
+		return true, nil
+	}
+	delete(u.pendingTypos, key)
+	u.storage.SetPendingTypos(u.pendingTypos)
+
+	tags := pending.Tags
+	result := "Оставил новые теги"
+	if parts[1] == typoActionFix {
+		tags = fixTypos(pending.Tags, pending.Typos)
+		result = "Исправил теги"
+	}
+
+	author := &tgbotapi.User{ID: pending.UserID, UserName: pending.UserName}
+	if u.addAnimation(pending.FileID, tags, pending.Description, pending.Channels, author, pending.ChangedAt) {
+		log.Printf("%s после подсказки => %v\n", query.Data, tags)
+	}
+
+	var errs []string
+	if err := u.api.AnswerCallbackQuery(query.ID, ""); err != nil {
+		errs = append(errs, err.Error())
+	}
+	text := fmt.Sprintf("%s: %s", result, strings.Join(tags, " "))
+	// без клавиатуры в новом тексте кнопки пропадут
+	if err := u.api.EditMessage(query.Message.Chat.ID, query.Message.MessageID, text, ""); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return true, errors.New("ответ на подсказку: " + strings.Join(errs, "; "))
+	}
+
+	return true, nil
+}
+
+// dropPendingTypos на новую подпись гифки старая подсказка уже не нужна
+func (u *UpdatesHandler) dropPendingTypos(fileID string) {
+	dropped := false
+	for key, pending := range u.pendingTypos {
+		if pending.FileID == fileID {
+			delete(u.pendingTypos, key)
+			dropped = true
+		}
+	}
+
+	if dropped {
+		u.storage.SetPendingTypos(u.pendingTypos)
+	}
+}
+
+// fixTypos заменит теги с опечатками на подсказки, без повторов
+func fixTypos(tags []string, typos []storage.TagTypo) []string {
+	replace := make(map[string]string, len(typos))
+	for _, typo := range typos {
+		replace[typo.Tag] = typo.Suggestion
+	}
+
+	fixed := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if suggestion, ok := replace[tag]; ok {
+			tag = suggestion
+		}
+		if seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		fixed = append(fixed, tag)
+	}
+
+	return fixed
+}
@@ -0,0 +1,191 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+func TestUpdatesHandler_findTypos(t *testing.T) {
+	t.Parallel()
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	store := NewGifkoskladMetaStorageMock(mc).
+		GetTagsAliasesMock.Return(nil).
+		GetUserRolesMock.Return(nil).
+		GetPendingTyposMock.Return(nil).
+		GetSentAnimationsMock.Return(nil).
+		GetTagsMock.Return([]string{"#kitten", "#котик"})
+
+	u := NewUpdatesHandler(config.Config{}, store, nil, NewTelegramBotAPIMock(mc))
+	u.animationsNewCaptions["file_1"] = &storage.SentAnimation{FileID: "file_1", Tags: []string{"#like_a_boss", "описание"}}
+
+	assert.Equal(t, []storage.TagTypo{
+		{Tag: "#kittne", Suggestion: "#kitten"},
+		{Tag: "#kotik", Suggestion: "#котик"},
+		{Tag: "#like_a_bos", Suggestion: "#like_a_boss"},
+	}, u.findTypos([]string{"#kitten", "#kittne", "#kotik", "#horse", "#like_a_bos", "описание"}))
+	assert.Empty(t, u.findTypos([]string{"#kitten", "#horse"}))
+}
+
+func TestUpdatesHandler_typos(t *testing.T) {
+	t.Parallel()
+
+	captionUpdate := tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID:      5,
+		From:           &tgbotapi.User{ID: 1, UserName: "user"},
+		Chat:           &tgbotapi.Chat{ID: 200},
+		Date:           100,
+		ReplyToMessage: &tgbotapi.Message{Animation: &tgbotapi.ChatAnimation{FileID: "file_1"}},
+		Text:           "#kittne #cat",
+	}}
+	callbackUpdate := func(data string) tgbotapi.Update {
+		return tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			ID:      "cb",
+			From:    &tgbotapi.User{ID: 2, UserName: "user"},
+			Message: &tgbotapi.Message{MessageID: 6, Chat: &tgbotapi.Chat{ID: 200}},
+			Data:    data,
+		}}
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("#kitten", "typo:5:fix"),
+		tgbotapi.NewInlineKeyboardButtonData("Оставить #kittne", "typo:5:keep"),
+	))
+
+	tests := []struct {
+		name     string
+		callback string
+		api      func(mc *minimock.Controller) telegramBotAPI
+		wantTags []string
+	}{
+		{
+			"should fix typo",
+			"typo:5:fix",
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendReplyKeyboardMock.Expect(200, 5, "Может, имелось в виду:\n#kittne => #kitten", keyboard).Return(6, nil).
+					AnswerCallbackQueryMock.Expect("cb", "").Return(nil).
+					EditMessageMock.Expect(200, 6, "Исправил теги: #kitten #cat", "").Return(nil)
+			},
+			[]string{"#kitten", "#cat"},
+		},
+		{
+			"should keep new tag",
+			"typo:5:keep",
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendReplyKeyboardMock.Expect(200, 5, "Может, имелось в виду:\n#kittne => #kitten", keyboard).Return(6, nil).
+					AnswerCallbackQueryMock.Expect("cb", "").Return(nil).
+					EditMessageMock.Expect(200, 6, "Оставил новые теги: #kittne #cat", "").Return(nil)
+			},
+			[]string{"#kittne", "#cat"},
+		},
+		{
+			"should answer on stale suggestion",
+			"typo:2:fix",
+			func(mc *minimock.Controller) telegramBotAPI {
+				return NewTelegramBotAPIMock(mc).
+					SendReplyKeyboardMock.Expect(200, 5, "Может, имелось в виду:\n#kittne => #kitten", keyboard).Return(6, nil).
+					AnswerCallbackQueryMock.Expect("cb", "Подсказка устарела, отправь подпись еще раз").Return(nil)
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			// подсказка переживает перезапуск бота, на кнопку отвечает уже новый обработчик
+			var saved map[string]*storage.PendingTypos
+			store := NewGifkoskladMetaStorageMock(mc).
+				GetTagsAliasesMock.Return(nil).
+				GetUserRolesMock.Return(nil).
+				GetPendingTyposMock.Set(func() map[string]*storage.PendingTypos {
+				return saved
+			}).
+				SetPendingTyposMock.Set(func(pending map[string]*storage.PendingTypos) {
+				saved = make(map[string]*storage.PendingTypos, len(pending))
+				for key, p := range pending {
+					saved[key] = p
+				}
+			}).
+				GetSentAnimationsMock.Return(nil).
+				GetTagsMock.Return([]string{"#cat", "#kitten"})
+			tgAPI := tt.api(mc)
+
+			u := NewUpdatesHandler(config.Config{}, store, nil, tgAPI)
+
+			ok, err := u.handleAnimationCaption(captionUpdate)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Empty(t, u.animationsNewCaptions, "gif should wait for the answer")
+			assert.Len(t, saved, 1)
+
+			u = NewUpdatesHandler(config.Config{}, store, nil, tgAPI)
+			ok, err = u.handleTypoCallback(callbackUpdate(tt.callback))
+			assert.True(t, ok)
+			assert.NoError(t, err)
+
+			if tt.wantTags == nil {
+				assert.Empty(t, u.animationsNewCaptions)
+
+				return
+			}
+			assert.Equal(t, map[string]*storage.SentAnimation{
+				"file_1": {FileID: "file_1", Tags: tt.wantTags},
+			}, u.animationsNewCaptions)
+			assert.Equal(t, &storage.TagChange{
+				FileID:    "file_1",
+				ChangedAt: time.Unix(100, 0).UTC(),
+				UserID:    1,
+				UserName:  "user",
+				NewTags:   tt.wantTags,
+			}, u.tagChanges["file_1"])
+			assert.Empty(t, u.pendingTypos)
+			assert.Empty(t, saved)
+		})
+	}
+}
+
+func TestUpdatesHandler_loadPendingTypos(t *testing.T) {
+	t.Parallel()
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	store := NewGifkoskladMetaStorageMock(mc).
+		GetTagsAliasesMock.Return(nil).
+		GetUserRolesMock.Return(nil).
+		GetPendingTyposMock.Return(map[string]*storage.PendingTypos{
+		"200:5": {FileID: "file_1", AskedAt: time.Now().Add(-time.Hour)},
+		"200:6": {FileID: "file_2", AskedAt: time.Now().Add(-pendingTyposTTL - time.Hour)},
+	}).
+		GetSentAnimationsMock.Return(nil).
+		GetTagsMock.Return(nil)
+
+	u := NewUpdatesHandler(config.Config{}, store, nil, NewTelegramBotAPIMock(mc))
+
+	assert.Len(t, u.pendingTypos, 1, "stale suggestion should be dropped")
+	assert.Contains(t, u.pendingTypos, "200:5")
+}
+
+func Test_fixTypos(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"#kitten", "#cat", "описание"},
+		fixTypos([]string{"#kittne", "#cat", "#kitten", "описание"}, []storage.TagTypo{{Tag: "#kittne", Suggestion: "#kitten"}}),
+	)
+}
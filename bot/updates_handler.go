@@ -1,18 +1,29 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"sort"
 	"strings"
-	"sync"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 
+	"github.com/cyhalothrin/gifkoskladbot/caption"
 	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/search"
 	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
 )
 
+const captionUsage = `Подпись пишется реплаем на гифку:
+#cat #funny кот упал - теги и описание
+"like a boss" - тег из нескольких слов
++#cat -#dog - добавить и убрать теги у уже отправленной гифки
+@канал - отправить в этот канал, у отправленной гифки - перенести
+Старая разметка тоже работает: cat funny 00описание00 11тег из слов11`
+
 type UpdatesHandler struct {
 	api     telegramBotAPI
 	conf    config.Config
@@ -21,12 +32,45 @@ type UpdatesHandler struct {
 	// animationsNewCaptions список сообщений для отправки, по fileID
 	animationsNewCaptions map[string]*storage.SentAnimation
 	sentAnimations        map[string]*storage.SentAnimation
-	allowedUsers          map[string]bool
-	tagsAliases           map[string]string
+	// allowedUsers админы по именам из устаревшего AllowedUsers
+	allowedUsers map[string]bool
+	// adminIDs админы из конфига, их роль нельзя поменять из чата
+	adminIDs map[int]bool
+	// userRoles роли, выданные через /role, по id пользователя
+	userRoles   map[int]role
+	tagsAliases map[string]string
 	// uniqueTags уникальные теги, сюда будут добавляться новые
 	uniqueTags map[string]bool
 	// hasTagsListChanges были ли добавлены новые теги в uniqueTags
 	hasTagsListChanges bool
+	// tagChanges изменения тегов из animationsNewCaptions для истории, по fileID
+	tagChanges map[string]*storage.TagChange
+	// changeOrder порядковый номер последнего изменения из tagChanges, по fileID.
+	// ChangedAt с точностью до секунды, порядок внутри секунды держится по нему
+	changeOrder map[string]int
+	// lastChangeOrder последний выданный номер в changeOrder
+	lastChangeOrder int
+	// pendingChannels каналы для гифок из animationsNewCaptions, по fileID
+	pendingChannels map[string][]int64
+	// updateID обновление, которое сейчас обрабатывается, 0 вне HandleUpdates
+	updateID int
+	// pendingUpdates обновления, из-за которых гифка из animationsNewCaptions попала в очередь, по fileID
+	pendingUpdates map[string][]int
+	// failedUpdates обновления, гифки из которых не дошли до канала
+	failedUpdates map[int]bool
+	// channels правила выбора канала для гифок
+	channels *channelRoutes
+	// index поиск по тегам для инлайн запросов
+	index *search.Index
+	// tagsFormat разметка списка тегов в канале
+	tagsFormat *tagsIndexFormatter
+	// captionFormat разметка подписей гифок в канале
+	captionFormat *captionFormatter
+	// normalizer все теги из чата проходят через него перед сохранением
+	normalizer *tagnorm.Normalizer
+	// pendingTypos подписи, которые ждут ответа на подсказку про опечатки, по id подсказки
+	pendingTypos map[string]*storage.PendingTypos
+	router       *router
 }
 
 func NewUpdatesHandler(
@@ -45,6 +89,11 @@ func NewUpdatesHandler(
 		allowedUsers[username] = true
 	}
 
+	adminIDs := make(map[int]bool)
+	for _, id := range conf.AdminIDs {
+		adminIDs[id] = true
+	}
+
 	sentAnimations := store.GetSentAnimations()
 	if sentAnimations == nil {
 		sentAnimations = make(map[string]*storage.SentAnimation)
@@ -55,47 +104,123 @@ func NewUpdatesHandler(
 		uniqueTags[tag] = true
 	}
 
-	return &UpdatesHandler{
+	normalizer := tagnorm.New(conf.TagNormalization)
+
+	channels, err := newChannelRoutes(conf, normalizer)
+	if err != nil {
+		// конфиг проверяется при запуске бота, сюда попасть не должны
+		log.Printf("Все гифки пойдут в основной канал: %s\n", err)
+		channels, _ = newChannelRoutes(config.Config{ChannelID: conf.ChannelID}, normalizer)
+	}
+
+	captionFormat, err := newCaptionFormatter(conf)
+	if err != nil {
+		// конфиг проверяется при запуске бота, сюда попасть не должны
+		log.Printf("Подписи гифок будут простым текстом: %s\n", err)
+		captionFormat, _ = newCaptionFormatter(config.Config{})
+	}
+
+	tagsFormat, err := newTagsIndexFormatter(conf)
+	if err != nil {
+		// конфиг проверяется при запуске бота, сюда попасть не должны
+		log.Printf("Список тегов будет простым текстом: %s\n", err)
+		tagsFormat, _ = newTagsIndexFormatter(config.Config{})
+	}
+
+	u := &UpdatesHandler{
 		api:                   tgAPI,
 		conf:                  conf,
 		storage:               store,
 		alert:                 alert,
 		animationsNewCaptions: make(map[string]*storage.SentAnimation),
+		tagChanges:            make(map[string]*storage.TagChange),
+		changeOrder:           make(map[string]int),
+		pendingChannels:       make(map[string][]int64),
+		pendingUpdates:        make(map[string][]int),
+		failedUpdates:         make(map[int]bool),
+		channels:              channels,
 		tagsAliases:           aliases,
 		allowedUsers:          allowedUsers,
+		adminIDs:              adminIDs,
 		sentAnimations:        sentAnimations,
 		uniqueTags:            uniqueTags,
+		index:                 search.NewIndex(sentAnimations),
+		tagsFormat:            tagsFormat,
+		captionFormat:         captionFormat,
+		normalizer:            normalizer,
 	}
+	u.userRoles = u.loadUserRoles()
+	u.pendingTypos = u.loadPendingTypos()
+	u.router = u.newRouter()
+
+	return u
 }
 
-func (u *UpdatesHandler) HandleUpdates(updates []tgbotapi.Update) error {
-	handlers := []updateHandler{
-		u.handleAnimationCaption,
-	}
+// newRouter маршруты бота, новые обработчики регистрировать тут
+func (u *UpdatesHandler) newRouter() *router {
+	r := newRouter()
+	r.Use(
+		alertMiddleware(u.sendMeError),
+		logMiddleware,
+		recoverMiddleware,
+		authMiddleware(
+			u.userRole,
+			u.channels.channelIDs(),
+			// по ссылкам из списка тегов приходят подписчики канала, роль проверит сам handleStartCommand
+			map[string]bool{"start": true},
+		),
+	)
+
+	// поиск доступен всем с ролью, это проверяет authMiddleware
+	r.Command("alias", requireRole(u.userRole, roleAdmin, u.handleAliasCommand))
+	r.Command("role", requireRole(u.userRole, roleAdmin, u.handleRoleCommand))
+	r.Command("start", u.handleStartCommand)
+	r.Reply(requireRole(u.userRole, roleTagger, u.handleAnimationCaption))
+	r.InlineQuery(u.handleInlineQuery)
+	r.CallbackQuery(requireRole(u.userRole, roleTagger, u.handleTypoCallback))
+
+	return r
+}
 
+// HandleUpdates обработает обновления и отправит изменения в канал. Вернет id обновлений, обработанных
+// до конца, их можно подтверждать. Обновление не обработано, если его гифка не дошла до канала, тогда оно
+// придет еще раз, а остальные повторно обрабатывать не надо. Ошибки обработчиков уходят админу,
+// такие обновления считаются обработанными, иначе ответы в чат повторялись бы на каждом запросе.
+// Список тегов к обновлениям не привязан, при ошибке он обновится в следующий раз
+func (u *UpdatesHandler) HandleUpdates(updates []tgbotapi.Update) ([]int, error) {
 	if len(updates) == 0 {
 		log.Println("Нет обновлений")
 
-		return nil
+		return nil, nil
 	}
 
 	for _, update := range updates {
-		for _, handler := range handlers {
-			ok, err := handler(update)
-			if err != nil {
-				u.sendMeError(err)
+		u.updateID = update.UpdateID
+		if _, err := u.router.Handle(update); err != nil {
+			u.sendMeError(err)
+		}
+	}
+	u.updateID = 0
 
-				break // дальше не запускаем обработчики
-			}
+	publishErr := u.PublishAnimations()
 
-			if ok {
-				break
-			}
+	handled := make([]int, 0, len(updates))
+	for _, update := range updates {
+		if !u.failedUpdates[update.UpdateID] {
+			handled = append(handled, update.UpdateID)
 		}
 	}
+	u.failedUpdates = make(map[int]bool)
+
+	if err := u.UpdateTagsList(); err != nil {
+		if publishErr != nil {
+			log.Println(publishErr)
+		}
 
-	u.PublishAnimations()
-	return u.UpdateTagsList()
+		return handled, err
+	}
+
+	return handled, publishErr
 }
 
 // handleAnimationCaption вся суть бота. Предполагаю что ему будут отправляться гифки и реплей на них с подписью
@@ -111,11 +236,6 @@ func (u *UpdatesHandler) handleAnimationCaption(update tgbotapi.Update) (bool, e
 		message = update.EditedMessage
 	}
 
-	if message.From == nil || !u.allowedUsers[message.From.UserName] {
-		// возможно это не тот тип сообщения, отправим дальше на обработку
-		return false, nil
-	}
-
 	if message.ReplyToMessage == nil {
 		// возможно это не тот тип сообщения, отправим дальше на обработку
 		return false, nil
@@ -135,23 +255,88 @@ func (u *UpdatesHandler) handleAnimationCaption(update tgbotapi.Update) (bool, e
 
 	fmt.Println(animation.FileID, text)
 
-	tags := u.parseTags(strings.ToLower(text))
-	if u.AddAnimationWithTags(animation.FileID, tags) {
+	changedAt := message.Time()
+	if message.EditDate != 0 {
+		changedAt = time.Unix(int64(message.EditDate), 0)
+	}
+
+	tags, description, channels, err := u.parseCaption(animation.FileID, strings.ToLower(text))
+	if err != nil {
+		// ошибка в подписи, расскажем автору, а не админу
+		reply := fmt.Sprintf("Не понял подпись: %s\n\n%s", err, captionUsage)
+		if _, sendErr := u.api.SendReply(message.Chat.ID, message.MessageID, reply); sendErr != nil {
+			return true, fmt.Errorf("ответ на подпись с ошибкой: %w", sendErr)
+		}
+
+		return true, nil
+	}
+
+	if typos := u.findTypos(tags); len(typos) > 0 {
+		pending := &storage.PendingTypos{
+			FileID:      animation.FileID,
+			Tags:        tags,
+			Description: description,
+			Channels:    channels,
+			Typos:       typos,
+			ChangedAt:   changedAt.UTC(),
+		}
+		if message.From != nil {
+			pending.UserID = message.From.ID
+			pending.UserName = message.From.UserName
+		}
+
+		return true, u.askAboutTypos(message, pending)
+	}
+
+	u.dropPendingTypos(animation.FileID)
+	if u.addAnimation(animation.FileID, tags, description, channels, message.From, changedAt) {
 		log.Printf("%s => %v\n", text, tags)
 	}
 
 	return true, nil
 }
 
-func (u *UpdatesHandler) AddAnimationWithTags(fileID string, tags []string) bool {
+// AddAnimationWithTags добавит гифку в очередь на отправку, если теги или описание изменились.
+// author автор изменения для истории, nil если изменение сделано не из чата
+func (u *UpdatesHandler) AddAnimationWithTags(
+	fileID string,
+	tags []string,
+	description string,
+	author *tgbotapi.User,
+	changedAt time.Time,
+) bool {
+	return u.addAnimation(fileID, tags, description, nil, author, changedAt)
+}
+
+// addAnimation то же, что AddAnimationWithTags, channels каналы из подписи, без них гифка остается
+// в своих каналах, а новая выбирает канал по правилам
+func (u *UpdatesHandler) addAnimation(
+	fileID string,
+	tags []string,
+	description string,
+	channels []int64,
+	author *tgbotapi.User,
+	changedAt time.Time,
+) bool {
+	channels = u.targetChannels(fileID, tags, channels, author)
+
+	var oldTags []string
+	var oldDescription string
+	var channelMessages map[int64]int
 	id := 0
 	sentMsg := u.sentAnimations[fileID]
 	if sentMsg != nil {
-		if u.captionsIsEqual(sentMsg.Tags, tags) {
+		oldTags, oldDescription = animationCaption(sentMsg)
+		if u.captionsIsEqual(oldTags, tags) && oldDescription == description &&
+			sameChannels(u.postedChannels(sentMsg), channels) {
 			// если было предыдущее сообщение с другими тегами, а потом было отредактировано, но в этот виде
 			// было сохранено в базе, то почистим все что сюда попало
 			// была такая бага
 			delete(u.animationsNewCaptions, fileID)
+			delete(u.tagChanges, fileID)
+			delete(u.changeOrder, fileID)
+			delete(u.pendingChannels, fileID)
+			delete(u.pendingUpdates, fileID)
 
 			log.Printf("Нет изменений '%s' (fileID: %s)\n", strings.Join(tags, " "), fileID)
 			// к этому файлу уже было отправлены теги и не изменились
@@ -160,76 +345,208 @@ func (u *UpdatesHandler) AddAnimationWithTags(fileID string, tags []string) bool
 
 		log.Printf(
 			"Обновлены теги '%s' => '%s' (fileID: %s)\n",
-			strings.Join(sentMsg.Tags, " "),
+			strings.Join(oldTags, " "),
 			strings.Join(tags, " "),
 			fileID,
 		)
 
 		id = sentMsg.MessageID
+		for chatID, messageID := range sentMsg.ChannelMessages {
+			if channelMessages == nil {
+				channelMessages = make(map[int64]int, len(sentMsg.ChannelMessages))
+			}
+			channelMessages[chatID] = messageID
+		}
 	}
 
 	u.animationsNewCaptions[fileID] = &storage.SentAnimation{
-		FileID:    fileID,
-		Tags:      tags,
-		MessageID: id,
+		FileID:          fileID,
+		Tags:            tags,
+		Description:     description,
+		MessageID:       id,
+		ChannelMessages: channelMessages,
+	}
+	u.pendingChannels[fileID] = channels
+	if u.updateID != 0 {
+		u.pendingUpdates[fileID] = append(u.pendingUpdates[fileID], u.updateID)
+	}
+
+	change := &storage.TagChange{
+		FileID:         fileID,
+		ChangedAt:      changedAt.UTC(),
+		OldTags:        oldTags,
+		NewTags:        tags,
+		OldDescription: oldDescription,
+		NewDescription: description,
+	}
+	if author != nil {
+		change.UserID = author.ID
+		change.UserName = author.UserName
 	}
+	u.tagChanges[fileID] = change
+	u.lastChangeOrder++
+	u.changeOrder[fileID] = u.lastChangeOrder
 
 	return true
 }
 
-func (u *UpdatesHandler) PublishAnimations() {
+// PublishAnimations отправит гифки из очереди в канал в том порядке, в котором их тегали, и сохранит отправленные.
+// Гифки, которые не удалось отправить, в хранилище не попадут, очередь сбрасывается в любом случае:
+// обновления с ними не будут подтверждены и придут еще раз. Если гифка успела уйти только в часть каналов,
+// сохранятся ее новые сообщения, чтобы при повторе она отправилась только в оставшиеся
+func (u *UpdatesHandler) PublishAnimations() error {
 	if len(u.animationsNewCaptions) == 0 {
-		return
+		return nil
 	}
 
-	var wg sync.WaitGroup
-
-	for _, msg := range u.animationsNewCaptions {
-		wg.Add(1)
-		go u.sendAnimation(msg, &wg) // TODO: check it was sent without error
+	// по одной, частоту отправки держит лимитер в api, а порядок в канале сохранится
+	failed := make(map[string]bool)
+	// partlySent не отправленные гифки, у которых все же появились новые сообщения в каналах
+	partlySent := make(map[string]bool)
+	for _, msg := range u.pendingInOrder() {
+		before := messagesOf(msg)
+		if err := u.sendAnimation(msg); err != nil {
+			u.sendMeError(withAlertContext(err, "fileID: %s", msg.FileID))
+			failed[msg.FileID] = true
+			partlySent[msg.FileID] = !sameMessages(before, msg)
+		}
 	}
 
-	wg.Wait()
+	for fileID := range failed {
+		for _, updateID := range u.pendingUpdates[fileID] {
+			u.failedUpdates[updateID] = true
+		}
+		if partlySent[fileID] {
+			log.Printf("Гифка отправлена не во все каналы, сохраню то, что ушло (fileID: %s)\n", fileID)
+
+			continue
+		}
+		delete(u.animationsNewCaptions, fileID)
+		delete(u.tagChanges, fileID)
+		delete(u.changeOrder, fileID)
+		delete(u.pendingChannels, fileID)
+	}
 
-	u.storage.AddSentAnimations(u.animationsNewCaptions)
+	if len(u.animationsNewCaptions) > 0 {
+		u.storage.AddSentAnimations(u.animationsNewCaptions)
+	}
+	if len(u.tagChanges) > 0 {
+		u.storage.AddTagChanges(u.sortedTagChanges()...)
+	}
 	// добавим в уже отправленные, а список новых сбросим
 	for k, v := range u.animationsNewCaptions {
 		u.sentAnimations[k] = v
+		u.index.Add(v)
 		u.addTagsToList(v.Tags)
 	}
+	if len(u.animationsNewCaptions) > 0 {
+		// поменялось количество гифок у тегов
+		u.hasTagsListChanges = true
+	}
 	u.animationsNewCaptions = make(map[string]*storage.SentAnimation)
-}
+	u.tagChanges = make(map[string]*storage.TagChange)
+	u.changeOrder = make(map[string]int)
+	u.pendingChannels = make(map[string][]int64)
+	u.pendingUpdates = make(map[string][]int)
 
-func (u *UpdatesHandler) sendAnimation(msg *storage.SentAnimation, wg *sync.WaitGroup) {
-	defer wg.Done()
+	if len(failed) > 0 {
+		return fmt.Errorf("не отправлено гифок: %d", len(failed))
+	}
 
-	var err error
-	caption := strings.Join(msg.Tags, " ")
-	tryToSendNew := false
+	return nil
+}
 
-	if msg.MessageID != 0 {
-		// если ранее отправляли, то отредактируем сообщение
-		log.Printf("Теги отредактированы '%s' (fileID: %s)\n", caption, msg.FileID)
+// pendingInOrder очередь на отправку по времени изменения тегов
+func (u *UpdatesHandler) pendingInOrder() []*storage.SentAnimation {
+	pending := make([]*storage.SentAnimation, 0, len(u.animationsNewCaptions))
+	seen := make(map[string]bool, len(u.animationsNewCaptions))
 
-		err = u.api.EditMessage(u.conf.ChannelID, msg.MessageID, caption)
-		if err != nil && strings.Contains(err.Error(), "message to edit not found") {
-			// сообщение из канала было удалено
-			err = nil
-			tryToSendNew = true
+	for _, change := range u.sortedTagChanges() {
+		if msg, ok := u.animationsNewCaptions[change.FileID]; ok {
+			pending = append(pending, msg)
+			seen[change.FileID] = true
 		}
-	} else {
-		tryToSendNew = true
 	}
 
-	if tryToSendNew {
-		log.Printf("Новая гифка '%s' (fileID: %s)\n", caption, msg.FileID)
+	// без истории изменений в очередь не попадают, но на всякий случай отправим и такие
+	var rest []string
+	for fileID := range u.animationsNewCaptions {
+		if !seen[fileID] {
+			rest = append(rest, fileID)
+		}
+	}
+	sort.Strings(rest)
+	for _, fileID := range rest {
+		pending = append(pending, u.animationsNewCaptions[fileID])
+	}
+
+	return pending
+}
 
-		msg.MessageID, err = u.api.SendAnimation(u.conf.ChannelID, msg.FileID, caption)
+// sortedTagChanges изменения в порядке времени, а в одну секунду в порядке поступления,
+// чтобы история не зависела от обхода мапы
+func (u *UpdatesHandler) sortedTagChanges() []*storage.TagChange {
+	changes := make([]*storage.TagChange, 0, len(u.tagChanges))
+	for _, change := range u.tagChanges {
+		changes = append(changes, change)
 	}
 
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ChangedAt.Equal(changes[j].ChangedAt) {
+			return u.changeOrder[changes[i].FileID] < u.changeOrder[changes[j].FileID]
+		}
+
+		return changes[i].ChangedAt.Before(changes[j].ChangedAt)
+	})
+
+	return changes
+}
+
+// sendAnimation отправит или отредактирует гифку во всех ее каналах и удалит из каналов,
+// куда она больше не должна попадать
+func (u *UpdatesHandler) sendAnimation(msg *storage.SentAnimation) error {
+	tags := strings.Join(msg.Tags, " ")
+	posted := u.postedChannels(msg)
+
+	caption, err := u.captionFormat.format(msg)
 	if err != nil {
-		u.sendMeError(fmt.Errorf("отправка гифки '%s': %w", caption, err))
+		return fmt.Errorf("подпись гифки '%s': %w", tags, err)
+	}
+
+	targets, ok := u.pendingChannels[msg.FileID]
+	if !ok {
+		targets = u.targetChannels(msg.FileID, msg.Tags, nil, nil)
+	}
+
+	for _, chatID := range targets {
+		messageID := u.messageIn(msg, chatID)
+		if messageID != 0 {
+			log.Printf("Теги отредактированы '%s' (fileID: %s, канал: %d)\n", tags, msg.FileID, chatID)
+		} else {
+			log.Printf("Новая гифка '%s' (fileID: %s, канал: %d)\n", tags, msg.FileID, chatID)
+		}
+
+		newID, err := u.publishTo(chatID, messageID, msg.FileID, caption)
+		if err != nil {
+			return fmt.Errorf("отправка гифки '%s' в канал %d: %w", tags, chatID, err)
+		}
+		u.setMessageIn(msg, chatID, newID)
 	}
+
+	for _, chatID := range posted {
+		if containsChannel(targets, chatID) {
+			continue
+		}
+
+		log.Printf("Гифка убрана из канала %d (fileID: %s)\n", chatID, msg.FileID)
+
+		if err := u.unpublishFrom(chatID, u.messageIn(msg, chatID)); err != nil {
+			return fmt.Errorf("удаление гифки '%s' из канала %d: %w", tags, chatID, err)
+		}
+		u.setMessageIn(msg, chatID, 0)
+	}
+
+	return nil
 }
 
 func (u *UpdatesHandler) captionsIsEqual(tagsA, tagsB []string) bool {
@@ -253,42 +570,35 @@ func (u *UpdatesHandler) captionsIsEqual(tagsA, tagsB []string) bool {
 	return true
 }
 
+// UpdateTagsList обновит список тегов в канале. Список хранится в своих сообщениях, их id лежат в хранилище,
+// закрепленное сообщение канала бот не трогает, если оно не из списка
 func (u *UpdatesHandler) UpdateTagsList() error {
 	if !u.hasTagsListChanges {
 		return nil
 	}
 
-	text := u.createTagsList()
-
-	msgID, err := u.api.GetChatPinnedMessageID(u.conf.ChannelID)
+	text, err := u.createTagsList()
 	if err != nil {
-		return fmt.Errorf("получение закрепленного сообщения чата: %w", err)
+		return err
 	}
+	parts := splitTagsIndex(text, tagsIndexMessageLimit)
 
-	if msgID != 0 {
-		err := u.api.EditMessage(u.conf.ChannelID, msgID, text)
-		if err != nil {
-			return fmt.Errorf("редактирование списка тегов: %w", err)
-		}
-	} else {
-		// нет запиненного сообщения, создадим новое и запиним
-		newID, err := u.api.SendMessage(u.conf.ChannelID, text)
-		if err != nil {
-			return fmt.Errorf("отправка списка тегов: %w", err)
-		}
-		if err := u.api.PinMessage(u.conf.ChannelID, newID); err != nil {
-			return fmt.Errorf("пин сообщения #%d: %w", newID, err)
-		}
+	index, err := u.syncTagsIndex(u.storage.GetTagsIndex(), parts, u.tagsFormat.parseMode)
+	// сохраним даже при ошибке, чтобы не потерять уже отправленные сообщения
+	u.storage.SetTagsIndex(index)
+	if err != nil {
+		return err
 	}
 
 	u.hasTagsListChanges = false
 
-	log.Printf("Обновил список тегов:\n%s\n", text)
+	log.Printf("Обновил список тегов, сообщений %d:\n%s\n", len(index), text)
 
 	return nil
 }
 
-func (u *UpdatesHandler) createTagsList() string {
+// createTagsList текст списка тегов по шаблону из конфига
+func (u *UpdatesHandler) createTagsList() (string, error) {
 	list := make([]string, 0, len(u.uniqueTags))
 	for tag := range u.uniqueTags {
 		list = append(list, tag)
@@ -298,82 +608,88 @@ func (u *UpdatesHandler) createTagsList() string {
 
 	u.storage.SetTags(list)
 
-	return strings.Join(list, "\n")
+	return u.tagsFormat.format(list, u.index.Count)
 }
 
-// parseTags спарист теги из текста
-func (u *UpdatesHandler) parseTags(text string) []string {
-	words := strings.Split(text, " ")
-	var tags []string
-	var tag string
-	var sep string
-	waitEnd := false
+// parseCaption вырежет из подписи @каналы и разберет теги и описание. Подпись только из @каналов
+// переносит гифку с ее текущими тегами. Подпись, которая не влезет в лимит телеги, тоже ошибка
+func (u *UpdatesHandler) parseCaption(fileID, text string) (
+	tags []string,
+	description string,
+	channels []int64,
+	err error,
+) {
+	channels, text, err = u.channels.extract(text)
+	if err != nil {
+		return nil, "", nil, err
+	}
 
-	for _, word := range words {
-		if word == "" {
-			continue
+	if len(channels) > 0 && strings.TrimSpace(text) == "" {
+		tags, description = u.currentCaption(fileID)
+		if len(tags) == 0 {
+			return nil, "", nil, errors.New("у гифки еще нет тегов, напиши их вместе с каналом")
+		}
+	} else {
+		tags, description, err = u.parseTags(fileID, text)
+		if err != nil {
+			return nil, "", nil, err
 		}
+	}
 
-		if waitEnd {
-			if len(word) < 2 {
-				tag += sep + word
-				continue
-			}
-			last2 := word[len(word)-2:]
-			if last2 == "00" || last2 == "11" {
-				word = word[:len(word)-2]
-				if word != "" {
-					tag += sep + word
-				}
-				tags = append(tags, tag)
-				tag = ""
-				waitEnd = false
-			} else {
-				tag += sep + word
-			}
+	if _, err := u.captionFormat.format(&storage.SentAnimation{Tags: tags, Description: description}); err != nil {
+		return nil, "", nil, err
+	}
 
-			continue
-		}
+	return tags, description, channels, nil
+}
 
-		if len(word) < 2 {
-			tags = append(tags, "#"+word)
-			continue
-		}
+// parseTags разберет подпись и вернет новые теги и описание гифки fileID. Правки +#тег/-#тег применяются
+// к тегам, которые ждут отправки или уже отправлены
+func (u *UpdatesHandler) parseTags(fileID, text string) ([]string, string, error) {
+	c, err := caption.Parse(text)
+	if err != nil {
+		return nil, "", err
+	}
 
-		first2 := word[:2]
-		if first2 == "00" {
-			tag = word[2:]
-			sep = " "
-			waitEnd = true
-		} else if first2 == "11" {
-			tag = "#" + word[2:]
-			sep = "_"
-			waitEnd = true
-		} else {
-			tags = append(tags, "#"+word)
+	c.Tags = u.replaceAliases(u.normalizer.Tags(c.Tags))
+	c.Add = u.replaceAliases(u.normalizer.Tags(c.Add))
+	c.Remove = u.replaceAliases(u.normalizer.Tags(c.Remove))
 
-			continue
-		}
+	tags, description := c.Apply(u.currentCaption(fileID))
+	if len(tags) == 0 {
+		return nil, "", errors.New("у гифки не останется тегов")
+	}
 
-		// однако если конец этого же слова такой же, то просто обрежем и запишем
-		// например случай не тега в одно слово 00описание00
-		if waitEnd && first2 == tag[len(tag)-2:] {
-			tags = append(tags, tag[:len(tag)-2])
-			waitEnd = false
-			tag = ""
-		}
+	return tags, description, nil
+}
+
+// currentCaption теги и описание гифки, которая ждет отправки или уже отправлена
+func (u *UpdatesHandler) currentCaption(fileID string) ([]string, string) {
+	if pending, ok := u.animationsNewCaptions[fileID]; ok {
+		return animationCaption(pending)
 	}
+	if sent, ok := u.sentAnimations[fileID]; ok {
+		return animationCaption(sent)
+	}
+
+	return nil, ""
+}
 
-	if tag != "" {
-		tags = append(tags, tag)
+// animationCaption теги и описание гифки. У гифок, которые не прошли миграцию, описание лежит в тегах
+func animationCaption(anim *storage.SentAnimation) ([]string, string) {
+	if anim.Description != "" {
+		return anim.Tags, anim.Description
 	}
 
-	// заменим алиасы
+	return caption.SplitDescription(anim.Tags)
+}
+
+// replaceAliases заменит алиасы на теги
+func (u *UpdatesHandler) replaceAliases(tags []string) []string {
 	for i, tag := range tags {
 		if alias, ok := u.tagsAliases[tag]; ok {
 			tags[i] = alias
 		}
-		// Убрать отюда нахер		u.addTagToList(tags[i])
 	}
 
 	return tags
@@ -399,5 +715,3 @@ func (u *UpdatesHandler) sendMeError(err error) {
 		log.Println("send alert error:", err)
 	}
 }
-
-type updateHandler func(update tgbotapi.Update) (bool, error)
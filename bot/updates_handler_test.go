@@ -2,12 +2,15 @@ package bot
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/gojuno/minimock/v3"
 
+	"github.com/cyhalothrin/gifkoskladbot/api"
 	"github.com/cyhalothrin/gifkoskladbot/config"
 	"github.com/cyhalothrin/gifkoskladbot/storage"
 )
@@ -22,10 +25,13 @@ func TestBot_parseTags(t *testing.T) {
 		store GifkoskladMetaStorage
 	}
 	type args struct {
-		text string
+		fileID string
+		text   string
 	}
 	type want struct {
-		tags []string
+		tags        []string
+		description string
+		err         bool
 	}
 	tests := []struct {
 		name   string
@@ -38,6 +44,8 @@ func TestBot_parseTags(t *testing.T) {
 			fields{
 				store: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return(nil),
 			},
@@ -45,7 +53,8 @@ func TestBot_parseTags(t *testing.T) {
 				text: "tag1 f  1tag 11tag with  space11 00just description i 00",
 			},
 			want{
-				[]string{"#tag1", "#f", "#1tag", "#tag_with_space", "just description i"},
+				tags:        []string{"#tag1", "#f", "#1tag", "#tag_with_space"},
+				description: "just description i",
 			},
 		},
 		{
@@ -53,6 +62,8 @@ func TestBot_parseTags(t *testing.T) {
 			fields{
 				store: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return(nil),
 			},
@@ -60,7 +71,8 @@ func TestBot_parseTags(t *testing.T) {
 				text: "11like a boss11 00description00",
 			},
 			want{
-				[]string{"#like_a_boss", "description"},
+				tags:        []string{"#like_a_boss"},
+				description: "description",
 			},
 		},
 		{
@@ -68,6 +80,8 @@ func TestBot_parseTags(t *testing.T) {
 			fields{
 				store: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(map[string]string{"#lab": "#like_a_boss"}).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return([]string{"#like_a_boss", "#existing_tag"}),
 			},
@@ -75,17 +89,115 @@ func TestBot_parseTags(t *testing.T) {
 				text: "lab 11existing tag11 00description00",
 			},
 			want{
-				[]string{"#like_a_boss", "#existing_tag", "description"},
+				tags:        []string{"#like_a_boss", "#existing_tag"},
+				description: "description",
 			},
 		},
+		{
+			"should normalize tags",
+			fields{
+				store: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(nil).
+					GetTagsMock.Return(nil),
+			},
+			args{
+				text: "#кот! #cat, #cat️ кот",
+			},
+			want{
+				tags:        []string{"#кот", "#cat"},
+				description: "кот",
+			},
+		},
+		{
+			"should parse caption grammar",
+			fields{
+				store: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(map[string]string{"#lab": "#like_a_boss"}).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(nil).
+					GetTagsMock.Return(nil),
+			},
+			args{
+				text: `#lab "кот босс" кот в очках`,
+			},
+			want{
+				tags:        []string{"#like_a_boss", "#кот_босс"},
+				description: "кот в очках",
+			},
+		},
+		{
+			"should edit tags of sent animation",
+			fields{
+				store: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(map[string]string{"#lab": "#like_a_boss"}).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(map[string]*storage.SentAnimation{
+					"file_1": {FileID: "file_1", MessageID: 1, Tags: []string{"#cat", "#dog", "description"}},
+				}).
+					GetTagsMock.Return(nil),
+			},
+			args{
+				fileID: "file_1",
+				text:   "-#dog +#lab",
+			},
+			want{
+				tags:        []string{"#cat", "#like_a_boss"},
+				description: "description",
+			},
+		},
+		{
+			"should fail when no tags left",
+			fields{
+				store: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(map[string]*storage.SentAnimation{
+					"file_1": {FileID: "file_1", MessageID: 1, Tags: []string{"#cat"}},
+				}).
+					GetTagsMock.Return(nil),
+			},
+			args{
+				fileID: "file_1",
+				text:   "-#cat",
+			},
+			want{err: true},
+		},
+		{
+			"should fail on syntax error",
+			fields{
+				store: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(nil).
+					GetTagsMock.Return(nil),
+			},
+			args{
+				text: `#cat "like a boss`,
+			},
+			want{err: true},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
 			b := NewUpdatesHandler(config.Config{}, tt.fields.store, NewAlerterMock(mc), NewTelegramBotAPIMock(mc))
-			if got := b.parseTags(tt.args.text); !reflect.DeepEqual(got, tt.want.tags) {
+			got, description, err := b.parseTags(tt.args.fileID, tt.args.text)
+			if (err != nil) != tt.want.err {
+				t.Errorf("parseTags() error = %v, wantErr %v", err, tt.want.err)
+			}
+			if !reflect.DeepEqual(got, tt.want.tags) {
 				t.Errorf("parseTags() = %v, want %v", got, tt.want.tags)
 			}
+			if description != tt.want.description {
+				t.Errorf("parseTags() description = %q, want %q", description, tt.want.description)
+			}
 		})
 	}
 }
@@ -145,6 +257,8 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 
 	emptyStorage := NewGifkoskladMetaStorageMock(mc).
 		GetTagsAliasesMock.Return(nil).
+		GetUserRolesMock.Return(nil).
+		GetPendingTyposMock.Return(nil).
 		GetSentAnimationsMock.Return(nil).
 		GetTagsMock.Return(nil)
 	conf := config.Config{
@@ -154,6 +268,7 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 	type fields struct {
 		storage GifkoskladMetaStorage
 		config  config.Config
+		api     telegramBotAPI
 	}
 	type args struct {
 		update tgbotapi.Update
@@ -165,6 +280,7 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 		want                      bool
 		wantErr                   bool
 		wantAnimationsNewCaptions map[string]*storage.SentAnimation
+		wantTagChanges            map[string]*storage.TagChange
 	}{
 		{
 			"handler should return false, coz update not has message",
@@ -175,25 +291,7 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			false,
 			false,
 			make(map[string]*storage.SentAnimation),
-		},
-		{
-			"should be rejected due not allowed user",
-			fields{
-				storage: emptyStorage,
-				config:  conf,
-			},
-			args{
-				update: tgbotapi.Update{
-					Message: &tgbotapi.Message{
-						From: &tgbotapi.User{
-							UserName: "not_cyhalothrin",
-						},
-					},
-				},
-			},
-			false,
-			false,
-			make(map[string]*storage.SentAnimation),
+			make(map[string]*storage.TagChange),
 		},
 		{
 			"not reply message should be skipped",
@@ -213,6 +311,7 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			false,
 			false,
 			make(map[string]*storage.SentAnimation),
+			make(map[string]*storage.TagChange),
 		},
 		{
 			"without animation message should be skipped",
@@ -233,6 +332,7 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			false,
 			false,
 			make(map[string]*storage.SentAnimation),
+			make(map[string]*storage.TagChange),
 		},
 		{
 			"without text message should be skipped",
@@ -255,6 +355,7 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			false,
 			false,
 			make(map[string]*storage.SentAnimation),
+			make(map[string]*storage.TagChange),
 		},
 		{
 			"should add new caption",
@@ -266,8 +367,10 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 				update: tgbotapi.Update{
 					Message: &tgbotapi.Message{
 						From: &tgbotapi.User{
+							ID:       1,
 							UserName: "cyhalothrin",
 						},
+						Date: 100,
 						ReplyToMessage: &tgbotapi.Message{
 							Animation: &tgbotapi.ChatAnimation{
 								FileID: "animation_file_id_1",
@@ -281,8 +384,19 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			false,
 			map[string]*storage.SentAnimation{
 				"animation_file_id_1": {
-					FileID: "animation_file_id_1",
-					Tags:   []string{"#tag1", "#tag2", "#tag_tree", "not a tag"},
+					FileID:      "animation_file_id_1",
+					Tags:        []string{"#tag1", "#tag2", "#tag_tree"},
+					Description: "not a tag",
+				},
+			},
+			map[string]*storage.TagChange{
+				"animation_file_id_1": {
+					FileID:         "animation_file_id_1",
+					ChangedAt:      time.Unix(100, 0).UTC(),
+					UserID:         1,
+					UserName:       "cyhalothrin",
+					NewTags:        []string{"#tag1", "#tag2", "#tag_tree"},
+					NewDescription: "not a tag",
 				},
 			},
 		},
@@ -291,6 +405,8 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			fields{
 				storage: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(map[string]*storage.SentAnimation{
 					"animation_file_id_1": {
 						MessageID: 101,
@@ -305,8 +421,10 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 				update: tgbotapi.Update{
 					Message: &tgbotapi.Message{
 						From: &tgbotapi.User{
+							ID:       1,
 							UserName: "cyhalothrin",
 						},
+						Date: 100,
 						ReplyToMessage: &tgbotapi.Message{
 							Animation: &tgbotapi.ChatAnimation{
 								FileID: "animation_file_id_1",
@@ -320,9 +438,22 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			false,
 			map[string]*storage.SentAnimation{
 				"animation_file_id_1": {
-					MessageID: 101,
-					FileID:    "animation_file_id_1",
-					Tags:      []string{"#tag1", "#tag2", "#tag_tree", "not a tag"},
+					MessageID:   101,
+					FileID:      "animation_file_id_1",
+					Tags:        []string{"#tag1", "#tag2", "#tag_tree"},
+					Description: "not a tag",
+				},
+			},
+			map[string]*storage.TagChange{
+				"animation_file_id_1": {
+					FileID:         "animation_file_id_1",
+					ChangedAt:      time.Unix(100, 0).UTC(),
+					UserID:         1,
+					UserName:       "cyhalothrin",
+					OldTags:        []string{"#tag1", "#tag2"},
+					OldDescription: "not a tag",
+					NewTags:        []string{"#tag1", "#tag2", "#tag_tree"},
+					NewDescription: "not a tag",
 				},
 			},
 		},
@@ -331,6 +462,8 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			fields{
 				storage: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(map[string]*storage.SentAnimation{
 					"animation_file_id_1": {
 						MessageID: 101,
@@ -345,8 +478,10 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 				update: tgbotapi.Update{
 					Message: &tgbotapi.Message{
 						From: &tgbotapi.User{
+							ID:       1,
 							UserName: "cyhalothrin",
 						},
+						Date: 100,
 						ReplyToMessage: &tgbotapi.Message{
 							Animation: &tgbotapi.ChatAnimation{
 								FileID: "animation_file_id_1",
@@ -359,15 +494,54 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			true,
 			false,
 			map[string]*storage.SentAnimation{},
+			map[string]*storage.TagChange{},
+		},
+		{
+			"should reply on caption syntax error",
+			fields{
+				storage: emptyStorage,
+				config:  conf,
+				api: NewTelegramBotAPIMock(mc).
+					SendReplyMock.
+					Expect(200, 5, "Не понял подпись: символ 6: не закрыта кавычка\n\n"+captionUsage).
+					Return(6, nil),
+			},
+			args{
+				update: tgbotapi.Update{
+					Message: &tgbotapi.Message{
+						MessageID: 5,
+						From: &tgbotapi.User{
+							ID:       1,
+							UserName: "cyhalothrin",
+						},
+						Chat: &tgbotapi.Chat{ID: 200},
+						Date: 100,
+						ReplyToMessage: &tgbotapi.Message{
+							Animation: &tgbotapi.ChatAnimation{
+								FileID: "animation_file_id_1",
+							},
+						},
+						Text: `#cat "like a boss`,
+					},
+				},
+			},
+			true,
+			false,
+			make(map[string]*storage.SentAnimation),
+			make(map[string]*storage.TagChange),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tgAPI := tt.fields.api
+			if tgAPI == nil {
+				tgAPI = NewTelegramBotAPIMock(mc)
+			}
 			u := NewUpdatesHandler(
 				tt.fields.config,
 				tt.fields.storage,
 				NewAlerterMock(mc),
-				NewTelegramBotAPIMock(mc),
+				tgAPI,
 			)
 
 			got, err := u.handleAnimationCaption(tt.args.update)
@@ -381,6 +555,9 @@ func TestUpdatesHandler_handleAnimationCaption(t *testing.T) {
 			if !reflect.DeepEqual(u.animationsNewCaptions, tt.wantAnimationsNewCaptions) {
 				t.Errorf("animationsNewCaptions = %v, want %v", u.animationsNewCaptions, tt.wantAnimationsNewCaptions)
 			}
+			if !reflect.DeepEqual(u.tagChanges, tt.wantTagChanges) {
+				t.Errorf("tagChanges = %v, want %v", u.tagChanges, tt.wantTagChanges)
+			}
 		})
 	}
 }
@@ -401,6 +578,7 @@ func TestUpdatesHandler_publishAnimations(t *testing.T) {
 	}
 	type args struct {
 		animationsNewCaptions map[string]*storage.SentAnimation
+		tagChanges            map[string]*storage.TagChange
 	}
 	tests := []struct {
 		name                   string
@@ -408,19 +586,22 @@ func TestUpdatesHandler_publishAnimations(t *testing.T) {
 		args                   args
 		wantUniqueTags         map[string]bool
 		wantHasTagsListChanges bool
+		wantErr                bool
 	}{
 		{
 			"should send animations",
 			fields{
 				api: NewTelegramBotAPIMock(mc).
-					EditMessageMock.
-					Expect(conf.ChannelID, 10, "#tag3 #tag4 description").
+					EditCaptionMock.
+					Expect(conf.ChannelID, 10, "#tag3 #tag4 description", "").
 					Return(nil).
 					SendAnimationMock.
-					Expect(conf.ChannelID, "new_file_id", "#tag1 #tag2 description").
+					Expect(conf.ChannelID, "new_file_id", "#tag1 #tag2 description", "").
 					Return(20, nil),
 				storage: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return(nil).
 					AddSentAnimationsMock.
@@ -436,6 +617,12 @@ func TestUpdatesHandler_publishAnimations(t *testing.T) {
 							Tags:      []string{"#tag3", "#tag4", "description"},
 						},
 					}).
+					Return().
+					AddTagChangesMock.
+					Expect(
+						&storage.TagChange{FileID: "old_file_id", ChangedAt: time.Unix(100, 0), NewTags: []string{"#tag3"}},
+						&storage.TagChange{FileID: "new_file_id", ChangedAt: time.Unix(200, 0), NewTags: []string{"#tag1"}},
+					).
 					Return(),
 			},
 			args{
@@ -451,22 +638,29 @@ func TestUpdatesHandler_publishAnimations(t *testing.T) {
 						Tags:      []string{"#tag3", "#tag4", "description"},
 					},
 				},
+				tagChanges: map[string]*storage.TagChange{
+					"new_file_id": {FileID: "new_file_id", ChangedAt: time.Unix(200, 0), NewTags: []string{"#tag1"}},
+					"old_file_id": {FileID: "old_file_id", ChangedAt: time.Unix(100, 0), NewTags: []string{"#tag3"}},
+				},
 			},
 			map[string]bool{"#tag1": true, "#tag2": true, "#tag3": true, "#tag4": true},
 			true,
+			false,
 		},
 		{
 			"should handle 'message to edit not found' error and send new message",
 			fields{
 				api: NewTelegramBotAPIMock(mc).
-					EditMessageMock.
-					Expect(conf.ChannelID, 10, "#tag1 #tag2 description").
-					Return(errors.New("send edited message: Bad Request: message to edit not found")).
+					EditCaptionMock.
+					Expect(conf.ChannelID, 10, "#tag1 #tag2 description", "").
+					Return(fmt.Errorf("send edited message: %w", api.ErrNotFound)).
 					SendAnimationMock.
-					Expect(conf.ChannelID, "old_file_id", "#tag1 #tag2 description").
+					Expect(conf.ChannelID, "old_file_id", "#tag1 #tag2 description", "").
 					Return(20, nil),
 				storage: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return([]string{"#tag1", "#tag2", "#tag3"}).
 					AddSentAnimationsMock.
@@ -489,15 +683,65 @@ func TestUpdatesHandler_publishAnimations(t *testing.T) {
 				},
 			},
 			map[string]bool{"#tag1": true, "#tag2": true, "#tag3": true},
+			// новых тегов нет, но у тегов поменялось количество гифок
+			true,
 			false,
 		},
+		{
+			"should not save animations that were not sent",
+			fields{
+				api: NewTelegramBotAPIMock(mc).
+					EditCaptionMock.
+					Expect(conf.ChannelID, 10, "#tag3", "").
+					Return(errors.New("send edited message: Too Many Requests")).
+					SendAnimationMock.
+					Expect(conf.ChannelID, "new_file_id", "#tag1", "").
+					Return(20, nil),
+				storage: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(nil).
+					GetTagsMock.Return(nil).
+					AddSentAnimationsMock.
+					Expect(map[string]*storage.SentAnimation{
+						"new_file_id": {MessageID: 20, FileID: "new_file_id", Tags: []string{"#tag1"}},
+					}).
+					Return().
+					AddTagChangesMock.
+					Expect(&storage.TagChange{FileID: "new_file_id", ChangedAt: time.Unix(200, 0), NewTags: []string{"#tag1"}}).
+					Return(),
+			},
+			args{
+				animationsNewCaptions: map[string]*storage.SentAnimation{
+					"new_file_id": {FileID: "new_file_id", Tags: []string{"#tag1"}},
+					"old_file_id": {MessageID: 10, FileID: "old_file_id", Tags: []string{"#tag3"}},
+				},
+				tagChanges: map[string]*storage.TagChange{
+					"new_file_id": {FileID: "new_file_id", ChangedAt: time.Unix(200, 0), NewTags: []string{"#tag1"}},
+					"old_file_id": {FileID: "old_file_id", ChangedAt: time.Unix(100, 0), NewTags: []string{"#tag3"}},
+				},
+			},
+			map[string]bool{"#tag1": true},
+			true,
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			u := NewUpdatesHandler(conf, tt.fields.storage, NewAlerterMock(mc), tt.fields.api)
+			alert := NewAlerterMock(mc)
+			if tt.wantErr {
+				alert.SendMock.Return(nil)
+			}
+			u := NewUpdatesHandler(conf, tt.fields.storage, alert, tt.fields.api)
 			u.animationsNewCaptions = tt.args.animationsNewCaptions
+			if tt.args.tagChanges != nil {
+				u.tagChanges = tt.args.tagChanges
+			}
 
-			u.PublishAnimations()
+			if err := u.PublishAnimations(); (err != nil) != tt.wantErr {
+				t.Errorf("PublishAnimations() error = %v, wantErr %v", err, tt.wantErr)
+			}
 
 			if !reflect.DeepEqual(u.uniqueTags, tt.wantUniqueTags) {
 				t.Errorf("uniqueTags = %v, want %v", u.uniqueTags, tt.wantUniqueTags)
@@ -521,7 +765,7 @@ func TestUpdatesHandler_updateTagsList(t *testing.T) {
 	}
 	tagsMap := map[string]bool{"#tag3": true, "#tag1": true, "#tag2": true}
 	tagsList := []string{"#tag1", "#tag2", "#tag3"}
-	tagsText := "#tag1\n#tag2\n#tag3"
+	tagsText := "#tag1 (0)\n#tag2 (0)\n#tag3 (0)"
 
 	type fields struct {
 		api     telegramBotAPI
@@ -546,18 +790,21 @@ func TestUpdatesHandler_updateTagsList(t *testing.T) {
 			fields{
 				storage: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return(nil).
-					SetTagsMock.Expect(tagsList).Return(),
+					SetTagsMock.Expect(tagsList).Return().
+					GetTagsIndexMock.Return(nil).
+					SetTagsIndexMock.Expect([]*storage.TagsIndexMessage{{MessageID: 100, Text: tagsText}}).Return(),
 				api: NewTelegramBotAPIMock(mc).
-					GetChatPinnedMessageIDMock.Expect(conf.ChannelID).Return(0, nil).
-					SendMessageMock.Expect(conf.ChannelID, tagsText).Return(100, nil).
+					SendMessageMock.Expect(conf.ChannelID, tagsText, "").Return(100, nil).
 					PinMessageMock.Expect(conf.ChannelID, 100).Return(nil),
 			},
 			false,
 		},
 		{
-			"should create tags list and update pinned message",
+			"should create tags list and update own message",
 			args{
 				uniqueTags:         tagsMap,
 				hasTagsListChanges: true,
@@ -565,15 +812,40 @@ func TestUpdatesHandler_updateTagsList(t *testing.T) {
 			fields{
 				storage: NewGifkoskladMetaStorageMock(mc).
 					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
 					GetSentAnimationsMock.Return(nil).
 					GetTagsMock.Return(nil).
-					SetTagsMock.Expect(tagsList).Return(),
+					SetTagsMock.Expect(tagsList).Return().
+					GetTagsIndexMock.Return([]*storage.TagsIndexMessage{{MessageID: 10, Text: "#tag1"}}).
+					SetTagsIndexMock.Expect([]*storage.TagsIndexMessage{{MessageID: 10, Text: tagsText}}).Return(),
 				api: NewTelegramBotAPIMock(mc).
-					GetChatPinnedMessageIDMock.Expect(conf.ChannelID).Return(10, nil).
-					EditMessageMock.Expect(conf.ChannelID, 10, tagsText).Return(nil),
+					EditMessageMock.Expect(conf.ChannelID, 10, tagsText, "").Return(nil),
 			},
 			false,
 		},
+		{
+			"should keep sent messages on error",
+			args{
+				uniqueTags:         tagsMap,
+				hasTagsListChanges: true,
+			},
+			fields{
+				storage: NewGifkoskladMetaStorageMock(mc).
+					GetTagsAliasesMock.Return(nil).
+					GetUserRolesMock.Return(nil).
+					GetPendingTyposMock.Return(nil).
+					GetSentAnimationsMock.Return(nil).
+					GetTagsMock.Return(nil).
+					SetTagsMock.Expect(tagsList).Return().
+					GetTagsIndexMock.Return(nil).
+					SetTagsIndexMock.Expect([]*storage.TagsIndexMessage{{MessageID: 100, Text: tagsText}}).Return(),
+				api: NewTelegramBotAPIMock(mc).
+					SendMessageMock.Expect(conf.ChannelID, tagsText, "").Return(100, nil).
+					PinMessageMock.Expect(conf.ChannelID, 100).Return(api.ErrForbidden),
+			},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -590,3 +862,34 @@ func TestUpdatesHandler_updateTagsList(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatesHandler_pendingInOrder(t *testing.T) {
+	t.Parallel()
+
+	u := &UpdatesHandler{
+		animationsNewCaptions: map[string]*storage.SentAnimation{
+			"file_1": {FileID: "file_1"},
+			"file_2": {FileID: "file_2"},
+			"file_3": {FileID: "file_3"},
+			"file_4": {FileID: "file_4"},
+			"file_5": {FileID: "file_5"},
+		},
+		tagChanges: map[string]*storage.TagChange{
+			"file_1": {FileID: "file_1", ChangedAt: time.Unix(300, 0)},
+			"file_3": {FileID: "file_3", ChangedAt: time.Unix(100, 0)},
+			"file_4": {FileID: "file_4", ChangedAt: time.Unix(200, 0)},
+			"file_5": {FileID: "file_5", ChangedAt: time.Unix(200, 0)},
+		},
+		// в одну секунду file_5 тегнули раньше
+		changeOrder: map[string]int{"file_1": 4, "file_3": 3, "file_4": 2, "file_5": 1},
+	}
+
+	var fileIDs []string
+	for _, msg := range u.pendingInOrder() {
+		fileIDs = append(fileIDs, msg.FileID)
+	}
+
+	if want := []string{"file_3", "file_5", "file_4", "file_1", "file_2"}; !reflect.DeepEqual(fileIDs, want) {
+		t.Errorf("pendingInOrder() = %v, want %v", fileIDs, want)
+	}
+}
@@ -0,0 +1,145 @@
+package caption
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	// tokenWord слово описания
+	tokenWord tokenKind = iota
+	// tokenTag #тег или #"тег из слов"
+	tokenTag
+	// tokenPhrase "тег из слов" в кавычках
+	tokenPhrase
+)
+
+type token struct {
+	kind tokenKind
+	// op '+' или '-' перед тегом, 0 если оператора нет
+	op   rune
+	text string
+	// pos номер символа, с которого начинается токен, с единицы
+	pos int
+}
+
+// closingQuotes какая кавычка закрывает открывающую
+var closingQuotes = map[rune]rune{
+	'"': '"',
+	'«': '»',
+	'“': '”',
+}
+
+type lexer struct {
+	text []rune
+	pos  int
+}
+
+// tokenize разбивает подпись на слова, теги и фразы в кавычках
+func tokenize(text string) ([]token, error) {
+	l := &lexer{text: []rune(text)}
+
+	var tokens []token
+	for {
+		l.skipSpaces()
+		if l.pos >= len(l.text) {
+			return tokens, nil
+		}
+
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	start := l.pos
+	tok := token{pos: start + 1}
+
+	r := l.text[l.pos]
+	if (r == '+' || r == '-') && l.pos+1 < len(l.text) && l.startsTag(l.text[l.pos+1]) {
+		tok.op = r
+		l.pos++
+		r = l.text[l.pos]
+	}
+
+	switch {
+	case r == '#':
+		l.pos++
+		tok.kind = tokenTag
+		if l.pos < len(l.text) && isOpeningQuote(l.text[l.pos]) {
+			phrase, err := l.readQuoted()
+			if err != nil {
+				return tok, err
+			}
+			tok.text = phrase
+
+			return tok, nil
+		}
+
+		tok.text = l.readWhile(func(r rune) bool { return !unicode.IsSpace(r) && r != '#' })
+		if tok.text == "" {
+			return tok, &SyntaxError{Pos: tok.pos, Msg: "пустой тег"}
+		}
+	case isOpeningQuote(r):
+		phrase, err := l.readQuoted()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind = tokenPhrase
+		tok.text = phrase
+	default:
+		tok.kind = tokenWord
+		tok.text = l.readWhile(func(r rune) bool { return !unicode.IsSpace(r) })
+	}
+
+	return tok, nil
+}
+
+// readQuoted читает фразу в кавычках, l.pos стоит на открывающей
+func (l *lexer) readQuoted() (string, error) {
+	start := l.pos
+	closing := closingQuotes[l.text[l.pos]]
+	l.pos++
+
+	phrase := l.readWhile(func(r rune) bool { return r != closing })
+	if l.pos >= len(l.text) {
+		return "", &SyntaxError{Pos: start + 1, Msg: "не закрыта кавычка"}
+	}
+	l.pos++
+
+	phrase = strings.Join(strings.Fields(phrase), " ")
+	if phrase == "" {
+		return "", &SyntaxError{Pos: start + 1, Msg: "пустые кавычки"}
+	}
+
+	return phrase, nil
+}
+
+func (l *lexer) readWhile(ok func(r rune) bool) string {
+	start := l.pos
+	for l.pos < len(l.text) && ok(l.text[l.pos]) {
+		l.pos++
+	}
+
+	return string(l.text[start:l.pos])
+}
+
+func (l *lexer) skipSpaces() {
+	l.readWhile(unicode.IsSpace)
+}
+
+// startsTag с этого символа начинается тег, значит + или - перед ним оператор
+func (l *lexer) startsTag(r rune) bool {
+	return r == '#' || isOpeningQuote(r)
+}
+
+func isOpeningQuote(r rune) bool {
+	_, ok := closingQuotes[r]
+
+	return ok
+}
@@ -0,0 +1,244 @@
+// Package caption разбор подписи к гифке: теги, описание и правки тегов
+package caption
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// legacyDescription старая разметка описания: 00описание из слов00
+	legacyDescription = "00"
+	// legacyTag старая разметка тега из нескольких слов: 11тег из слов11
+	legacyTag = "11"
+)
+
+// ErrEmpty в подписи нет тегов, одного описания мало
+var ErrEmpty = errors.New("в подписи нет тегов")
+
+// SyntaxError ошибка в подписи, Pos номер символа с единицы
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("символ %d: %s", e.Pos, e.Msg)
+}
+
+// Caption разобранная подпись. Если есть Add или Remove, то это правка уже отправленных тегов,
+// Tags тогда пустой
+type Caption struct {
+	Tags        []string
+	Description string
+	Add         []string
+	Remove      []string
+}
+
+// IsEdit подпись правит теги, а не заменяет их
+func (c Caption) IsEdit() bool {
+	return len(c.Add) > 0 || len(c.Remove) > 0
+}
+
+// Apply теги и описание гифки после подписи. currentTags и currentDescription текущие теги
+// и описание гифки, нужны только для правок
+func (c Caption) Apply(currentTags []string, currentDescription string) ([]string, string) {
+	if !c.IsEdit() {
+		return append([]string(nil), c.Tags...), c.Description
+	}
+
+	removed := make(map[string]bool, len(c.Remove))
+	for _, tag := range c.Remove {
+		removed[tag] = true
+	}
+
+	var result []string
+	for _, tag := range currentTags {
+		if !removed[tag] {
+			result = appendUnique(result, tag)
+		}
+	}
+	for _, tag := range c.Add {
+		result = appendUnique(result, tag)
+	}
+
+	description := currentDescription
+	if c.Description != "" {
+		description = c.Description
+	}
+
+	return result, description
+}
+
+// SplitDescription разделит старый список тегов, где описание шло среди тегов, на теги и описание
+func SplitDescription(tags []string) ([]string, string) {
+	var hashtags, description []string
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, "#") {
+			hashtags = append(hashtags, tag)
+		} else if tag != "" {
+			description = append(description, tag)
+		}
+	}
+
+	return hashtags, strings.Join(description, " ")
+}
+
+// Parse разбирает подпись к гифке:
+//
+//	#cat #funny кот упал     - теги и описание из остальных слов
+//	"like a boss" #"кот босс" - тег из нескольких слов, станет #like_a_boss
+//	+#cat -#dog новое описание - добавить и убрать теги у уже отправленной гифки
+//
+// Если в подписи нет ни решеток, ни кавычек, или она начинается с маркера 00/11, то подпись разбирается по-старому:
+// каждое слово тег, 00описание00, 11тег из слов11.
+// Текст ожидается уже в нижнем регистре
+func Parse(text string) (Caption, error) {
+	if isLegacy(text) {
+		return parseLegacy(text)
+	}
+
+	tokens, err := tokenize(text)
+	if err != nil {
+		return Caption{}, err
+	}
+
+	var c Caption
+	var description []string
+	// plainTag и firstOp первые токены, чтобы показать, где смешаны теги и правки
+	var plainTag, firstOp *token
+
+	for i := range tokens {
+		tok := &tokens[i]
+		if tok.kind == tokenWord {
+			description = append(description, tok.text)
+
+			continue
+		}
+
+		tag := makeTag(tok.text)
+		switch tok.op {
+		case '+':
+			c.Add = appendUnique(c.Add, tag)
+		case '-':
+			c.Remove = appendUnique(c.Remove, tag)
+		default:
+			c.Tags = appendUnique(c.Tags, tag)
+		}
+
+		if tok.op == 0 && plainTag == nil {
+			plainTag = tok
+		}
+		if tok.op != 0 && firstOp == nil {
+			firstOp = tok
+		}
+	}
+
+	if plainTag != nil && firstOp != nil {
+		pos := firstOp.pos
+		if plainTag.pos > pos {
+			pos = plainTag.pos
+		}
+
+		return Caption{}, &SyntaxError{Pos: pos, Msg: "нельзя смешивать +#тег/-#тег с обычными тегами"}
+	}
+	for _, tag := range c.Add {
+		for _, removed := range c.Remove {
+			if tag == removed {
+				return Caption{}, &SyntaxError{Pos: firstOp.pos, Msg: fmt.Sprintf("тег %s и добавляется, и удаляется", tag)}
+			}
+		}
+	}
+
+	c.Description = strings.Join(description, " ")
+	if len(c.Tags) == 0 && !c.IsEdit() {
+		return Caption{}, ErrEmpty
+	}
+
+	return c, nil
+}
+
+// isLegacy подпись в старой разметке. Маркер проверяется только отдельным первым словом,
+// иначе описание вроде "#cat 11 котов" разбиралось бы по-старому
+func isLegacy(text string) bool {
+	words := strings.Fields(text)
+	if len(words) > 0 && (words[0] == legacyDescription || words[0] == legacyTag) {
+		return true
+	}
+
+	return !strings.ContainsAny(text, "#\"«“")
+}
+
+// parseLegacy старая разметка: каждое слово тег, 00описание из слов00, 11тег из слов11.
+// Маркер может стоять отдельным словом, незакрытый маркер закрывается в конце подписи
+func parseLegacy(text string) (Caption, error) {
+	var c Caption
+	var description []string
+	// open открытый маркер, пусто если вне маркеров
+	open := ""
+	var words []string
+
+	closeMarker := func() {
+		if len(words) > 0 {
+			if open == legacyDescription {
+				description = append(description, strings.Join(words, " "))
+			} else {
+				c.Tags = append(c.Tags, makeTag(strings.Join(words, " ")))
+			}
+		}
+		open = ""
+		words = nil
+	}
+
+	for _, word := range strings.Fields(text) {
+		if open == "" {
+			if !strings.HasPrefix(word, legacyDescription) && !strings.HasPrefix(word, legacyTag) {
+				c.Tags = append(c.Tags, makeTag(word))
+
+				continue
+			}
+
+			open, word = word[:2], word[2:]
+			if word == "" {
+				continue
+			}
+		}
+
+		if strings.HasSuffix(word, open) {
+			if word = strings.TrimSuffix(word, open); word != "" {
+				words = append(words, word)
+			}
+			closeMarker()
+
+			continue
+		}
+
+		words = append(words, word)
+	}
+	if open != "" {
+		closeMarker()
+	}
+
+	c.Description = strings.Join(description, " ")
+	if len(c.Tags) == 0 {
+		return Caption{}, ErrEmpty
+	}
+
+	return c, nil
+}
+
+// makeTag тег из текста без решетки, слова склеиваются через _
+func makeTag(text string) string {
+	return "#" + strings.Join(strings.Fields(strings.TrimPrefix(text, "#")), "_")
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+
+	return append(list, item)
+}
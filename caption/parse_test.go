@@ -0,0 +1,198 @@
+package caption
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		want    Caption
+		wantErr error
+	}{
+		{
+			"tags and description",
+			"#cat #funny кот упал",
+			Caption{Tags: []string{"#cat", "#funny"}, Description: "кот упал"},
+			nil,
+		},
+		{
+			"quoted multi-word tags",
+			`"like a  boss" #"кот босс" #cat`,
+			Caption{Tags: []string{"#like_a_boss", "#кот_босс", "#cat"}},
+			nil,
+		},
+		{
+			"russian quotes",
+			"«кот  босс» описание",
+			Caption{Tags: []string{"#кот_босс"}, Description: "описание"},
+			nil,
+		},
+		{
+			"tags without spaces and duplicates",
+			"#cat#dog #cat",
+			Caption{Tags: []string{"#cat", "#dog"}},
+			nil,
+		},
+		{
+			"edit operators",
+			`+#cat -#dog +"like a boss" новое описание`,
+			Caption{Add: []string{"#cat", "#like_a_boss"}, Remove: []string{"#dog"}, Description: "новое описание"},
+			nil,
+		},
+		{
+			"minus without tag is description",
+			"#cold -5 градусов",
+			Caption{Tags: []string{"#cold"}, Description: "-5 градусов"},
+			nil,
+		},
+		{
+			"legacy words are tags",
+			"tag1 f  1tag",
+			Caption{Tags: []string{"#tag1", "#f", "#1tag"}},
+			nil,
+		},
+		{
+			"legacy markers",
+			"tag1 11tag with  space11 00just description i 00",
+			Caption{Tags: []string{"#tag1", "#tag_with_space"}, Description: "just description i"},
+			nil,
+		},
+		{
+			"legacy lone markers",
+			"00 описание 00 11 like a boss 11 #cat",
+			Caption{Tags: []string{"#like_a_boss", "#cat"}, Description: "описание"},
+			nil,
+		},
+		{
+			"legacy lone marker without end",
+			"cat 00",
+			Caption{Tags: []string{"#cat"}},
+			nil,
+		},
+		{
+			"legacy unclosed marker",
+			"cat 00кот упал",
+			Caption{Tags: []string{"#cat"}, Description: "кот упал"},
+			nil,
+		},
+		{
+			"numbers like markers in description",
+			"#cat 11 котов 00:30",
+			Caption{Tags: []string{"#cat"}, Description: "11 котов 00:30"},
+			nil,
+		},
+		{
+			"words like markers after tag",
+			"#cat 11котов",
+			Caption{Tags: []string{"#cat"}, Description: "11котов"},
+			nil,
+		},
+		{"empty", "  ", Caption{}, ErrEmpty},
+		{"empty quoted tag", `#"" кот`, Caption{}, &SyntaxError{Pos: 2, Msg: "пустые кавычки"}},
+		{"only description", "00 кот 00", Caption{}, ErrEmpty},
+		{"unclosed quote", `#cat "like a boss`, Caption{}, &SyntaxError{Pos: 6, Msg: "не закрыта кавычка"}},
+		{"empty tag", "#cat # кот", Caption{}, &SyntaxError{Pos: 6, Msg: "пустой тег"}},
+		{
+			"tags mixed with operators",
+			"#cat +#dog",
+			Caption{},
+			&SyntaxError{Pos: 6, Msg: "нельзя смешивать +#тег/-#тег с обычными тегами"},
+		},
+		{
+			"same tag added and removed",
+			"+#cat -#cat",
+			Caption{},
+			&SyntaxError{Pos: 1, Msg: "тег #cat и добавляется, и удаляется"},
+		},
+		{"tag without description", "#cat", Caption{Tags: []string{"#cat"}}, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tt.text)
+			if tt.wantErr != nil {
+				var syntaxErr *SyntaxError
+				if errors.As(tt.wantErr, &syntaxErr) {
+					assert.Equal(t, tt.wantErr, err)
+				} else {
+					assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCaption_Apply(t *testing.T) {
+	t.Parallel()
+
+	currentTags := []string{"#cat", "#dog"}
+	currentDescription := "старое описание"
+
+	tests := []struct {
+		name            string
+		caption         Caption
+		wantTags        []string
+		wantDescription string
+	}{
+		{
+			"replace tags",
+			Caption{Tags: []string{"#fox"}, Description: "лиса"},
+			[]string{"#fox"},
+			"лиса",
+		},
+		{
+			"replace tags drops description",
+			Caption{Tags: []string{"#fox"}},
+			[]string{"#fox"},
+			"",
+		},
+		{
+			"add and remove keeps description",
+			Caption{Add: []string{"#funny", "#cat"}, Remove: []string{"#dog"}},
+			[]string{"#cat", "#funny"},
+			"старое описание",
+		},
+		{
+			"edit replaces description",
+			Caption{Remove: []string{"#cat"}, Description: "новое"},
+			[]string{"#dog"},
+			"новое",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags, description := tt.caption.Apply(currentTags, currentDescription)
+			assert.Equal(t, tt.wantTags, tags)
+			assert.Equal(t, tt.wantDescription, description)
+		})
+	}
+}
+
+func TestSplitDescription(t *testing.T) {
+	t.Parallel()
+
+	tags, description := SplitDescription([]string{"#cat", "кот упал", "#funny", "", "еще"})
+	assert.Equal(t, []string{"#cat", "#funny"}, tags)
+	assert.Equal(t, "кот упал еще", description)
+
+	tags, description = SplitDescription([]string{"#cat"})
+	assert.Equal(t, []string{"#cat"}, tags)
+	assert.Equal(t, "", description)
+}
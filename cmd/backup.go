@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Бэкапы файла базы данных",
+	Long: `Бэкапы файла базы данных.
+Бэкап делается автоматически при каждом открытии хранилища, если база изменилась с прошлого раза.`,
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Список бэкапов, сначала новые",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newBackupManager()
+		if err != nil {
+			return err
+		}
+
+		backups, err := manager.List()
+		if err != nil {
+			return err
+		}
+
+		if len(backups) == 0 {
+			fmt.Println("бэкапов нет")
+
+			return nil
+		}
+
+		for _, b := range backups {
+			fmt.Printf("%s\t%s\t%d B\n", b.ID, b.CreatedAt.Local().Format(time.RFC3339), b.Size)
+		}
+
+		return nil
+	},
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Делает бэкап прямо сейчас",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newBackupManager()
+		if err != nil {
+			return err
+		}
+
+		backup, err := manager.Create()
+		if err != nil {
+			return err
+		}
+
+		if backup == nil {
+			fmt.Println("база не изменилась с последнего бэкапа")
+
+			return nil
+		}
+
+		fmt.Println("бэкап", backup.ID, backup.Path)
+
+		return nil
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Восстанавливает базу из бэкапа",
+	Long: `Восстанавливает базу из бэкапа, id можно посмотреть в backup list.
+Бэкап сначала проверяется, текущая база перед заменой тоже бэкапится. Бот в этот момент должен быть остановлен.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newBackupManager()
+		if err != nil {
+			return err
+		}
+
+		if err := manager.Restore(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Println("база восстановлена из", args[0])
+
+		return nil
+	},
+}
+
+func newBackupManager() (*storage.BackupManager, error) {
+	conf, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewBackupManager(conf), nil
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+}
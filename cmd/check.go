@@ -16,34 +16,118 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
 	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/integrity"
 	"github.com/cyhalothrin/gifkoskladbot/storage"
-	"github.com/davecgh/go-spew/spew"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
 	"github.com/spf13/cobra"
 )
 
+var isCheckFix bool
+var isCheckJSON bool
+
 // checkCmd represents the check command
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Проверяет конфиг и базу данных",
-	Long:  "",
+	Long: `Проверяет конфиг и базу данных: теги, которых нет у гифок или в списке тегов, кривые теги,
+цепочки и циклы алиасов, гифки без сообщения в канале и несколько гифок на одно сообщение.
+С --fix исправляет то, что можно исправить автоматически.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		conf, err := config.ReadConfig()
 		if err != nil {
 			return err
 		}
 
-		db, err := storage.NewFileMetaStorage(conf.StoragePath)
+		if conf.Token == "" {
+			fmt.Println("в конфиге не указан token")
+		}
+		if conf.ChannelID == 0 {
+			fmt.Println("в конфиге не указан channelID")
+		}
+
+		// бэкап нужен, только если база поменяется
+		openStorage := storage.NewMetaStorageReadOnly
+		if isCheckFix {
+			openStorage = storage.NewMetaStorageForWrite
+		}
+
+		db, err := openStorage(conf)
 		if err != nil {
 			return err
 		}
+		defer db.Close()
+
+		var report *integrity.Report
+		if isCheckFix {
+			err = db.Update(func(tx storage.MetaTx) error {
+				report = integrity.Check(tx, true, tagnorm.New(conf.TagNormalization))
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if err := db.Flush(); err != nil {
+				return fmt.Errorf("сохранение хранилища: %w", err)
+			}
+		} else {
+			// только чтение, без транзакции: в хранилище только для чтения ее не открыть
+			report = integrity.Check(db, false, tagnorm.New(conf.TagNormalization))
+		}
+
+		if isCheckJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				return err
+			}
+		} else {
+			printCheckReport(report)
+		}
 
-		spew.Dump(conf, db.GetTags())
+		if unresolved := report.Unresolved(); unresolved > 0 {
+			return fmt.Errorf("осталось проблем: %d", unresolved)
+		}
 
 		return nil
 	},
 }
 
+func printCheckReport(report *integrity.Report) {
+	if len(report.Problems) == 0 {
+		fmt.Println("проблем не найдено")
+
+		return
+	}
+
+	kinds, groups := report.ByKind()
+	for _, kind := range kinds {
+		fmt.Printf("%s (%d):\n", kind, len(groups[kind]))
+
+		for _, p := range groups[kind] {
+			mark := " "
+			switch {
+			case p.Fixed:
+				mark = "+"
+			case !p.Fixable:
+				mark = "!"
+			}
+
+			fmt.Printf("  %s %s\n", mark, p)
+		}
+	}
+
+	fmt.Println("+ исправлено, ! нужно исправить руками")
+}
+
 func init() {
 	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().BoolVar(&isCheckFix, "fix", false, "исправить то, что можно исправить автоматически")
+	checkCmd.Flags().BoolVar(&isCheckJSON, "json", false, "вывести отчет в json")
 }
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/archive"
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+var exportFormat string
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <file|->",
+	Short: "Выгружает гифки, теги и алиасы в json, jsonl или csv",
+	Long: `Выгружает гифки, теги и алиасы в json, jsonl или csv.
+Формат определяется по расширению файла, для вывода в консоль укажите "-" и --format.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		format, err := archiveFormat(path, exportFormat)
+		if err != nil {
+			return err
+		}
+
+		conf, err := config.ReadConfig()
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.NewMetaStorageReadOnly(conf)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		a := archive.FromStorage(db)
+
+		var w io.Writer = os.Stdout
+		if path != "-" {
+			f, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("create export file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := archive.Encode(w, a, format); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+
+		if path != "-" {
+			fmt.Printf("выгружено гифок: %d, тегов: %d, алиасов: %d\n", len(a.Animations), len(a.Tags), len(a.TagsAliases))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "json, jsonl или csv, по умолчанию по расширению файла")
+}
+
+func archiveFormat(path, format string) (string, error) {
+	if format != "" {
+		return format, nil
+	}
+
+	if path == "-" {
+		return archive.FormatJSONL, nil
+	}
+
+	return archive.FormatFromPath(path)
+}
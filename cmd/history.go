@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/bot"
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history <fileID>",
+	Short: "Показывает, кто и когда менял теги гифки",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, err := config.ReadConfig()
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.NewMetaStorageReadOnly(conf)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		history := db.GetTagHistory(args[0])
+		if len(history) == 0 {
+			fmt.Println("теги этой гифки не менялись")
+
+			return nil
+		}
+
+		for i, change := range history {
+			author := "консоль"
+			if change.UserID != 0 || change.UserName != "" {
+				author = fmt.Sprintf("@%s (%d)", change.UserName, change.UserID)
+			}
+
+			fmt.Printf(
+				"#%d\t%s\t%s\t'%s' => '%s'\n",
+				i+1,
+				change.ChangedAt.Local().Format(time.RFC3339),
+				author,
+				joinCaption(change.OldTags, change.OldDescription),
+				joinCaption(change.NewTags, change.NewDescription),
+			)
+		}
+
+		return nil
+	},
+}
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <fileID> [N]",
+	Short: "Возвращает гифке теги, которые были до изменения N из history",
+	Long: `Возвращает гифке теги, которые были до изменения N из history, и редактирует подпись в канале.
+Без N отменяет последнее изменение.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		change := 0
+		if len(args) > 1 {
+			var err error
+			if change, err = strconv.Atoi(strings.TrimPrefix(args[1], "#")); err != nil {
+				return fmt.Errorf("номер изменения: %w", err)
+			}
+		}
+
+		return bot.RollbackAnimationTags(args[0], change)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+// joinCaption теги и описание через пробел, как в подписи без шаблона
+func joinCaption(tags []string, description string) string {
+	caption := strings.Join(tags, " ")
+	if description != "" && caption != "" {
+		return caption + " " + description
+	}
+
+	return caption + description
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/archive"
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+var importFormat string
+var importMode string
+var importOverwrite bool
+var isImportDryRun bool
+
+var errImportDryRun = errors.New("dry run")
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Загружает гифки, теги и алиасы из json, jsonl или csv",
+	Long: `Загружает гифки, теги и алиасы из json, jsonl или csv.
+В режиме merge новое добавляется к существующему, а расхождения выводятся как конфликты,
+с --overwrite при конфликте берется значение из файла.
+В режиме replace база становится точной копией файла.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		format, err := archiveFormat(path, importFormat)
+		if err != nil {
+			return err
+		}
+
+		var r io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("open import file: %w", err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		a, err := archive.Decode(r, format)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+
+		conf, err := config.ReadConfig()
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.NewMetaStorageForWrite(conf)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var report *archive.ImportReport
+		err = db.Update(func(tx storage.MetaTx) error {
+			report, err = archive.Import(tx, a, archive.ImportOptions{
+				Mode:      archive.Mode(importMode),
+				Overwrite: importOverwrite,
+			})
+			if err != nil {
+				return err
+			}
+
+			if isImportDryRun {
+				// откатим транзакцию
+				return errImportDryRun
+			}
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, errImportDryRun) {
+			return err
+		}
+
+		if err := db.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Printf(
+			"добавлено: %d, обновлено: %d, без изменений: %d, удалено: %d, конфликтов: %d\n",
+			report.Added, report.Updated, report.Unchanged, report.Removed, len(report.Conflicts),
+		)
+		for _, c := range report.Conflicts {
+			fmt.Println("  ", c)
+		}
+
+		if isImportDryRun {
+			fmt.Println("dry run, база не изменена")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importFormat, "format", "", "json, jsonl или csv, по умолчанию по расширению файла")
+	importCmd.Flags().StringVar(&importMode, "mode", string(archive.ModeMerge), "merge или replace")
+	importCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "при конфликте в режиме merge брать значение из файла")
+	importCmd.Flags().BoolVar(&isImportDryRun, "dry-run", false, "только показать, что изменится")
+}
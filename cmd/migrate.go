@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+var isMigrateDryRun bool
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [file]",
+	Short: "Обновляет схему файла базы данных до текущей версии",
+	Long: `Обновляет схему файла базы данных до текущей версии.
+Без аргумента берется файл из конфига, но можно указать любой, например старый бэкап.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var path string
+		if len(args) > 0 {
+			path = args[0]
+		} else {
+			conf, err := config.ReadConfig()
+			if err != nil {
+				return err
+			}
+			path = conf.StoragePath
+		}
+
+		report, err := storage.MigrateFile(path, isMigrateDryRun)
+		if err != nil {
+			return err
+		}
+
+		if len(report.Applied) == 0 {
+			fmt.Printf("%s: уже версия %d, нечего мигрировать\n", path, report.ToVersion)
+
+			return nil
+		}
+
+		fmt.Printf("%s: версия %d => %d\n", path, report.FromVersion, report.ToVersion)
+		for _, desc := range report.Applied {
+			fmt.Println("  ", desc)
+		}
+
+		if isMigrateDryRun {
+			fmt.Print(report.Diff)
+			fmt.Println("dry run, файл не изменен")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().BoolVar(&isMigrateDryRun, "dry-run", false, "только показать изменения")
+}
@@ -1,18 +1,39 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/cyhalothrin/gifkoskladbot/favchannel/publish"
 	"github.com/spf13/cobra"
 )
 
 var isCommandCollect bool
 var isCommandPublish bool
+var isCommandImportList bool
 
 // publishCmd represents the publish command
 var publishCmd = &cobra.Command{
 	Use:   "publish",
 	Short: "Posts gif with tags to channel",
+	Long: `Posts gif with tags to channel.
+Publish state is kept in storage, old gifs with tags list can be moved there with --import-list [file].`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if isCommandImportList {
+			var path string
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			report, err := publish.ImportGifsWithTagsList(path)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("imported: %d, skipped as already in storage: %d\n", report.Imported, report.Skipped)
+
+			return nil
+		}
 		if isCommandCollect {
 			return publish.PublishGifWithTags(publish.CommandCollect)
 		}
@@ -41,4 +62,5 @@ func init() {
 	// publishCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	publishCmd.Flags().BoolVar(&isCommandCollect, "collect", false, "collects messages")
 	publishCmd.Flags().BoolVar(&isCommandPublish, "publish", false, "posts gifs to channel")
+	publishCmd.Flags().BoolVar(&isCommandImportList, "import-list", false, "moves old gifs with tags list to storage, once")
 }
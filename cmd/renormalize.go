@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/integrity"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+var isRenormalizeDryRun bool
+
+// renormalizeCmd represents the renormalize command
+var renormalizeCmd = &cobra.Command{
+	Use:   "renormalize",
+	Short: "Прогоняет все теги в базе через нормализацию",
+	Long: `Прогоняет теги гифок, список тегов и алиасы через нормализацию из конфига (tagNormalization)
+и пересобирает список тегов. Нужно после изменения настроек нормализации, например включения foldYo.
+Подписи в канале не меняются, список тегов обновится при следующей публикации.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, err := config.ReadConfig()
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.NewMetaStorageForWrite(conf)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var report *integrity.Report
+		err = db.Update(func(tx storage.MetaTx) error {
+			report = integrity.Renormalize(tx, tagnorm.New(conf.TagNormalization), isRenormalizeDryRun)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if !isRenormalizeDryRun {
+			if err := db.Flush(); err != nil {
+				return fmt.Errorf("сохранение хранилища: %w", err)
+			}
+		}
+
+		printCheckReport(report)
+		if isRenormalizeDryRun && len(report.Problems) > 0 {
+			fmt.Println("dry run, база не изменена")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renormalizeCmd)
+
+	renormalizeCmd.Flags().BoolVar(&isRenormalizeDryRun, "dry-run", false, "только показать изменения")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/search"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+var searchLimit int
+var searchOldestFirst bool
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Ищет гифки по тегам",
+	Long: `Ищет гифки по тегам, например:
+  gifkoskladbot search "#cat #funny -#dog"
+  gifkoskladbot search "#cat | #kot*"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conf, err := config.ReadConfig()
+		if err != nil {
+			return err
+		}
+
+		q, err := search.Parse(strings.Join(args, " "), tagnorm.New(conf.TagNormalization))
+		if err != nil {
+			return err
+		}
+
+		db, err := storage.NewMetaStorageReadOnly(conf)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		sort := search.SortRecent
+		if searchOldestFirst {
+			sort = search.SortOldest
+		}
+
+		idx := search.NewIndex(db.GetSentAnimations())
+		found := idx.Search(q, search.WithSort(sort), search.WithLimit(searchLimit))
+		for _, anim := range found {
+			fmt.Printf("#%d %s %s\n", anim.MessageID, anim.FileID, joinCaption(anim.Tags, anim.Description))
+		}
+		fmt.Println("найдено:", len(found))
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "максимум результатов, 0 - без ограничения")
+	searchCmd.Flags().BoolVar(&searchOldestFirst, "oldest", false, "сначала старые")
+}
@@ -12,13 +12,116 @@ import (
 var StoragePathFlag string
 
 type Config struct {
-	Token               string
-	HostUsername        string
-	ChannelID           int64
+	Token        string
+	HostUsername string
+	ChannelID    int64
+	// AdminIDs id админов бота в телеге, их роль нельзя отозвать из чата
+	AdminIDs []int
+	// AllowedUsers устарело: имена пользователей с правами админа, вместо них AdminIDs и /role
 	AllowedUsers        []string
 	StoragePath         string
+	StorageDriver       string
+	Backup              Backup
+	Alert               Alert
+	RateLimit           RateLimit
+	TagsIndex           TagsIndex
+	Caption             Caption
+	TagNormalization    TagNormalization
+	Routing             Routing
 	TDLib               TDLibClient
 	FavChannelMigration FavChannelMigration
+	// UpdateMaxAttempts сколько раз пробовать обработать обновление. После стольких неудач оно подтверждается
+	// без обработки, чтобы не держать следующие, и админу уходит алерт. По умолчанию 5
+	UpdateMaxAttempts int
+}
+
+type Backup struct {
+	// Disabled не делать бэкап при запуске бота и команд, которые меняют базу
+	Disabled bool
+	// Directory куда складывать бэкапы, по умолчанию папка backups рядом с базой
+	Directory string
+	// Keep сколько последних бэкапов хранить, по умолчанию 10
+	Keep int
+	// MaxAgeDays удалять бэкапы старше, 0 - не удалять по возрасту
+	MaxAgeDays int
+}
+
+type Alert struct {
+	// AdminChatID куда слать ошибки бота, 0 - только в stderr и FallbackFile
+	AdminChatID int64
+	// DedupWindowSeconds одинаковые ошибки внутри окна отправляются один раз, по умолчанию 600.
+	// Окно не сохраняется между запусками, так что работает только в режиме poll
+	DedupWindowSeconds int
+	// MaxPerMinute больше алертов в минуту в телегу не отправлять, по умолчанию 10
+	MaxPerMinute int
+	// FallbackFile файл, куда дописываются алерты, которые не ушли в телегу
+	FallbackFile string
+}
+
+type RateLimit struct {
+	// GlobalPerSecond сообщений в секунду во все чаты, по умолчанию 30
+	GlobalPerSecond float64
+	// ChatPerMinute сообщений в минуту в одну группу или канал, по умолчанию 20. В личку всегда не чаще раза в секунду
+	ChatPerMinute float64
+	// ChatBurst сколько сообщений в группу или канал можно отправить подряд без ожидания, по умолчанию 3
+	ChatBurst int
+}
+
+type TagsIndex struct {
+	// Format разметка списка тегов: html, markdownv2, пусто - простой текст без ссылок.
+	// Ссылки на теги ведут в бота HostUsername (имя без @), без него ссылок не будет
+	Format string
+	// GroupBy letter - группы по первой букве, category - по Categories, пусто - без групп
+	GroupBy string
+	// Categories группы тегов по порядку, теги без категории попадут в последнюю группу
+	Categories []TagsCategory
+	// Template шаблон text/template всего списка, по умолчанию теги с количеством гифок по группам.
+	// Разметка одного тега или заголовка не должна переноситься на другую строку, длинный список режется по строкам
+	Template string
+	// PublicLinks ссылки на теги присылают гифки всем. По умолчанию только тем, у кого есть роль viewer и выше
+	PublicLinks bool
+}
+
+type Caption struct {
+	// Format разметка подписи гифки в канале: html, markdownv2, пусто - простой текст
+	Format string
+	// Template шаблон text/template подписи, по умолчанию теги и описание через пробел, как раньше.
+	// Например, описание курсивом отдельной строкой и теги под ним:
+	// {{with .Description}}{{italic .}}{{"\n"}}{{end}}{{esc .Hashtags}}
+	Template string
+}
+
+type TagsCategory struct {
+	Name string
+	Tags []string
+}
+
+type TagNormalization struct {
+	// FoldYo заменять ё на е, чтобы #ёжик и #ежик были одним тегом
+	FoldYo bool
+}
+
+type Routing struct {
+	// Channels каналы кроме основного ChannelID, в подписи гифки канал выбирается словом @name,
+	// основной канал называется @main
+	Channels []RoutingChannel
+	// Rules правила по порядку для новых гифок без @канала в подписи, первое подходящее выбирает канал,
+	// если ни одно не подошло, гифка уйдет в основной канал. Уже отправленные гифки остаются в своих каналах
+	Rules []RoutingRule
+}
+
+type RoutingChannel struct {
+	Name string
+	ID   int64
+}
+
+type RoutingRule struct {
+	// Channel имя канала из Channels или main
+	Channel string
+	// Tags правило подходит гифкам с любым из тегов, пусто - с любыми тегами
+	Tags []string
+	// UserIDs правило подходит гифкам, подписанным этими пользователями, пусто - любыми
+	UserIDs []int
 }
 
 type TDLibClient struct {
@@ -32,7 +135,7 @@ type TDLibClient struct {
 }
 
 type FavChannelMigration struct {
-	// GifsWithTagsListPath file path for temporary store gif with tags
+	// GifsWithTagsListPath legacy gifs with tags list, now only for publish --import-list
 	GifsWithTagsListPath string
 	BotChatID            int64
 }
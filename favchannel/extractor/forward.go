@@ -14,11 +14,15 @@ func ForwardWithEmptyCaption() error {
 		return err
 	}
 
-	store, err := fileStorage.NewFileMetaStorage(conf.StoragePath)
+	store, err := fileStorage.NewMetaStorageForWrite(conf)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Println("close storage:", err)
+		}
+	}()
 
 	client, err := tdlibclient.NewClient(conf.TDLib)
 	if err != nil {
@@ -1,48 +1,63 @@
 package publish
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"sort"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/Arman92/go-tdlib"
 
 	"github.com/cyhalothrin/gifkoskladbot/bot"
 	"github.com/cyhalothrin/gifkoskladbot/config"
 	"github.com/cyhalothrin/gifkoskladbot/favchannel/tdlibclient"
+	"github.com/cyhalothrin/gifkoskladbot/ratelimit"
 	fileStorage "github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
 )
 
 // GifTagsPublisher publish tagged gifs from fav channel and adds Tags to storage
 type GifTagsPublisher struct {
 	client publisherClient
 	conf   config.Config
+	// limiter paces posts to the channel, nil means no limit
+	limiter *ratelimit.Limiter
+	// normalizer is the same tags normalizer the bot uses, so tags from both sources match
+	normalizer *tagnorm.Normalizer
+	formatter  postFormatter
+	// updateTagsList updates the bot's tags index in the channel after new tags are saved
+	updateTagsList func(store publishStorage) error
 }
 
 // NewGifTagsPublisher creates GifTagsPublisher
 func NewGifTagsPublisher(conf config.Config, client publisherClient) (*GifTagsPublisher, error) {
+	formatter, err := bot.NewPostFormatter(conf)
+	if err != nil {
+		return nil, err
+	}
+
 	return &GifTagsPublisher{
-		client: client,
-		conf:   conf,
+		client:     client,
+		conf:       conf,
+		limiter:    ratelimit.New(conf.RateLimit),
+		normalizer: tagnorm.New(conf.TagNormalization),
+		formatter:  formatter,
+		updateTagsList: func(store publishStorage) error {
+			return bot.UpdateTagsList(conf, store)
+		},
 	}, nil
 }
 
-func (g *GifTagsPublisher) collect() error {
+// collect finds tagged gifs in fav channel and saves them to storage. Gifs that are already known keep their
+// publish status, so collect can be run again at any time
+func (g *GifTagsPublisher) collect(store publishStorage) error {
 	favChatID, err := g.client.GetFavChannelID()
 	if err != nil {
 		return err
 	}
 
 	hIter := tdlibclient.NewHistoryIterator(g.client, favChatID)
-	info := gifsInfo{
-		Messages: make(map[string]animationTagInfo),
-	}
-	uniqueTags := make(map[string]bool)
+	collected := make(map[string]*fileStorage.FavChannelAnimation)
 
 	for {
 		msgs, err := hIter.Next()
@@ -76,7 +91,8 @@ func (g *GifTagsPublisher) collect() error {
 			}
 
 			// checking tags of same gifs
-			if gifInfo, ok := info.Messages[fileID]; ok {
+			if gifInfo, ok := collected[fileID]; ok {
+				oldTags := gifInfo.Tags
 				tagsIsChanged := false
 				for _, tag := range tags {
 					isExist := false
@@ -95,61 +111,61 @@ func (g *GifTagsPublisher) collect() error {
 				}
 
 				if tagsIsChanged {
-					fmt.Printf("tags changed: %s => %s\n", info.Messages[fileID].Tags, gifInfo.Tags)
+					fmt.Printf("tags changed: %s => %s\n", oldTags, gifInfo.Tags)
 				}
 
 				if desc != "" && desc != gifInfo.Description {
+					oldDesc := gifInfo.Description
 					if gifInfo.Description != "" {
 						gifInfo.Description += ", " + desc
 					} else {
 						gifInfo.Description = desc
 					}
 
-					fmt.Printf("description changed: %s => %s\n", info.Messages[fileID].Description, gifInfo.Description)
+					fmt.Printf("description changed: %s => %s\n", oldDesc, gifInfo.Description)
 				}
-
-				info.Messages[fileID] = gifInfo
 			} else {
-				info.Messages[fileID] = animationTagInfo{
-					FileID:      fileID,
-					Tags:        tags,
-					ID:          msg.ID,
-					Description: desc,
+				collected[fileID] = &fileStorage.FavChannelAnimation{
+					FileID:          fileID,
+					Tags:            tags,
+					SourceMessageID: msg.ID,
+					Description:     desc,
+					Status:          fileStorage.PublishStatusCollected,
 				}
 			}
-
-			for _, tag := range tags {
-				uniqueTags[tag] = true
-			}
 		}
 	}
 
-	for tag := range uniqueTags {
-		info.Tags = append(info.Tags, tag)
+	existing := store.GetFavChannelAnimations()
+	for fileID, anim := range collected {
+		if old, ok := existing[fileID]; ok {
+			anim.Status = old.Status
+			anim.ChannelMessageID = old.ChannelMessageID
+		}
 	}
-	sort.Strings(info.Tags)
 
-	return g.saveInfo(info)
+	store.AddFavChannelAnimations(collected)
+
+	fmt.Println("collected animations:", len(collected))
+
+	return nil
 }
 
-func (g *GifTagsPublisher) publishMessages(storage bot.GifkoskladMetaStorage) (err error) {
+// publishMessages posts collected gifs in the order they were tagged in the fav channel. Captions and channels
+// are the bot's ones, gifs are sent by TDLib client, because their file ids are valid only for it
+func (g *GifTagsPublisher) publishMessages(store publishStorage) (err error) {
 	newSentAnimations := make(map[string]*fileStorage.SentAnimation)
-	info, err := g.readInfo()
-	if err != nil {
-		return err
-	}
+	favAnimations := store.GetFavChannelAnimations()
+	// changed fav channel animations with new publish status
+	changed := make(map[string]*fileStorage.FavChannelAnimation)
 
 	defer func() {
 		if len(newSentAnimations) > 0 {
-			storage.AddSentAnimations(newSentAnimations)
-			g.saveSentTags(storage, newSentAnimations)
+			store.AddSentAnimations(newSentAnimations)
+			g.saveSentTags(store, newSentAnimations)
 		}
-		if saveErr := g.saveInfo(info); saveErr != nil {
-			if err == nil {
-				err = saveErr
-			} else {
-				fmt.Println("save info:", saveErr)
-			}
+		if len(changed) > 0 {
+			store.AddFavChannelAnimations(changed)
 		}
 
 		if r := recover(); r != nil {
@@ -157,132 +173,129 @@ func (g *GifTagsPublisher) publishMessages(storage bot.GifkoskladMetaStorage) (e
 		}
 	}()
 
-	msgCh := make(chan *fileStorage.SentAnimation)
-	sentMsgCh := g.listenMessagesToSend(msgCh)
-	sentAnimations := storage.GetSentAnimations()
+	sentAnimations := store.GetSentAnimations()
+	var toSend []*fileStorage.SentAnimation
 
-	go func() {
-		defer close(msgCh)
+	for fileID, gifInfo := range favAnimations {
+		if gifInfo.Status != fileStorage.PublishStatusCollected {
+			continue
+		}
 
-		for fileID, gifInfo := range info.Messages {
-			if _, ok := sentAnimations[fileID]; ok {
-				continue
-			}
-			if gifInfo.IsSent {
-				continue
+		// gif could be sent by bot, only status is outdated
+		if sent, ok := sentAnimations[fileID]; ok {
+			gifInfo.Status = fileStorage.PublishStatusSent
+			if sent != nil {
+				gifInfo.ChannelMessageID = int64(sent.MessageID)
 			}
+			changed[fileID] = gifInfo
 
-			msgCh <- &fileStorage.SentAnimation{
-				FileID: fileID,
-				Tags:   g.addDescriptionToTags(gifInfo.Tags, gifInfo.Description),
-			}
+			continue
 		}
-	}()
-
-	for msg := range sentMsgCh {
-		newSentAnimations[msg.FileID] = msg
 
-		gifInfo := info.Messages[msg.FileID]
-		gifInfo.IsSent = true
-		gifInfo.ChannelMessageID = int64(msg.MessageID)
-		info.Messages[msg.FileID] = gifInfo
+		toSend = append(toSend, &fileStorage.SentAnimation{
+			FileID:      fileID,
+			Tags:        gifInfo.Tags,
+			Description: gifInfo.Description,
+		})
 	}
 
-	fmt.Println("sent animations:", len(newSentAnimations))
-
-	return nil
-}
+	// post in the same order as gifs were tagged in the fav channel
+	sort.Slice(toSend, func(i, j int) bool {
+		a, b := favAnimations[toSend[i].FileID], favAnimations[toSend[j].FileID]
+		if a.SourceMessageID == b.SourceMessageID {
+			return a.FileID < b.FileID
+		}
 
-func (g *GifTagsPublisher) listen(sentMsgCh <-chan *fileStorage.SentAnimation) {
-	pendingMessagesIDs := make(map[int64]*fileStorage.SentAnimation)
-	sendFailChan := g.client.AddUpdatesListener(
-		tdlib.NewUpdateMessageSendFailed(nil, 0, 0, ""),
-	)
-	sendSucceededChan := g.client.AddUpdatesListener(
-		tdlib.NewUpdateMessageSendSucceeded(nil, 0),
-	)
+		return a.SourceMessageID < b.SourceMessageID
+	})
 
-	for {
-		select {
-		case upd := <-sendFailChan:
+	msgCh := make(chan *fileStorage.SentAnimation)
+	sentMsgCh := g.listenMessagesToSend(msgCh)
 
-		case upd := <-sendSucceededChan:
-			succeededUpd, ok := upd.(*tdlib.UpdateMessageSendSucceeded)
-			if !ok {
-				continue
-			}
+	go func() {
+		defer close(msgCh)
 
-			msg := pendingMessagesIDs[succeededUpd.OldMessageID]
-			if msg != nil {
-				msg.MessageID = int(succeededUpd.Message.ID)
-			}
-		case msg := <-sentMsgCh:
-			pendingMessagesIDs[int64(msg.MessageID)] = msg
-		case <-time.After(30 * time.Second):
-			return
+		for _, msg := range toSend {
+			msgCh <- msg
 		}
-	}
-}
+	}()
+
+	for msg := range sentMsgCh {
+		newSentAnimations[msg.FileID] = msg
 
-func (g *GifTagsPublisher) addDescriptionToTags(tags []string, desc string) []string {
-	if desc == "" {
-		return tags
+		gifInfo := favAnimations[msg.FileID]
+		gifInfo.Status = fileStorage.PublishStatusSent
+		gifInfo.ChannelMessageID = int64(postedMessageID(msg))
+		changed[msg.FileID] = gifInfo
 	}
 
-	newTags := make([]string, len(tags))
-	copy(newTags, tags)
-	newTags = append(newTags, desc)
+	fmt.Println("sent animations:", len(newSentAnimations))
 
-	return newTags
+	return nil
 }
 
+// listenMessagesToSend posts messages one by one, so they appear in the channel in the order they were received.
+// Sending rate is controlled by limiter
 func (g *GifTagsPublisher) listenMessagesToSend(msgCh <-chan *fileStorage.SentAnimation) <-chan *fileStorage.SentAnimation {
 	sentMsgCh := make(chan *fileStorage.SentAnimation)
-	var wg sync.WaitGroup
-
-	// workers
-	for i := 0; i < 10; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
 
-			for msg := range msgCh {
-				err := g.postToChannel(msg)
-				if err == nil {
-					sentMsgCh <- msg
+	go func() {
+		defer close(sentMsgCh)
 
-					continue
-				}
+		for msg := range msgCh {
+			err := g.postToChannel(msg)
+			if err == nil {
+				sentMsgCh <- msg
 
-				fmt.Printf("failed post gif %v: %s\n", msg.Tags, err)
+				continue
 			}
-		}()
-	}
-	go func() {
-		wg.Wait()
-		close(sentMsgCh)
+
+			fmt.Printf("failed post gif %v: %s\n", msg.Tags, err)
+		}
 	}()
 
 	return sentMsgCh
 }
 
+// postToChannel sends gif to the channel chosen by the bot's routing rules with the bot's caption template
 func (g *GifTagsPublisher) postToChannel(msg *fileStorage.SentAnimation) error {
-	id, err := g.client.SendAnimation(g.conf.ChannelID, msg.FileID, strings.Join(msg.Tags, " "))
+	caption, err := g.formatter.Caption(msg.Tags, msg.Description)
 	if err != nil {
 		return err
 	}
 
-	msg.MessageID = int(id)
+	chatID := g.formatter.Channel(msg.Tags)
+	g.limiter.Wait(chatID)
+
+	id, err := g.client.SendAnimation(chatID, msg.FileID, caption, g.formatter.ParseMode())
+	if err != nil {
+		return err
+	}
+
+	if chatID == g.conf.ChannelID {
+		msg.MessageID = int(id)
+	} else {
+		msg.ChannelMessages = map[int64]int{chatID: int(id)}
+	}
 
 	return nil
 }
 
+// postedMessageID id of the gif message in the channel it was posted to
+func postedMessageID(msg *fileStorage.SentAnimation) int {
+	for _, id := range msg.ChannelMessages {
+		return id
+	}
+
+	return msg.MessageID
+}
+
 func (g *GifTagsPublisher) saveSentTags(
-	storage bot.GifkoskladMetaStorage,
+	store publishStorage,
 	sentAnimations map[string]*fileStorage.SentAnimation,
 ) {
 	uniqueTags := make(map[string]bool)
-	for _, tag := range storage.GetTags() {
+	for _, tag := range store.GetTags() {
 		uniqueTags[tag] = true
 	}
 
@@ -300,47 +313,16 @@ func (g *GifTagsPublisher) saveSentTags(
 	}
 	sort.Strings(tags)
 
-	storage.SetTags(tags)
-
-	if err := g.updateTagsList(tags); err != nil {
-		fmt.Println("update tags message failed:", err)
-	}
-}
-
-func (g *GifTagsPublisher) updateTagsList(tags []string) error {
-	if (len(tags)) == 0 {
-		return nil
-	}
-
-	text := strings.Join(tags, "\n")
-	msgID, err := g.client.GetPinnedMessageID(g.conf.ChannelID)
-	if err != nil {
-		return fmt.Errorf("getting chat pinned message id: %w", err)
-	}
+	store.SetTags(tags)
 
-	if msgID != 0 {
-		err := g.client.EditMessageCaption(g.conf.ChannelID, msgID, text)
-		if err != nil {
-			return fmt.Errorf("editing tags list message: %w", err)
-		}
-	} else {
-		// if here no pinned message, create it
-		newID, err := g.client.SendTextMessage(g.conf.ChannelID, text)
-		if err != nil {
-			return fmt.Errorf("sending tags list: %w", err)
-		}
-		if err := g.client.PinMessage(g.conf.ChannelID, newID); err != nil {
-			return fmt.Errorf("pin message #%d: %w", newID, err)
-		}
+	// the bot keeps the tags index in its own messages, ids are in storage
+	if err := g.updateTagsList(store); err != nil {
+		fmt.Println("update tags list failed:", err)
 	}
-
-	fmt.Printf("Tags list updated:\n%s\n", text)
-
-	return nil
 }
 
 func (g *GifTagsPublisher) parseTags(caption string) ([]string, string) {
-	chunks := strings.Split(strings.ToLower(caption), " ")
+	chunks := strings.Fields(strings.ToLower(caption))
 	if len(chunks) == 0 {
 		return nil, ""
 	}
@@ -354,7 +336,9 @@ func (g *GifTagsPublisher) parseTags(caption string) ([]string, string) {
 		}
 
 		if strings.HasPrefix(chunk, "#") {
-			tags = append(tags, chunk)
+			if tag, ok := g.normalizer.Tag(chunk); ok && tag != "#gif" && !containsTag(tags, tag) {
+				tags = append(tags, tag)
+			}
 			continue
 		}
 
@@ -371,57 +355,25 @@ func (g *GifTagsPublisher) parseTags(caption string) ([]string, string) {
 	return tags, description
 }
 
-func (g *GifTagsPublisher) saveInfo(list gifsInfo) error {
-	data, err := json.Marshal(list)
-	if err != nil {
-		return fmt.Errorf("marshal gifs list: %w", err)
-	}
-
-	path := g.conf.FavChannelMigration.GifsWithTagsListPath
-	if err := ioutil.WriteFile(path, data, 0666); err != nil {
-		return fmt.Errorf("write gifs list to file '%s': %w", path, err)
-	}
-
-	fmt.Println("saved info:", path)
-
-	return nil
-}
-
-func (g *GifTagsPublisher) readInfo() (info gifsInfo, err error) {
-	content, err := ioutil.ReadFile(g.conf.FavChannelMigration.GifsWithTagsListPath)
-	if err != nil {
-		return info, fmt.Errorf("read file: %w", err)
-	}
-
-	if err := json.Unmarshal(content, &info); err != nil {
-		return info, fmt.Errorf("parse json: %w", err)
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
 	}
 
-	return info, nil
+	return false
 }
 
 type publisherClient interface {
 	tdlibclient.ChatHistorier
 	tdlibclient.FavChannelFinder
-	SendAnimation(chatID int64, fileID string, caption string) (int64, error)
-	EditMessageCaption(chatID int64, messageID int64, caption string) error
-	SendTextMessage(chatID int64, text string) (int64, error)
-	GetPinnedMessageID(chatID int64) (int64, error)
-	PinMessage(chatID int64, messageID int64) error
-	AddUpdatesListener(updateType tdlib.TdMessage) chan tdlib.TdMessage
-}
-
-type animationTagInfo struct {
-	FileID           string
-	Tags             []string
-	Description      string `json:",omitempty"`
-	ID               int64
-	IsSent           bool  `json:",omitempty"`
-	IsDeleted        bool  `json:",omitempty"`
-	ChannelMessageID int64 `json:",omitempty"`
+	SendAnimation(chatID int64, fileID string, caption string, parseMode string) (int64, error)
 }
 
-type gifsInfo struct {
-	Messages map[string]animationTagInfo
-	Tags     []string
+// postFormatter renders captions and picks channels the same way the bot does, it is *bot.PostFormatter
+type postFormatter interface {
+	Caption(tags []string, description string) (string, error)
+	ParseMode() string
+	Channel(tags []string) int64
 }
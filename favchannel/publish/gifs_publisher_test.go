@@ -1,13 +1,9 @@
 package publish
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"math/rand"
-	"os"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -21,104 +17,136 @@ import (
 	fileStorage "github.com/cyhalothrin/gifkoskladbot/storage"
 )
 
-func setup() {
-	// copy data file
-	source, err := os.Open("testdata/messages.init.json")
-	if err != nil {
-		panic(err)
-	}
-	defer source.Close()
+const (
+	fileLol        = "CgACAgIAAx0ER7jZmwACB29fY6IkFe1uAcGWG1slegjj9SLIZwAC0AADQREZCsJQJe2uMLiHGAQ"
+	fileNeponyatno = "CgACAgIAAxkBAAEDBfhfXjb61m1eQc1Wmb626tmS2BgTNwAClwAD5Im4SfoGWydN2QgMGAQ"
+	fileKotiki     = "CgACAgIAAx0ETm6cZwACA9BfY6IgM6ZaGFh89Erp6-G6547K6wAC-gMAAgeIOUuCh-0pbyC76BgE"
+	fileOvechka    = "CgACAgIAAxkBAAEDBU9fXjb76uuhZkONrEXHA3BVxb66xwAC6AIAAg0IUEuo9FFl_K-mRxgE"
+)
 
-	destination, err := os.Create("testdata/messages.json")
+func newTestFormatter(t *testing.T, conf config.Config) *bot.PostFormatter {
+	formatter, err := bot.NewPostFormatter(conf)
 	if err != nil {
-		panic(err)
+		t.Fatalf("post formatter: %s", err)
 	}
-	defer destination.Close()
-
-	if _, err := io.Copy(destination, source); err != nil {
-		panic(err)
-	}
-}
 
-func teardown() {
-	err := os.Remove("testdata/messages.json")
-	if err != nil {
-		if os.IsNotExist(err) {
-			return
-		}
-
-		fmt.Println("Failed remove data file:", err)
-	}
+	return formatter
 }
 
 func TestGifTagsPublisher_publishMessages(t *testing.T) {
-	setup()
-	defer teardown()
-
 	mc := minimock.NewController(t)
 	defer mc.Finish()
 
-	channelID := int64(1013)
+	channelID := int64(-1013)
+	catsChannelID := int64(-1014)
 	conf := config.Config{
-		FavChannelMigration: config.FavChannelMigration{
-			GifsWithTagsListPath: "testdata/messages.json",
-		},
 		ChannelID: channelID,
+		Caption: config.Caption{
+			Format:   "html",
+			Template: `{{esc .Hashtags}}{{with .Description}}{{"\n"}}{{italic .}}{{end}}`,
+		},
+		Routing: config.Routing{
+			Channels: []config.RoutingChannel{{Name: "cats", ID: catsChannelID}},
+			Rules:    []config.RoutingRule{{Channel: "cats", Tags: []string{"#котики"}}},
+		},
 	}
 
 	type fields struct {
 		client publisherClient
 	}
 	type args struct {
-		storage bot.GifkoskladMetaStorage
+		store publishStorage
 	}
 	tests := []struct {
-		name            string
-		fields          fields
-		args            args
-		wantErr         bool
-		wantFileContent gifsInfo
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
 	}{
 		{
 			"publish messages",
 			fields{
 				client: NewPublisherClientMock(mc).
 					SendAnimationMock.
-					When(
-						channelID,
-						"CgACAgIAAxkBAAEDBfhfXjb61m1eQc1Wmb626tmS2BgTNwAClwAD5Im4SfoGWydN2QgMGAQ",
-						"#непонятно сложно!",
-					).
+					When(channelID, fileNeponyatno, "#непонятно\n<i>сложно!</i>", "HTML").
 					Then(101, nil).
 					SendAnimationMock.
-					When(
-						channelID,
-						"CgACAgIAAx0ETm6cZwACA9BfY6IgM6ZaGFh89Erp6-G6547K6wAC-gMAAgeIOUuCh-0pbyC76BgE",
-						"#aaaaaa #fuuuu #котики",
-					).
-					Then(102, nil).
-					GetPinnedMessageIDMock.Expect(channelID).Return(100500, nil).
-					EditMessageCaptionMock.
-					Expect(channelID, 100500, "#aaaaaa\n#fuuuu\n#tag1\n#tag2\n#котики\n#непонятно").
-					Return(nil),
+					When(catsChannelID, fileKotiki, "#aaaaaa #fuuuu #котики", "HTML").
+					Then(102, nil),
 			},
 			args{
-				storage: NewGifkoskladMetaStorageMock(t).
+				store: NewPublishStorageMock(mc).
+					GetFavChannelAnimationsMock.
+					Return(map[string]*fileStorage.FavChannelAnimation{
+						fileLol: {
+							FileID:          fileLol,
+							Tags:            []string{"#lol"},
+							SourceMessageID: 207842443264,
+							Status:          fileStorage.PublishStatusCollected,
+						},
+						fileNeponyatno: {
+							FileID:          fileNeponyatno,
+							Tags:            []string{"#непонятно"},
+							Description:     "сложно!",
+							SourceMessageID: 207760654336,
+							Status:          fileStorage.PublishStatusCollected,
+						},
+						fileKotiki: {
+							FileID:          fileKotiki,
+							Tags:            []string{"#aaaaaa", "#fuuuu", "#котики"},
+							SourceMessageID: 207861317632,
+							Status:          fileStorage.PublishStatusCollected,
+						},
+						fileOvechka: {
+							FileID:           fileOvechka,
+							Tags:             []string{"#овечка"},
+							SourceMessageID:  207583444992,
+							Status:           fileStorage.PublishStatusSent,
+							ChannelMessageID: 50,
+						},
+					}).
 					GetSentAnimationsMock.
 					Return(map[string]*fileStorage.SentAnimation{
-						"CgACAgIAAx0ER7jZmwACB29fY6IkFe1uAcGWG1slegjj9SLIZwAC0AADQREZCsJQJe2uMLiHGAQ": nil,
+						fileLol: {MessageID: 99, FileID: fileLol, Tags: []string{"#lol"}},
 					}).
 					AddSentAnimationsMock.
 					Expect(map[string]*fileStorage.SentAnimation{
-						"CgACAgIAAxkBAAEDBfhfXjb61m1eQc1Wmb626tmS2BgTNwAClwAD5Im4SfoGWydN2QgMGAQ": {
-							MessageID: 101,
-							FileID:    "CgACAgIAAxkBAAEDBfhfXjb61m1eQc1Wmb626tmS2BgTNwAClwAD5Im4SfoGWydN2QgMGAQ",
-							Tags:      []string{"#непонятно", "сложно!"},
+						fileNeponyatno: {
+							MessageID:   101,
+							FileID:      fileNeponyatno,
+							Tags:        []string{"#непонятно"},
+							Description: "сложно!",
 						},
-						"CgACAgIAAx0ETm6cZwACA9BfY6IgM6ZaGFh89Erp6-G6547K6wAC-gMAAgeIOUuCh-0pbyC76BgE": {
-							MessageID: 102,
-							FileID:    "CgACAgIAAx0ETm6cZwACA9BfY6IgM6ZaGFh89Erp6-G6547K6wAC-gMAAgeIOUuCh-0pbyC76BgE",
-							Tags:      []string{"#aaaaaa", "#fuuuu", "#котики"},
+						fileKotiki: {
+							FileID:          fileKotiki,
+							Tags:            []string{"#aaaaaa", "#fuuuu", "#котики"},
+							ChannelMessages: map[int64]int{catsChannelID: 102},
+						},
+					}).
+					Return().
+					AddFavChannelAnimationsMock.
+					Expect(map[string]*fileStorage.FavChannelAnimation{
+						fileLol: {
+							FileID:           fileLol,
+							Tags:             []string{"#lol"},
+							SourceMessageID:  207842443264,
+							Status:           fileStorage.PublishStatusSent,
+							ChannelMessageID: 99,
+						},
+						fileNeponyatno: {
+							FileID:           fileNeponyatno,
+							Tags:             []string{"#непонятно"},
+							Description:      "сложно!",
+							SourceMessageID:  207760654336,
+							Status:           fileStorage.PublishStatusSent,
+							ChannelMessageID: 101,
+						},
+						fileKotiki: {
+							FileID:           fileKotiki,
+							Tags:             []string{"#aaaaaa", "#fuuuu", "#котики"},
+							SourceMessageID:  207861317632,
+							Status:           fileStorage.PublishStatusSent,
+							ChannelMessageID: 102,
 						},
 					}).
 					Return().
@@ -129,97 +157,42 @@ func TestGifTagsPublisher_publishMessages(t *testing.T) {
 					Return(),
 			},
 			false,
-			gifsInfo{
-				Messages: map[string]animationTagInfo{
-					"CgACAgIAAx0ER7jZmwACB29fY6IkFe1uAcGWG1slegjj9SLIZwAC0AADQREZCsJQJe2uMLiHGAQ": {
-						FileID: "CgACAgIAAx0ER7jZmwACB29fY6IkFe1uAcGWG1slegjj9SLIZwAC0AADQREZCsJQJe2uMLiHGAQ",
-						Tags:   []string{"#lol"},
-						ID:     207842443264,
-					},
-					"CgACAgIAAxkBAAEDBfhfXjb61m1eQc1Wmb626tmS2BgTNwAClwAD5Im4SfoGWydN2QgMGAQ": {
-						FileID:           "CgACAgIAAxkBAAEDBfhfXjb61m1eQc1Wmb626tmS2BgTNwAClwAD5Im4SfoGWydN2QgMGAQ",
-						Tags:             []string{"#непонятно"},
-						Description:      "сложно!",
-						ID:               207760654336,
-						IsSent:           true,
-						ChannelMessageID: 101,
-					},
-					"CgACAgIAAx0ETm6cZwACA9BfY6IgM6ZaGFh89Erp6-G6547K6wAC-gMAAgeIOUuCh-0pbyC76BgE": {
-						FileID:           "CgACAgIAAx0ETm6cZwACA9BfY6IgM6ZaGFh89Erp6-G6547K6wAC-gMAAgeIOUuCh-0pbyC76BgE",
-						Tags:             []string{"#aaaaaa", "#fuuuu", "#котики"},
-						ID:               207861317632,
-						IsSent:           true,
-						ChannelMessageID: 102,
-					},
-					"CgACAgIAAxkBAAEDBU9fXjb76uuhZkONrEXHA3BVxb66xwAC6AIAAg0IUEuo9FFl_K-mRxgE": {
-						FileID: "CgACAgIAAxkBAAEDBU9fXjb76uuhZkONrEXHA3BVxb66xwAC6AIAAg0IUEuo9FFl_K-mRxgE",
-						Tags:   []string{"#овечка"},
-						ID:     207583444992,
-						IsSent: true,
-					},
-				},
-			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tagsListUpdates := 0
 			g := &GifTagsPublisher{
-				client: tt.fields.client,
-				conf:   conf,
-			}
-			if err := g.publishMessages(tt.args.storage); (err != nil) != tt.wantErr {
-				t.Errorf("publishMessages() error = %v, wantErr %v", err, tt.wantErr)
-			}
+				client:    tt.fields.client,
+				conf:      conf,
+				formatter: newTestFormatter(t, conf),
+				updateTagsList: func(store publishStorage) error {
+					tagsListUpdates++
 
-			content, err := ioutil.ReadFile(conf.FavChannelMigration.GifsWithTagsListPath)
-			if err != nil {
-				t.Errorf("read file: %s", err)
+					return nil
+				},
 			}
-
-			fileGifInfo := &gifsInfo{}
-			if err := json.Unmarshal(content, fileGifInfo); err != nil {
-				t.Errorf("parse json: %s", err)
+			if err := g.publishMessages(tt.args.store); (err != nil) != tt.wantErr {
+				t.Errorf("publishMessages() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			assert.Equal(t, tt.wantFileContent.Messages, fileGifInfo.Messages)
+			assert.Equal(t, 1, tagsListUpdates)
 		})
 	}
 }
 
-func TestGifTagsPublisher_addDescriptionToTags(t *testing.T) {
-	for i := 0; i < 10; i++ {
-		length := rand.Intn(9) + 1
-		tags := make([]string, 0, length)
-		for i := 1; i <= length; i++ {
-			tags = append(tags, fmt.Sprintf("#tag%d", i))
-		}
-
-		desc := ""
-		if rand.Intn(2) > 0 {
-			desc = "gif description"
-		}
-
-		g := &GifTagsPublisher{}
-		newTags := g.addDescriptionToTags(tags, desc)
-		if desc == "" {
-			assert.ElementsMatch(t, newTags, tags)
-		} else {
-			assert.Equal(t, len(newTags), length+1)
-			assert.Equal(t, len(tags), length, "original slice should not changed")
-			assert.Equal(t, newTags[len(newTags)-1], desc)
-		}
-	}
-}
-
 func TestGifTagsPublisher_listenMessagesToSend(t *testing.T) {
 	mc := minimock.NewController(t)
 	defer mc.Finish()
 
 	var errCount int64
 
+	conf := config.Config{ChannelID: -1010}
 	g := &GifTagsPublisher{
+		conf:      conf,
+		formatter: newTestFormatter(t, conf),
 		client: NewPublisherClientMock(mc).SendAnimationMock.Set(
-			func(chatID int64, fileID string, caption string) (i1 int64, err error) {
+			func(chatID int64, fileID string, caption string, parseMode string) (i1 int64, err error) {
 				if rand.Float64() < 0.3 {
 					atomic.AddInt64(&errCount, 1)
 					return 0, errors.New("sendAnimationErr")
@@ -258,7 +231,8 @@ func TestGifTagsPublisher_postToChannel(t *testing.T) {
 	defer mc.Finish()
 
 	conf := config.Config{
-		ChannelID: 1010,
+		ChannelID: -1010,
+		Caption:   config.Caption{Template: `{{.Description}}: {{.Hashtags}}`},
 	}
 
 	type fields struct {
@@ -279,13 +253,14 @@ func TestGifTagsPublisher_postToChannel(t *testing.T) {
 			fields{
 				client: NewPublisherClientMock(mc).
 					SendAnimationMock.
-					Expect(conf.ChannelID, "file_1", "#tag1 #tag2 description").
+					Expect(conf.ChannelID, "file_1", "description: #tag1 #tag2", "").
 					Return(1001, nil),
 			},
 			args{
 				msg: &fileStorage.SentAnimation{
-					FileID: "file_1",
-					Tags:   []string{"#tag1", "#tag2", "description"},
+					FileID:      "file_1",
+					Tags:        []string{"#tag1", "#tag2"},
+					Description: "description",
 				},
 			},
 			false,
@@ -296,13 +271,29 @@ func TestGifTagsPublisher_postToChannel(t *testing.T) {
 			fields{
 				client: NewPublisherClientMock(mc).
 					SendAnimationMock.
-					Expect(conf.ChannelID, "file_1", "#tag1 #tag2 description").
+					Expect(conf.ChannelID, "file_1", "description: #tag1 #tag2", "").
 					Return(0, errors.New("SendAnimationErr")),
 			},
 			args{
 				msg: &fileStorage.SentAnimation{
-					FileID: "file_1",
-					Tags:   []string{"#tag1", "#tag2", "description"},
+					FileID:      "file_1",
+					Tags:        []string{"#tag1", "#tag2"},
+					Description: "description",
+				},
+			},
+			true,
+			0,
+		},
+		{
+			"should not send too long caption",
+			fields{
+				client: NewPublisherClientMock(mc),
+			},
+			args{
+				msg: &fileStorage.SentAnimation{
+					FileID:      "file_1",
+					Tags:        []string{"#tag1"},
+					Description: strings.Repeat("a", 1100),
 				},
 			},
 			true,
@@ -312,8 +303,9 @@ func TestGifTagsPublisher_postToChannel(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := &GifTagsPublisher{
-				client: tt.fields.client,
-				conf:   conf,
+				client:    tt.fields.client,
+				conf:      conf,
+				formatter: newTestFormatter(t, conf),
 			}
 			if err := g.postToChannel(tt.args.msg); (err != nil) != tt.wantErr {
 				t.Errorf("postToChannel() error = %v, wantErr %v", err, tt.wantErr)
@@ -324,68 +316,72 @@ func TestGifTagsPublisher_postToChannel(t *testing.T) {
 	}
 }
 
-func TestGifTagsPublisher_updateTagsList(t *testing.T) {
+func Test_importGifsInfo(t *testing.T) {
 	mc := minimock.NewController(t)
 	defer mc.Finish()
 
-	conf := config.Config{
-		ChannelID: 1010,
+	info, err := readGifsInfo("testdata/messages.init.json")
+	if err != nil {
+		t.Fatalf("read gifs info: %s", err)
 	}
 
-	tags := []string{"#tag1", "#tag2", "#tag3", "#tag4"}
-	caption := strings.Join(tags, "\n")
-	pinnedMessageID := int64(20304050)
-
-	type fields struct {
-		client publisherClient
-	}
-	type args struct {
-		tags []string
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			"should edit existing pinned message",
-			fields{
-				client: NewPublisherClientMock(mc).GetPinnedMessageIDMock.
-					Expect(conf.ChannelID).Return(pinnedMessageID, nil).
-					EditMessageCaptionMock.
-					Expect(conf.ChannelID, pinnedMessageID, caption).Return(nil),
+	store := NewPublishStorageMock(mc).
+		GetFavChannelAnimationsMock.
+		Return(map[string]*fileStorage.FavChannelAnimation{
+			fileLol: {FileID: fileLol, Tags: []string{"#lol"}, Status: fileStorage.PublishStatusSent, ChannelMessageID: 99},
+		}).
+		GetSentAnimationsMock.
+		Return(map[string]*fileStorage.SentAnimation{
+			fileOvechka: {MessageID: 50, FileID: fileOvechka, Tags: []string{"#овечка"}},
+		}).
+		AddFavChannelAnimationsMock.
+		Expect(map[string]*fileStorage.FavChannelAnimation{
+			fileNeponyatno: {
+				FileID:          fileNeponyatno,
+				Tags:            []string{"#непонятно"},
+				Description:     "сложно!",
+				SourceMessageID: 207760654336,
+				Status:          fileStorage.PublishStatusCollected,
 			},
-			args{
-				tags: tags,
+			fileKotiki: {
+				FileID:          fileKotiki,
+				Tags:            []string{"#aaaaaa", "#fuuuu", "#котики"},
+				SourceMessageID: 207861317632,
+				Status:          fileStorage.PublishStatusCollected,
 			},
-			false,
-		},
-		{
-			"should creat new message and pin it",
-			fields{
-				client: NewPublisherClientMock(mc).
-					GetPinnedMessageIDMock.
-					Expect(conf.ChannelID).Return(0, nil).
-					SendTextMessageMock.
-					Expect(conf.ChannelID, caption).Return(1020, nil).
-					PinMessageMock.Expect(conf.ChannelID, 1020).Return(nil),
-			},
-			args{
-				tags: tags,
+			fileOvechka: {
+				FileID:           fileOvechka,
+				Tags:             []string{"#овечка"},
+				SourceMessageID:  207583444992,
+				Status:           fileStorage.PublishStatusSent,
+				ChannelMessageID: 50,
 			},
-			false,
-		},
+		}).
+		Return()
+
+	report := importGifsInfo(store, info)
+
+	assert.Equal(t, &ImportReport{Imported: 3, Skipped: 1}, report)
+}
+
+func TestGifTagsPublisher_parseTags(t *testing.T) {
+	g, _ := NewGifTagsPublisher(config.Config{TagNormalization: config.TagNormalization{FoldYo: true}}, nil)
+
+	tests := []struct {
+		caption  string
+		wantTags []string
+		wantDesc string
+	}{
+		{"#Cat #gif funny cat", []string{"#cat"}, "funny cat"},
+		{"#Ёжик, #ежик\n#кот! в тумане", []string{"#ежик", "#кот"}, "в тумане"},
+		{"just description", nil, ""},
+		{"#!!! text", nil, ""},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			g := &GifTagsPublisher{
-				client: tt.fields.client,
-				conf:   conf,
-			}
-			if err := g.updateTagsList(tt.args.tags); (err != nil) != tt.wantErr {
-				t.Errorf("updateTagsList() error = %v, wantErr %v", err, tt.wantErr)
-			}
+		t.Run(tt.caption, func(t *testing.T) {
+			tags, desc := g.parseTags(tt.caption)
+			assert.Equal(t, tt.wantTags, tags)
+			assert.Equal(t, tt.wantDesc, desc)
 		})
 	}
 }
@@ -0,0 +1,92 @@
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	fileStorage "github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// animationTagInfo gif from legacy gifs with tags list, before publish state was moved to storage
+type animationTagInfo struct {
+	FileID           string
+	Tags             []string
+	Description      string `json:",omitempty"`
+	ID               int64
+	IsSent           bool  `json:",omitempty"`
+	IsDeleted        bool  `json:",omitempty"`
+	ChannelMessageID int64 `json:",omitempty"`
+}
+
+type gifsInfo struct {
+	Messages map[string]animationTagInfo
+	Tags     []string
+}
+
+// ImportReport result of legacy gifs with tags list import
+type ImportReport struct {
+	Imported int
+	// Skipped gifs that are already in storage, their state is newer than in list
+	Skipped int
+}
+
+// importGifsInfo moves gifs from legacy list to storage, existing gifs are not overwritten
+func importGifsInfo(store publishStorage, info gifsInfo) *ImportReport {
+	report := &ImportReport{}
+	existing := store.GetFavChannelAnimations()
+	sentAnimations := store.GetSentAnimations()
+	imported := make(map[string]*fileStorage.FavChannelAnimation)
+
+	for fileID, gifInfo := range info.Messages {
+		if _, ok := existing[fileID]; ok {
+			report.Skipped++
+
+			continue
+		}
+
+		anim := &fileStorage.FavChannelAnimation{
+			FileID:           fileID,
+			Tags:             gifInfo.Tags,
+			Description:      gifInfo.Description,
+			SourceMessageID:  gifInfo.ID,
+			Status:           fileStorage.PublishStatusCollected,
+			ChannelMessageID: gifInfo.ChannelMessageID,
+		}
+
+		switch {
+		case gifInfo.IsDeleted:
+			anim.Status = fileStorage.PublishStatusDeleted
+		case gifInfo.IsSent:
+			anim.Status = fileStorage.PublishStatusSent
+		}
+
+		// old list did not always save channel message id
+		if sent := sentAnimations[fileID]; sent != nil && anim.ChannelMessageID == 0 {
+			anim.Status = fileStorage.PublishStatusSent
+			anim.ChannelMessageID = int64(sent.MessageID)
+		}
+
+		imported[fileID] = anim
+	}
+
+	if len(imported) > 0 {
+		store.AddFavChannelAnimations(imported)
+	}
+	report.Imported = len(imported)
+
+	return report
+}
+
+func readGifsInfo(path string) (info gifsInfo, err error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return info, fmt.Errorf("read file: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &info); err != nil {
+		return info, fmt.Errorf("parse json: %w", err)
+	}
+
+	return info, nil
+}
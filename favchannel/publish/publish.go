@@ -27,11 +27,15 @@ func PublishGifWithTags(command string) error {
 		return err
 	}
 
-	store, err := fileStorage.NewFileMetaStorage(conf.StoragePath)
+	store, err := fileStorage.NewMetaStorageForWrite(conf)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Println("close storage:", err)
+		}
+	}()
 
 	client, err := tdlibclient.NewClient(conf.TDLib)
 	if err != nil {
@@ -50,7 +54,7 @@ func PublishGifWithTags(command string) error {
 
 	switch command {
 	case CommandCollect:
-		return gifPub.collect()
+		return gifPub.collect(store)
 	case CommandPublish:
 		return gifPub.publishMessages(store)
 	}
@@ -58,6 +62,42 @@ func PublishGifWithTags(command string) error {
 	return nil
 }
 
+// ImportGifsWithTagsList moves publish state from legacy gifs with tags list (FavChannelMigration.GifsWithTagsListPath)
+// to storage. It needs to be run only once, after that the list is not used
+func ImportGifsWithTagsList(path string) (*ImportReport, error) {
+	conf, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		path = conf.FavChannelMigration.GifsWithTagsListPath
+	}
+
+	info, err := readGifsInfo(path)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := fileStorage.NewMetaStorageForWrite(conf)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Println("close storage:", err)
+		}
+	}()
+
+	report := importGifsInfo(store, info)
+
+	if err := store.Flush(); err != nil {
+		return nil, fmt.Errorf("save storage: %w", err)
+	}
+
+	return report, nil
+}
+
 func TestPublish() {
 	conf, err := config.ReadConfig()
 	if err != nil {
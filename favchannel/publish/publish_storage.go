@@ -0,0 +1,13 @@
+package publish
+
+import (
+	"github.com/cyhalothrin/gifkoskladbot/bot"
+	fileStorage "github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+type publishStorage interface {
+	bot.GifkoskladMetaStorage
+	// state of gifs moved from the fav channel
+	GetFavChannelAnimations() map[string]*fileStorage.FavChannelAnimation
+	AddFavChannelAnimations(map[string]*fileStorage.FavChannelAnimation)
+}
@@ -0,0 +1,2727 @@
+package publish
+
+// Code generated by http://github.com/gojuno/minimock (3.0.8). DO NOT EDIT.
+
+//go:generate minimock -i github.com/cyhalothrin/gifkoskladbot/favchannel/publish.publishStorage -o ./favchannel/publish/publish_storage_mock_test.go
+
+import (
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	fileStorage "github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/gojuno/minimock/v3"
+)
+
+// PublishStorageMock implements publishStorage
+type PublishStorageMock struct {
+	t minimock.Tester
+
+	funcAddFavChannelAnimations          func(m1 map[string]*fileStorage.FavChannelAnimation)
+	inspectFuncAddFavChannelAnimations   func(m1 map[string]*fileStorage.FavChannelAnimation)
+	afterAddFavChannelAnimationsCounter  uint64
+	beforeAddFavChannelAnimationsCounter uint64
+	AddFavChannelAnimationsMock          mPublishStorageMockAddFavChannelAnimations
+
+	funcAddSentAnimations          func(m1 map[string]*storage.SentAnimation)
+	inspectFuncAddSentAnimations   func(m1 map[string]*storage.SentAnimation)
+	afterAddSentAnimationsCounter  uint64
+	beforeAddSentAnimationsCounter uint64
+	AddSentAnimationsMock          mPublishStorageMockAddSentAnimations
+
+	funcAddTagChanges          func(changes ...*storage.TagChange)
+	inspectFuncAddTagChanges   func(changes ...*storage.TagChange)
+	afterAddTagChangesCounter  uint64
+	beforeAddTagChangesCounter uint64
+	AddTagChangesMock          mPublishStorageMockAddTagChanges
+
+	funcGetFavChannelAnimations          func() (m1 map[string]*fileStorage.FavChannelAnimation)
+	inspectFuncGetFavChannelAnimations   func()
+	afterGetFavChannelAnimationsCounter  uint64
+	beforeGetFavChannelAnimationsCounter uint64
+	GetFavChannelAnimationsMock          mPublishStorageMockGetFavChannelAnimations
+
+	funcGetPendingTypos          func() (m1 map[string]*storage.PendingTypos)
+	inspectFuncGetPendingTypos   func()
+	afterGetPendingTyposCounter  uint64
+	beforeGetPendingTyposCounter uint64
+	GetPendingTyposMock          mPublishStorageMockGetPendingTypos
+
+	funcGetSentAnimations          func() (m1 map[string]*storage.SentAnimation)
+	inspectFuncGetSentAnimations   func()
+	afterGetSentAnimationsCounter  uint64
+	beforeGetSentAnimationsCounter uint64
+	GetSentAnimationsMock          mPublishStorageMockGetSentAnimations
+
+	funcGetTags          func() (sa1 []string)
+	inspectFuncGetTags   func()
+	afterGetTagsCounter  uint64
+	beforeGetTagsCounter uint64
+	GetTagsMock          mPublishStorageMockGetTags
+
+	funcGetTagsAliases          func() (m1 map[string]string)
+	inspectFuncGetTagsAliases   func()
+	afterGetTagsAliasesCounter  uint64
+	beforeGetTagsAliasesCounter uint64
+	GetTagsAliasesMock          mPublishStorageMockGetTagsAliases
+
+	funcGetTagsIndex          func() (tpa1 []*storage.TagsIndexMessage)
+	inspectFuncGetTagsIndex   func()
+	afterGetTagsIndexCounter  uint64
+	beforeGetTagsIndexCounter uint64
+	GetTagsIndexMock          mPublishStorageMockGetTagsIndex
+
+	funcGetUserRoles          func() (m1 map[int]string)
+	inspectFuncGetUserRoles   func()
+	afterGetUserRolesCounter  uint64
+	beforeGetUserRolesCounter uint64
+	GetUserRolesMock          mPublishStorageMockGetUserRoles
+
+	funcSetPendingTypos          func(m1 map[string]*storage.PendingTypos)
+	inspectFuncSetPendingTypos   func(m1 map[string]*storage.PendingTypos)
+	afterSetPendingTyposCounter  uint64
+	beforeSetPendingTyposCounter uint64
+	SetPendingTyposMock          mPublishStorageMockSetPendingTypos
+
+	funcSetTags          func(sa1 []string)
+	inspectFuncSetTags   func(sa1 []string)
+	afterSetTagsCounter  uint64
+	beforeSetTagsCounter uint64
+	SetTagsMock          mPublishStorageMockSetTags
+
+	funcSetTagsAliases          func(m1 map[string]string)
+	inspectFuncSetTagsAliases   func(m1 map[string]string)
+	afterSetTagsAliasesCounter  uint64
+	beforeSetTagsAliasesCounter uint64
+	SetTagsAliasesMock          mPublishStorageMockSetTagsAliases
+
+	funcSetTagsIndex          func(tpa1 []*storage.TagsIndexMessage)
+	inspectFuncSetTagsIndex   func(tpa1 []*storage.TagsIndexMessage)
+	afterSetTagsIndexCounter  uint64
+	beforeSetTagsIndexCounter uint64
+	SetTagsIndexMock          mPublishStorageMockSetTagsIndex
+
+	funcSetUserRoles          func(m1 map[int]string)
+	inspectFuncSetUserRoles   func(m1 map[int]string)
+	afterSetUserRolesCounter  uint64
+	beforeSetUserRolesCounter uint64
+	SetUserRolesMock          mPublishStorageMockSetUserRoles
+}
+
+// NewPublishStorageMock returns a mock for publishStorage
+func NewPublishStorageMock(t minimock.Tester) *PublishStorageMock {
+	m := &PublishStorageMock{t: t}
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.AddFavChannelAnimationsMock = mPublishStorageMockAddFavChannelAnimations{mock: m}
+	m.AddFavChannelAnimationsMock.callArgs = []*PublishStorageMockAddFavChannelAnimationsParams{}
+
+	m.AddSentAnimationsMock = mPublishStorageMockAddSentAnimations{mock: m}
+	m.AddSentAnimationsMock.callArgs = []*PublishStorageMockAddSentAnimationsParams{}
+
+	m.AddTagChangesMock = mPublishStorageMockAddTagChanges{mock: m}
+	m.AddTagChangesMock.callArgs = []*PublishStorageMockAddTagChangesParams{}
+
+	m.GetFavChannelAnimationsMock = mPublishStorageMockGetFavChannelAnimations{mock: m}
+
+	m.GetPendingTyposMock = mPublishStorageMockGetPendingTypos{mock: m}
+
+	m.GetSentAnimationsMock = mPublishStorageMockGetSentAnimations{mock: m}
+
+	m.GetTagsMock = mPublishStorageMockGetTags{mock: m}
+
+	m.GetTagsAliasesMock = mPublishStorageMockGetTagsAliases{mock: m}
+
+	m.GetTagsIndexMock = mPublishStorageMockGetTagsIndex{mock: m}
+
+	m.GetUserRolesMock = mPublishStorageMockGetUserRoles{mock: m}
+
+	m.SetPendingTyposMock = mPublishStorageMockSetPendingTypos{mock: m}
+	m.SetPendingTyposMock.callArgs = []*PublishStorageMockSetPendingTyposParams{}
+
+	m.SetTagsMock = mPublishStorageMockSetTags{mock: m}
+	m.SetTagsMock.callArgs = []*PublishStorageMockSetTagsParams{}
+
+	m.SetTagsAliasesMock = mPublishStorageMockSetTagsAliases{mock: m}
+	m.SetTagsAliasesMock.callArgs = []*PublishStorageMockSetTagsAliasesParams{}
+
+	m.SetTagsIndexMock = mPublishStorageMockSetTagsIndex{mock: m}
+	m.SetTagsIndexMock.callArgs = []*PublishStorageMockSetTagsIndexParams{}
+
+	m.SetUserRolesMock = mPublishStorageMockSetUserRoles{mock: m}
+	m.SetUserRolesMock.callArgs = []*PublishStorageMockSetUserRolesParams{}
+
+	return m
+}
+
+type mPublishStorageMockAddFavChannelAnimations struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockAddFavChannelAnimationsExpectation
+	expectations       []*PublishStorageMockAddFavChannelAnimationsExpectation
+
+	callArgs []*PublishStorageMockAddFavChannelAnimationsParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockAddFavChannelAnimationsExpectation specifies expectation struct of the publishStorage.AddFavChannelAnimations
+type PublishStorageMockAddFavChannelAnimationsExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockAddFavChannelAnimationsParams
+
+	Counter uint64
+}
+
+// PublishStorageMockAddFavChannelAnimationsParams contains parameters of the publishStorage.AddFavChannelAnimations
+type PublishStorageMockAddFavChannelAnimationsParams struct {
+	m1 map[string]*fileStorage.FavChannelAnimation
+}
+
+// Expect sets up expected params for publishStorage.AddFavChannelAnimations
+func (mmAddFavChannelAnimations *mPublishStorageMockAddFavChannelAnimations) Expect(m1 map[string]*fileStorage.FavChannelAnimation) *mPublishStorageMockAddFavChannelAnimations {
+	if mmAddFavChannelAnimations.mock.funcAddFavChannelAnimations != nil {
+		mmAddFavChannelAnimations.mock.t.Fatalf("PublishStorageMock.AddFavChannelAnimations mock is already set by Set")
+	}
+
+	if mmAddFavChannelAnimations.defaultExpectation == nil {
+		mmAddFavChannelAnimations.defaultExpectation = &PublishStorageMockAddFavChannelAnimationsExpectation{}
+	}
+
+	mmAddFavChannelAnimations.defaultExpectation.params = &PublishStorageMockAddFavChannelAnimationsParams{m1}
+	for _, e := range mmAddFavChannelAnimations.expectations {
+		if minimock.Equal(e.params, mmAddFavChannelAnimations.defaultExpectation.params) {
+			mmAddFavChannelAnimations.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddFavChannelAnimations.defaultExpectation.params)
+		}
+	}
+
+	return mmAddFavChannelAnimations
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.AddFavChannelAnimations
+func (mmAddFavChannelAnimations *mPublishStorageMockAddFavChannelAnimations) Inspect(f func(m1 map[string]*fileStorage.FavChannelAnimation)) *mPublishStorageMockAddFavChannelAnimations {
+	if mmAddFavChannelAnimations.mock.inspectFuncAddFavChannelAnimations != nil {
+		mmAddFavChannelAnimations.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.AddFavChannelAnimations")
+	}
+
+	mmAddFavChannelAnimations.mock.inspectFuncAddFavChannelAnimations = f
+
+	return mmAddFavChannelAnimations
+}
+
+// Return sets up results that will be returned by publishStorage.AddFavChannelAnimations
+func (mmAddFavChannelAnimations *mPublishStorageMockAddFavChannelAnimations) Return() *PublishStorageMock {
+	if mmAddFavChannelAnimations.mock.funcAddFavChannelAnimations != nil {
+		mmAddFavChannelAnimations.mock.t.Fatalf("PublishStorageMock.AddFavChannelAnimations mock is already set by Set")
+	}
+
+	if mmAddFavChannelAnimations.defaultExpectation == nil {
+		mmAddFavChannelAnimations.defaultExpectation = &PublishStorageMockAddFavChannelAnimationsExpectation{mock: mmAddFavChannelAnimations.mock}
+	}
+
+	return mmAddFavChannelAnimations.mock
+}
+
+// Set uses given function f to mock the publishStorage.AddFavChannelAnimations method
+func (mmAddFavChannelAnimations *mPublishStorageMockAddFavChannelAnimations) Set(f func(m1 map[string]*fileStorage.FavChannelAnimation)) *PublishStorageMock {
+	if mmAddFavChannelAnimations.defaultExpectation != nil {
+		mmAddFavChannelAnimations.mock.t.Fatalf("Default expectation is already set for the publishStorage.AddFavChannelAnimations method")
+	}
+
+	if len(mmAddFavChannelAnimations.expectations) > 0 {
+		mmAddFavChannelAnimations.mock.t.Fatalf("Some expectations are already set for the publishStorage.AddFavChannelAnimations method")
+	}
+
+	mmAddFavChannelAnimations.mock.funcAddFavChannelAnimations = f
+	return mmAddFavChannelAnimations.mock
+}
+
+// AddFavChannelAnimations implements publishStorage
+func (mmAddFavChannelAnimations *PublishStorageMock) AddFavChannelAnimations(m1 map[string]*fileStorage.FavChannelAnimation) {
+	mm_atomic.AddUint64(&mmAddFavChannelAnimations.beforeAddFavChannelAnimationsCounter, 1)
+	defer mm_atomic.AddUint64(&mmAddFavChannelAnimations.afterAddFavChannelAnimationsCounter, 1)
+
+	if mmAddFavChannelAnimations.inspectFuncAddFavChannelAnimations != nil {
+		mmAddFavChannelAnimations.inspectFuncAddFavChannelAnimations(m1)
+	}
+
+	mm_params := &PublishStorageMockAddFavChannelAnimationsParams{m1}
+
+	// Record call args
+	mmAddFavChannelAnimations.AddFavChannelAnimationsMock.mutex.Lock()
+	mmAddFavChannelAnimations.AddFavChannelAnimationsMock.callArgs = append(mmAddFavChannelAnimations.AddFavChannelAnimationsMock.callArgs, mm_params)
+	mmAddFavChannelAnimations.AddFavChannelAnimationsMock.mutex.Unlock()
+
+	for _, e := range mmAddFavChannelAnimations.AddFavChannelAnimationsMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmAddFavChannelAnimations.AddFavChannelAnimationsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAddFavChannelAnimations.AddFavChannelAnimationsMock.defaultExpectation.Counter, 1)
+		mm_want := mmAddFavChannelAnimations.AddFavChannelAnimationsMock.defaultExpectation.params
+		mm_got := PublishStorageMockAddFavChannelAnimationsParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAddFavChannelAnimations.t.Errorf("PublishStorageMock.AddFavChannelAnimations got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmAddFavChannelAnimations.funcAddFavChannelAnimations != nil {
+		mmAddFavChannelAnimations.funcAddFavChannelAnimations(m1)
+		return
+	}
+	mmAddFavChannelAnimations.t.Fatalf("Unexpected call to PublishStorageMock.AddFavChannelAnimations. %v", m1)
+
+}
+
+// AddFavChannelAnimationsAfterCounter returns a count of finished PublishStorageMock.AddFavChannelAnimations invocations
+func (mmAddFavChannelAnimations *PublishStorageMock) AddFavChannelAnimationsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddFavChannelAnimations.afterAddFavChannelAnimationsCounter)
+}
+
+// AddFavChannelAnimationsBeforeCounter returns a count of PublishStorageMock.AddFavChannelAnimations invocations
+func (mmAddFavChannelAnimations *PublishStorageMock) AddFavChannelAnimationsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddFavChannelAnimations.beforeAddFavChannelAnimationsCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.AddFavChannelAnimations.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAddFavChannelAnimations *mPublishStorageMockAddFavChannelAnimations) Calls() []*PublishStorageMockAddFavChannelAnimationsParams {
+	mmAddFavChannelAnimations.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockAddFavChannelAnimationsParams, len(mmAddFavChannelAnimations.callArgs))
+	copy(argCopy, mmAddFavChannelAnimations.callArgs)
+
+	mmAddFavChannelAnimations.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAddFavChannelAnimationsDone returns true if the count of the AddFavChannelAnimations invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockAddFavChannelAnimationsDone() bool {
+	for _, e := range m.AddFavChannelAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddFavChannelAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddFavChannelAnimationsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddFavChannelAnimations != nil && mm_atomic.LoadUint64(&m.afterAddFavChannelAnimationsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockAddFavChannelAnimationsInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockAddFavChannelAnimationsInspect() {
+	for _, e := range m.AddFavChannelAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.AddFavChannelAnimations with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddFavChannelAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddFavChannelAnimationsCounter) < 1 {
+		if m.AddFavChannelAnimationsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.AddFavChannelAnimations")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.AddFavChannelAnimations with params: %#v", *m.AddFavChannelAnimationsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddFavChannelAnimations != nil && mm_atomic.LoadUint64(&m.afterAddFavChannelAnimationsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.AddFavChannelAnimations")
+	}
+}
+
+type mPublishStorageMockAddSentAnimations struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockAddSentAnimationsExpectation
+	expectations       []*PublishStorageMockAddSentAnimationsExpectation
+
+	callArgs []*PublishStorageMockAddSentAnimationsParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockAddSentAnimationsExpectation specifies expectation struct of the publishStorage.AddSentAnimations
+type PublishStorageMockAddSentAnimationsExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockAddSentAnimationsParams
+
+	Counter uint64
+}
+
+// PublishStorageMockAddSentAnimationsParams contains parameters of the publishStorage.AddSentAnimations
+type PublishStorageMockAddSentAnimationsParams struct {
+	m1 map[string]*storage.SentAnimation
+}
+
+// Expect sets up expected params for publishStorage.AddSentAnimations
+func (mmAddSentAnimations *mPublishStorageMockAddSentAnimations) Expect(m1 map[string]*storage.SentAnimation) *mPublishStorageMockAddSentAnimations {
+	if mmAddSentAnimations.mock.funcAddSentAnimations != nil {
+		mmAddSentAnimations.mock.t.Fatalf("PublishStorageMock.AddSentAnimations mock is already set by Set")
+	}
+
+	if mmAddSentAnimations.defaultExpectation == nil {
+		mmAddSentAnimations.defaultExpectation = &PublishStorageMockAddSentAnimationsExpectation{}
+	}
+
+	mmAddSentAnimations.defaultExpectation.params = &PublishStorageMockAddSentAnimationsParams{m1}
+	for _, e := range mmAddSentAnimations.expectations {
+		if minimock.Equal(e.params, mmAddSentAnimations.defaultExpectation.params) {
+			mmAddSentAnimations.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddSentAnimations.defaultExpectation.params)
+		}
+	}
+
+	return mmAddSentAnimations
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.AddSentAnimations
+func (mmAddSentAnimations *mPublishStorageMockAddSentAnimations) Inspect(f func(m1 map[string]*storage.SentAnimation)) *mPublishStorageMockAddSentAnimations {
+	if mmAddSentAnimations.mock.inspectFuncAddSentAnimations != nil {
+		mmAddSentAnimations.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.AddSentAnimations")
+	}
+
+	mmAddSentAnimations.mock.inspectFuncAddSentAnimations = f
+
+	return mmAddSentAnimations
+}
+
+// Return sets up results that will be returned by publishStorage.AddSentAnimations
+func (mmAddSentAnimations *mPublishStorageMockAddSentAnimations) Return() *PublishStorageMock {
+	if mmAddSentAnimations.mock.funcAddSentAnimations != nil {
+		mmAddSentAnimations.mock.t.Fatalf("PublishStorageMock.AddSentAnimations mock is already set by Set")
+	}
+
+	if mmAddSentAnimations.defaultExpectation == nil {
+		mmAddSentAnimations.defaultExpectation = &PublishStorageMockAddSentAnimationsExpectation{mock: mmAddSentAnimations.mock}
+	}
+
+	return mmAddSentAnimations.mock
+}
+
+// Set uses given function f to mock the publishStorage.AddSentAnimations method
+func (mmAddSentAnimations *mPublishStorageMockAddSentAnimations) Set(f func(m1 map[string]*storage.SentAnimation)) *PublishStorageMock {
+	if mmAddSentAnimations.defaultExpectation != nil {
+		mmAddSentAnimations.mock.t.Fatalf("Default expectation is already set for the publishStorage.AddSentAnimations method")
+	}
+
+	if len(mmAddSentAnimations.expectations) > 0 {
+		mmAddSentAnimations.mock.t.Fatalf("Some expectations are already set for the publishStorage.AddSentAnimations method")
+	}
+
+	mmAddSentAnimations.mock.funcAddSentAnimations = f
+	return mmAddSentAnimations.mock
+}
+
+// AddSentAnimations implements publishStorage
+func (mmAddSentAnimations *PublishStorageMock) AddSentAnimations(m1 map[string]*storage.SentAnimation) {
+	mm_atomic.AddUint64(&mmAddSentAnimations.beforeAddSentAnimationsCounter, 1)
+	defer mm_atomic.AddUint64(&mmAddSentAnimations.afterAddSentAnimationsCounter, 1)
+
+	if mmAddSentAnimations.inspectFuncAddSentAnimations != nil {
+		mmAddSentAnimations.inspectFuncAddSentAnimations(m1)
+	}
+
+	mm_params := &PublishStorageMockAddSentAnimationsParams{m1}
+
+	// Record call args
+	mmAddSentAnimations.AddSentAnimationsMock.mutex.Lock()
+	mmAddSentAnimations.AddSentAnimationsMock.callArgs = append(mmAddSentAnimations.AddSentAnimationsMock.callArgs, mm_params)
+	mmAddSentAnimations.AddSentAnimationsMock.mutex.Unlock()
+
+	for _, e := range mmAddSentAnimations.AddSentAnimationsMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmAddSentAnimations.AddSentAnimationsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAddSentAnimations.AddSentAnimationsMock.defaultExpectation.Counter, 1)
+		mm_want := mmAddSentAnimations.AddSentAnimationsMock.defaultExpectation.params
+		mm_got := PublishStorageMockAddSentAnimationsParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAddSentAnimations.t.Errorf("PublishStorageMock.AddSentAnimations got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmAddSentAnimations.funcAddSentAnimations != nil {
+		mmAddSentAnimations.funcAddSentAnimations(m1)
+		return
+	}
+	mmAddSentAnimations.t.Fatalf("Unexpected call to PublishStorageMock.AddSentAnimations. %v", m1)
+
+}
+
+// AddSentAnimationsAfterCounter returns a count of finished PublishStorageMock.AddSentAnimations invocations
+func (mmAddSentAnimations *PublishStorageMock) AddSentAnimationsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddSentAnimations.afterAddSentAnimationsCounter)
+}
+
+// AddSentAnimationsBeforeCounter returns a count of PublishStorageMock.AddSentAnimations invocations
+func (mmAddSentAnimations *PublishStorageMock) AddSentAnimationsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddSentAnimations.beforeAddSentAnimationsCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.AddSentAnimations.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAddSentAnimations *mPublishStorageMockAddSentAnimations) Calls() []*PublishStorageMockAddSentAnimationsParams {
+	mmAddSentAnimations.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockAddSentAnimationsParams, len(mmAddSentAnimations.callArgs))
+	copy(argCopy, mmAddSentAnimations.callArgs)
+
+	mmAddSentAnimations.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAddSentAnimationsDone returns true if the count of the AddSentAnimations invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockAddSentAnimationsDone() bool {
+	for _, e := range m.AddSentAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddSentAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddSentAnimationsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddSentAnimations != nil && mm_atomic.LoadUint64(&m.afterAddSentAnimationsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockAddSentAnimationsInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockAddSentAnimationsInspect() {
+	for _, e := range m.AddSentAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.AddSentAnimations with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddSentAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddSentAnimationsCounter) < 1 {
+		if m.AddSentAnimationsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.AddSentAnimations")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.AddSentAnimations with params: %#v", *m.AddSentAnimationsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddSentAnimations != nil && mm_atomic.LoadUint64(&m.afterAddSentAnimationsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.AddSentAnimations")
+	}
+}
+
+type mPublishStorageMockAddTagChanges struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockAddTagChangesExpectation
+	expectations       []*PublishStorageMockAddTagChangesExpectation
+
+	callArgs []*PublishStorageMockAddTagChangesParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockAddTagChangesExpectation specifies expectation struct of the publishStorage.AddTagChanges
+type PublishStorageMockAddTagChangesExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockAddTagChangesParams
+
+	Counter uint64
+}
+
+// PublishStorageMockAddTagChangesParams contains parameters of the publishStorage.AddTagChanges
+type PublishStorageMockAddTagChangesParams struct {
+	changes []*storage.TagChange
+}
+
+// Expect sets up expected params for publishStorage.AddTagChanges
+func (mmAddTagChanges *mPublishStorageMockAddTagChanges) Expect(changes ...*storage.TagChange) *mPublishStorageMockAddTagChanges {
+	if mmAddTagChanges.mock.funcAddTagChanges != nil {
+		mmAddTagChanges.mock.t.Fatalf("PublishStorageMock.AddTagChanges mock is already set by Set")
+	}
+
+	if mmAddTagChanges.defaultExpectation == nil {
+		mmAddTagChanges.defaultExpectation = &PublishStorageMockAddTagChangesExpectation{}
+	}
+
+	mmAddTagChanges.defaultExpectation.params = &PublishStorageMockAddTagChangesParams{changes}
+	for _, e := range mmAddTagChanges.expectations {
+		if minimock.Equal(e.params, mmAddTagChanges.defaultExpectation.params) {
+			mmAddTagChanges.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddTagChanges.defaultExpectation.params)
+		}
+	}
+
+	return mmAddTagChanges
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.AddTagChanges
+func (mmAddTagChanges *mPublishStorageMockAddTagChanges) Inspect(f func(changes ...*storage.TagChange)) *mPublishStorageMockAddTagChanges {
+	if mmAddTagChanges.mock.inspectFuncAddTagChanges != nil {
+		mmAddTagChanges.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.AddTagChanges")
+	}
+
+	mmAddTagChanges.mock.inspectFuncAddTagChanges = f
+
+	return mmAddTagChanges
+}
+
+// Return sets up results that will be returned by publishStorage.AddTagChanges
+func (mmAddTagChanges *mPublishStorageMockAddTagChanges) Return() *PublishStorageMock {
+	if mmAddTagChanges.mock.funcAddTagChanges != nil {
+		mmAddTagChanges.mock.t.Fatalf("PublishStorageMock.AddTagChanges mock is already set by Set")
+	}
+
+	if mmAddTagChanges.defaultExpectation == nil {
+		mmAddTagChanges.defaultExpectation = &PublishStorageMockAddTagChangesExpectation{mock: mmAddTagChanges.mock}
+	}
+
+	return mmAddTagChanges.mock
+}
+
+// Set uses given function f to mock the publishStorage.AddTagChanges method
+func (mmAddTagChanges *mPublishStorageMockAddTagChanges) Set(f func(changes ...*storage.TagChange)) *PublishStorageMock {
+	if mmAddTagChanges.defaultExpectation != nil {
+		mmAddTagChanges.mock.t.Fatalf("Default expectation is already set for the publishStorage.AddTagChanges method")
+	}
+
+	if len(mmAddTagChanges.expectations) > 0 {
+		mmAddTagChanges.mock.t.Fatalf("Some expectations are already set for the publishStorage.AddTagChanges method")
+	}
+
+	mmAddTagChanges.mock.funcAddTagChanges = f
+	return mmAddTagChanges.mock
+}
+
+// AddTagChanges implements publishStorage
+func (mmAddTagChanges *PublishStorageMock) AddTagChanges(changes ...*storage.TagChange) {
+	mm_atomic.AddUint64(&mmAddTagChanges.beforeAddTagChangesCounter, 1)
+	defer mm_atomic.AddUint64(&mmAddTagChanges.afterAddTagChangesCounter, 1)
+
+	if mmAddTagChanges.inspectFuncAddTagChanges != nil {
+		mmAddTagChanges.inspectFuncAddTagChanges(changes...)
+	}
+
+	mm_params := &PublishStorageMockAddTagChangesParams{changes}
+
+	// Record call args
+	mmAddTagChanges.AddTagChangesMock.mutex.Lock()
+	mmAddTagChanges.AddTagChangesMock.callArgs = append(mmAddTagChanges.AddTagChangesMock.callArgs, mm_params)
+	mmAddTagChanges.AddTagChangesMock.mutex.Unlock()
+
+	for _, e := range mmAddTagChanges.AddTagChangesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmAddTagChanges.AddTagChangesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAddTagChanges.AddTagChangesMock.defaultExpectation.Counter, 1)
+		mm_want := mmAddTagChanges.AddTagChangesMock.defaultExpectation.params
+		mm_got := PublishStorageMockAddTagChangesParams{changes}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAddTagChanges.t.Errorf("PublishStorageMock.AddTagChanges got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmAddTagChanges.funcAddTagChanges != nil {
+		mmAddTagChanges.funcAddTagChanges(changes...)
+		return
+	}
+	mmAddTagChanges.t.Fatalf("Unexpected call to PublishStorageMock.AddTagChanges. %v", changes)
+
+}
+
+// AddTagChangesAfterCounter returns a count of finished PublishStorageMock.AddTagChanges invocations
+func (mmAddTagChanges *PublishStorageMock) AddTagChangesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddTagChanges.afterAddTagChangesCounter)
+}
+
+// AddTagChangesBeforeCounter returns a count of PublishStorageMock.AddTagChanges invocations
+func (mmAddTagChanges *PublishStorageMock) AddTagChangesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddTagChanges.beforeAddTagChangesCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.AddTagChanges.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAddTagChanges *mPublishStorageMockAddTagChanges) Calls() []*PublishStorageMockAddTagChangesParams {
+	mmAddTagChanges.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockAddTagChangesParams, len(mmAddTagChanges.callArgs))
+	copy(argCopy, mmAddTagChanges.callArgs)
+
+	mmAddTagChanges.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAddTagChangesDone returns true if the count of the AddTagChanges invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockAddTagChangesDone() bool {
+	for _, e := range m.AddTagChangesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddTagChangesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddTagChanges != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockAddTagChangesInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockAddTagChangesInspect() {
+	for _, e := range m.AddTagChangesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.AddTagChanges with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddTagChangesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		if m.AddTagChangesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.AddTagChanges")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.AddTagChanges with params: %#v", *m.AddTagChangesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddTagChanges != nil && mm_atomic.LoadUint64(&m.afterAddTagChangesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.AddTagChanges")
+	}
+}
+
+type mPublishStorageMockGetFavChannelAnimations struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetFavChannelAnimationsExpectation
+	expectations       []*PublishStorageMockGetFavChannelAnimationsExpectation
+}
+
+// PublishStorageMockGetFavChannelAnimationsExpectation specifies expectation struct of the publishStorage.GetFavChannelAnimations
+type PublishStorageMockGetFavChannelAnimationsExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetFavChannelAnimationsResults
+	Counter uint64
+}
+
+// PublishStorageMockGetFavChannelAnimationsResults contains results of the publishStorage.GetFavChannelAnimations
+type PublishStorageMockGetFavChannelAnimationsResults struct {
+	m1 map[string]*fileStorage.FavChannelAnimation
+}
+
+// Expect sets up expected params for publishStorage.GetFavChannelAnimations
+func (mmGetFavChannelAnimations *mPublishStorageMockGetFavChannelAnimations) Expect() *mPublishStorageMockGetFavChannelAnimations {
+	if mmGetFavChannelAnimations.mock.funcGetFavChannelAnimations != nil {
+		mmGetFavChannelAnimations.mock.t.Fatalf("PublishStorageMock.GetFavChannelAnimations mock is already set by Set")
+	}
+
+	if mmGetFavChannelAnimations.defaultExpectation == nil {
+		mmGetFavChannelAnimations.defaultExpectation = &PublishStorageMockGetFavChannelAnimationsExpectation{}
+	}
+
+	return mmGetFavChannelAnimations
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetFavChannelAnimations
+func (mmGetFavChannelAnimations *mPublishStorageMockGetFavChannelAnimations) Inspect(f func()) *mPublishStorageMockGetFavChannelAnimations {
+	if mmGetFavChannelAnimations.mock.inspectFuncGetFavChannelAnimations != nil {
+		mmGetFavChannelAnimations.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetFavChannelAnimations")
+	}
+
+	mmGetFavChannelAnimations.mock.inspectFuncGetFavChannelAnimations = f
+
+	return mmGetFavChannelAnimations
+}
+
+// Return sets up results that will be returned by publishStorage.GetFavChannelAnimations
+func (mmGetFavChannelAnimations *mPublishStorageMockGetFavChannelAnimations) Return(m1 map[string]*fileStorage.FavChannelAnimation) *PublishStorageMock {
+	if mmGetFavChannelAnimations.mock.funcGetFavChannelAnimations != nil {
+		mmGetFavChannelAnimations.mock.t.Fatalf("PublishStorageMock.GetFavChannelAnimations mock is already set by Set")
+	}
+
+	if mmGetFavChannelAnimations.defaultExpectation == nil {
+		mmGetFavChannelAnimations.defaultExpectation = &PublishStorageMockGetFavChannelAnimationsExpectation{mock: mmGetFavChannelAnimations.mock}
+	}
+	mmGetFavChannelAnimations.defaultExpectation.results = &PublishStorageMockGetFavChannelAnimationsResults{m1}
+	return mmGetFavChannelAnimations.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetFavChannelAnimations method
+func (mmGetFavChannelAnimations *mPublishStorageMockGetFavChannelAnimations) Set(f func() (m1 map[string]*fileStorage.FavChannelAnimation)) *PublishStorageMock {
+	if mmGetFavChannelAnimations.defaultExpectation != nil {
+		mmGetFavChannelAnimations.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetFavChannelAnimations method")
+	}
+
+	if len(mmGetFavChannelAnimations.expectations) > 0 {
+		mmGetFavChannelAnimations.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetFavChannelAnimations method")
+	}
+
+	mmGetFavChannelAnimations.mock.funcGetFavChannelAnimations = f
+	return mmGetFavChannelAnimations.mock
+}
+
+// GetFavChannelAnimations implements publishStorage
+func (mmGetFavChannelAnimations *PublishStorageMock) GetFavChannelAnimations() (m1 map[string]*fileStorage.FavChannelAnimation) {
+	mm_atomic.AddUint64(&mmGetFavChannelAnimations.beforeGetFavChannelAnimationsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetFavChannelAnimations.afterGetFavChannelAnimationsCounter, 1)
+
+	if mmGetFavChannelAnimations.inspectFuncGetFavChannelAnimations != nil {
+		mmGetFavChannelAnimations.inspectFuncGetFavChannelAnimations()
+	}
+
+	if mmGetFavChannelAnimations.GetFavChannelAnimationsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetFavChannelAnimations.GetFavChannelAnimationsMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetFavChannelAnimations.GetFavChannelAnimationsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetFavChannelAnimations.t.Fatal("No results are set for the PublishStorageMock.GetFavChannelAnimations")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetFavChannelAnimations.funcGetFavChannelAnimations != nil {
+		return mmGetFavChannelAnimations.funcGetFavChannelAnimations()
+	}
+	mmGetFavChannelAnimations.t.Fatalf("Unexpected call to PublishStorageMock.GetFavChannelAnimations.")
+	return
+}
+
+// GetFavChannelAnimationsAfterCounter returns a count of finished PublishStorageMock.GetFavChannelAnimations invocations
+func (mmGetFavChannelAnimations *PublishStorageMock) GetFavChannelAnimationsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetFavChannelAnimations.afterGetFavChannelAnimationsCounter)
+}
+
+// GetFavChannelAnimationsBeforeCounter returns a count of PublishStorageMock.GetFavChannelAnimations invocations
+func (mmGetFavChannelAnimations *PublishStorageMock) GetFavChannelAnimationsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetFavChannelAnimations.beforeGetFavChannelAnimationsCounter)
+}
+
+// MinimockGetFavChannelAnimationsDone returns true if the count of the GetFavChannelAnimations invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetFavChannelAnimationsDone() bool {
+	for _, e := range m.GetFavChannelAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetFavChannelAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetFavChannelAnimationsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetFavChannelAnimations != nil && mm_atomic.LoadUint64(&m.afterGetFavChannelAnimationsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetFavChannelAnimationsInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetFavChannelAnimationsInspect() {
+	for _, e := range m.GetFavChannelAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetFavChannelAnimations")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetFavChannelAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetFavChannelAnimationsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetFavChannelAnimations")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetFavChannelAnimations != nil && mm_atomic.LoadUint64(&m.afterGetFavChannelAnimationsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetFavChannelAnimations")
+	}
+}
+
+type mPublishStorageMockGetPendingTypos struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetPendingTyposExpectation
+	expectations       []*PublishStorageMockGetPendingTyposExpectation
+}
+
+// PublishStorageMockGetPendingTyposExpectation specifies expectation struct of the publishStorage.GetPendingTypos
+type PublishStorageMockGetPendingTyposExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetPendingTyposResults
+	Counter uint64
+}
+
+// PublishStorageMockGetPendingTyposResults contains results of the publishStorage.GetPendingTypos
+type PublishStorageMockGetPendingTyposResults struct {
+	m1 map[string]*storage.PendingTypos
+}
+
+// Expect sets up expected params for publishStorage.GetPendingTypos
+func (mmGetPendingTypos *mPublishStorageMockGetPendingTypos) Expect() *mPublishStorageMockGetPendingTypos {
+	if mmGetPendingTypos.mock.funcGetPendingTypos != nil {
+		mmGetPendingTypos.mock.t.Fatalf("PublishStorageMock.GetPendingTypos mock is already set by Set")
+	}
+
+	if mmGetPendingTypos.defaultExpectation == nil {
+		mmGetPendingTypos.defaultExpectation = &PublishStorageMockGetPendingTyposExpectation{}
+	}
+
+	return mmGetPendingTypos
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetPendingTypos
+func (mmGetPendingTypos *mPublishStorageMockGetPendingTypos) Inspect(f func()) *mPublishStorageMockGetPendingTypos {
+	if mmGetPendingTypos.mock.inspectFuncGetPendingTypos != nil {
+		mmGetPendingTypos.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetPendingTypos")
+	}
+
+	mmGetPendingTypos.mock.inspectFuncGetPendingTypos = f
+
+	return mmGetPendingTypos
+}
+
+// Return sets up results that will be returned by publishStorage.GetPendingTypos
+func (mmGetPendingTypos *mPublishStorageMockGetPendingTypos) Return(m1 map[string]*storage.PendingTypos) *PublishStorageMock {
+	if mmGetPendingTypos.mock.funcGetPendingTypos != nil {
+		mmGetPendingTypos.mock.t.Fatalf("PublishStorageMock.GetPendingTypos mock is already set by Set")
+	}
+
+	if mmGetPendingTypos.defaultExpectation == nil {
+		mmGetPendingTypos.defaultExpectation = &PublishStorageMockGetPendingTyposExpectation{mock: mmGetPendingTypos.mock}
+	}
+	mmGetPendingTypos.defaultExpectation.results = &PublishStorageMockGetPendingTyposResults{m1}
+	return mmGetPendingTypos.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetPendingTypos method
+func (mmGetPendingTypos *mPublishStorageMockGetPendingTypos) Set(f func() (m1 map[string]*storage.PendingTypos)) *PublishStorageMock {
+	if mmGetPendingTypos.defaultExpectation != nil {
+		mmGetPendingTypos.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetPendingTypos method")
+	}
+
+	if len(mmGetPendingTypos.expectations) > 0 {
+		mmGetPendingTypos.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetPendingTypos method")
+	}
+
+	mmGetPendingTypos.mock.funcGetPendingTypos = f
+	return mmGetPendingTypos.mock
+}
+
+// GetPendingTypos implements publishStorage
+func (mmGetPendingTypos *PublishStorageMock) GetPendingTypos() (m1 map[string]*storage.PendingTypos) {
+	mm_atomic.AddUint64(&mmGetPendingTypos.beforeGetPendingTyposCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetPendingTypos.afterGetPendingTyposCounter, 1)
+
+	if mmGetPendingTypos.inspectFuncGetPendingTypos != nil {
+		mmGetPendingTypos.inspectFuncGetPendingTypos()
+	}
+
+	if mmGetPendingTypos.GetPendingTyposMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetPendingTypos.GetPendingTyposMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetPendingTypos.GetPendingTyposMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetPendingTypos.t.Fatal("No results are set for the PublishStorageMock.GetPendingTypos")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetPendingTypos.funcGetPendingTypos != nil {
+		return mmGetPendingTypos.funcGetPendingTypos()
+	}
+	mmGetPendingTypos.t.Fatalf("Unexpected call to PublishStorageMock.GetPendingTypos.")
+	return
+}
+
+// GetPendingTyposAfterCounter returns a count of finished PublishStorageMock.GetPendingTypos invocations
+func (mmGetPendingTypos *PublishStorageMock) GetPendingTyposAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPendingTypos.afterGetPendingTyposCounter)
+}
+
+// GetPendingTyposBeforeCounter returns a count of PublishStorageMock.GetPendingTypos invocations
+func (mmGetPendingTypos *PublishStorageMock) GetPendingTyposBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPendingTypos.beforeGetPendingTyposCounter)
+}
+
+// MinimockGetPendingTyposDone returns true if the count of the GetPendingTypos invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetPendingTyposDone() bool {
+	for _, e := range m.GetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetPendingTyposInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetPendingTyposInspect() {
+	for _, e := range m.GetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetPendingTypos")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetPendingTypos")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterGetPendingTyposCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetPendingTypos")
+	}
+}
+
+type mPublishStorageMockGetSentAnimations struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetSentAnimationsExpectation
+	expectations       []*PublishStorageMockGetSentAnimationsExpectation
+}
+
+// PublishStorageMockGetSentAnimationsExpectation specifies expectation struct of the publishStorage.GetSentAnimations
+type PublishStorageMockGetSentAnimationsExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetSentAnimationsResults
+	Counter uint64
+}
+
+// PublishStorageMockGetSentAnimationsResults contains results of the publishStorage.GetSentAnimations
+type PublishStorageMockGetSentAnimationsResults struct {
+	m1 map[string]*storage.SentAnimation
+}
+
+// Expect sets up expected params for publishStorage.GetSentAnimations
+func (mmGetSentAnimations *mPublishStorageMockGetSentAnimations) Expect() *mPublishStorageMockGetSentAnimations {
+	if mmGetSentAnimations.mock.funcGetSentAnimations != nil {
+		mmGetSentAnimations.mock.t.Fatalf("PublishStorageMock.GetSentAnimations mock is already set by Set")
+	}
+
+	if mmGetSentAnimations.defaultExpectation == nil {
+		mmGetSentAnimations.defaultExpectation = &PublishStorageMockGetSentAnimationsExpectation{}
+	}
+
+	return mmGetSentAnimations
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetSentAnimations
+func (mmGetSentAnimations *mPublishStorageMockGetSentAnimations) Inspect(f func()) *mPublishStorageMockGetSentAnimations {
+	if mmGetSentAnimations.mock.inspectFuncGetSentAnimations != nil {
+		mmGetSentAnimations.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetSentAnimations")
+	}
+
+	mmGetSentAnimations.mock.inspectFuncGetSentAnimations = f
+
+	return mmGetSentAnimations
+}
+
+// Return sets up results that will be returned by publishStorage.GetSentAnimations
+func (mmGetSentAnimations *mPublishStorageMockGetSentAnimations) Return(m1 map[string]*storage.SentAnimation) *PublishStorageMock {
+	if mmGetSentAnimations.mock.funcGetSentAnimations != nil {
+		mmGetSentAnimations.mock.t.Fatalf("PublishStorageMock.GetSentAnimations mock is already set by Set")
+	}
+
+	if mmGetSentAnimations.defaultExpectation == nil {
+		mmGetSentAnimations.defaultExpectation = &PublishStorageMockGetSentAnimationsExpectation{mock: mmGetSentAnimations.mock}
+	}
+	mmGetSentAnimations.defaultExpectation.results = &PublishStorageMockGetSentAnimationsResults{m1}
+	return mmGetSentAnimations.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetSentAnimations method
+func (mmGetSentAnimations *mPublishStorageMockGetSentAnimations) Set(f func() (m1 map[string]*storage.SentAnimation)) *PublishStorageMock {
+	if mmGetSentAnimations.defaultExpectation != nil {
+		mmGetSentAnimations.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetSentAnimations method")
+	}
+
+	if len(mmGetSentAnimations.expectations) > 0 {
+		mmGetSentAnimations.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetSentAnimations method")
+	}
+
+	mmGetSentAnimations.mock.funcGetSentAnimations = f
+	return mmGetSentAnimations.mock
+}
+
+// GetSentAnimations implements publishStorage
+func (mmGetSentAnimations *PublishStorageMock) GetSentAnimations() (m1 map[string]*storage.SentAnimation) {
+	mm_atomic.AddUint64(&mmGetSentAnimations.beforeGetSentAnimationsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetSentAnimations.afterGetSentAnimationsCounter, 1)
+
+	if mmGetSentAnimations.inspectFuncGetSentAnimations != nil {
+		mmGetSentAnimations.inspectFuncGetSentAnimations()
+	}
+
+	if mmGetSentAnimations.GetSentAnimationsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetSentAnimations.GetSentAnimationsMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetSentAnimations.GetSentAnimationsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetSentAnimations.t.Fatal("No results are set for the PublishStorageMock.GetSentAnimations")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetSentAnimations.funcGetSentAnimations != nil {
+		return mmGetSentAnimations.funcGetSentAnimations()
+	}
+	mmGetSentAnimations.t.Fatalf("Unexpected call to PublishStorageMock.GetSentAnimations.")
+	return
+}
+
+// GetSentAnimationsAfterCounter returns a count of finished PublishStorageMock.GetSentAnimations invocations
+func (mmGetSentAnimations *PublishStorageMock) GetSentAnimationsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetSentAnimations.afterGetSentAnimationsCounter)
+}
+
+// GetSentAnimationsBeforeCounter returns a count of PublishStorageMock.GetSentAnimations invocations
+func (mmGetSentAnimations *PublishStorageMock) GetSentAnimationsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetSentAnimations.beforeGetSentAnimationsCounter)
+}
+
+// MinimockGetSentAnimationsDone returns true if the count of the GetSentAnimations invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetSentAnimationsDone() bool {
+	for _, e := range m.GetSentAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetSentAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetSentAnimationsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetSentAnimations != nil && mm_atomic.LoadUint64(&m.afterGetSentAnimationsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetSentAnimationsInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetSentAnimationsInspect() {
+	for _, e := range m.GetSentAnimationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetSentAnimations")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetSentAnimationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetSentAnimationsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetSentAnimations")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetSentAnimations != nil && mm_atomic.LoadUint64(&m.afterGetSentAnimationsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetSentAnimations")
+	}
+}
+
+type mPublishStorageMockGetTags struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetTagsExpectation
+	expectations       []*PublishStorageMockGetTagsExpectation
+}
+
+// PublishStorageMockGetTagsExpectation specifies expectation struct of the publishStorage.GetTags
+type PublishStorageMockGetTagsExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetTagsResults
+	Counter uint64
+}
+
+// PublishStorageMockGetTagsResults contains results of the publishStorage.GetTags
+type PublishStorageMockGetTagsResults struct {
+	sa1 []string
+}
+
+// Expect sets up expected params for publishStorage.GetTags
+func (mmGetTags *mPublishStorageMockGetTags) Expect() *mPublishStorageMockGetTags {
+	if mmGetTags.mock.funcGetTags != nil {
+		mmGetTags.mock.t.Fatalf("PublishStorageMock.GetTags mock is already set by Set")
+	}
+
+	if mmGetTags.defaultExpectation == nil {
+		mmGetTags.defaultExpectation = &PublishStorageMockGetTagsExpectation{}
+	}
+
+	return mmGetTags
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetTags
+func (mmGetTags *mPublishStorageMockGetTags) Inspect(f func()) *mPublishStorageMockGetTags {
+	if mmGetTags.mock.inspectFuncGetTags != nil {
+		mmGetTags.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetTags")
+	}
+
+	mmGetTags.mock.inspectFuncGetTags = f
+
+	return mmGetTags
+}
+
+// Return sets up results that will be returned by publishStorage.GetTags
+func (mmGetTags *mPublishStorageMockGetTags) Return(sa1 []string) *PublishStorageMock {
+	if mmGetTags.mock.funcGetTags != nil {
+		mmGetTags.mock.t.Fatalf("PublishStorageMock.GetTags mock is already set by Set")
+	}
+
+	if mmGetTags.defaultExpectation == nil {
+		mmGetTags.defaultExpectation = &PublishStorageMockGetTagsExpectation{mock: mmGetTags.mock}
+	}
+	mmGetTags.defaultExpectation.results = &PublishStorageMockGetTagsResults{sa1}
+	return mmGetTags.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetTags method
+func (mmGetTags *mPublishStorageMockGetTags) Set(f func() (sa1 []string)) *PublishStorageMock {
+	if mmGetTags.defaultExpectation != nil {
+		mmGetTags.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetTags method")
+	}
+
+	if len(mmGetTags.expectations) > 0 {
+		mmGetTags.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetTags method")
+	}
+
+	mmGetTags.mock.funcGetTags = f
+	return mmGetTags.mock
+}
+
+// GetTags implements publishStorage
+func (mmGetTags *PublishStorageMock) GetTags() (sa1 []string) {
+	mm_atomic.AddUint64(&mmGetTags.beforeGetTagsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetTags.afterGetTagsCounter, 1)
+
+	if mmGetTags.inspectFuncGetTags != nil {
+		mmGetTags.inspectFuncGetTags()
+	}
+
+	if mmGetTags.GetTagsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetTags.GetTagsMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetTags.GetTagsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetTags.t.Fatal("No results are set for the PublishStorageMock.GetTags")
+		}
+		return (*mm_results).sa1
+	}
+	if mmGetTags.funcGetTags != nil {
+		return mmGetTags.funcGetTags()
+	}
+	mmGetTags.t.Fatalf("Unexpected call to PublishStorageMock.GetTags.")
+	return
+}
+
+// GetTagsAfterCounter returns a count of finished PublishStorageMock.GetTags invocations
+func (mmGetTags *PublishStorageMock) GetTagsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTags.afterGetTagsCounter)
+}
+
+// GetTagsBeforeCounter returns a count of PublishStorageMock.GetTags invocations
+func (mmGetTags *PublishStorageMock) GetTagsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTags.beforeGetTagsCounter)
+}
+
+// MinimockGetTagsDone returns true if the count of the GetTags invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetTagsDone() bool {
+	for _, e := range m.GetTagsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTags != nil && mm_atomic.LoadUint64(&m.afterGetTagsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetTagsInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetTagsInspect() {
+	for _, e := range m.GetTagsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetTags")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetTags")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTags != nil && mm_atomic.LoadUint64(&m.afterGetTagsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetTags")
+	}
+}
+
+type mPublishStorageMockGetTagsAliases struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetTagsAliasesExpectation
+	expectations       []*PublishStorageMockGetTagsAliasesExpectation
+}
+
+// PublishStorageMockGetTagsAliasesExpectation specifies expectation struct of the publishStorage.GetTagsAliases
+type PublishStorageMockGetTagsAliasesExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetTagsAliasesResults
+	Counter uint64
+}
+
+// PublishStorageMockGetTagsAliasesResults contains results of the publishStorage.GetTagsAliases
+type PublishStorageMockGetTagsAliasesResults struct {
+	m1 map[string]string
+}
+
+// Expect sets up expected params for publishStorage.GetTagsAliases
+func (mmGetTagsAliases *mPublishStorageMockGetTagsAliases) Expect() *mPublishStorageMockGetTagsAliases {
+	if mmGetTagsAliases.mock.funcGetTagsAliases != nil {
+		mmGetTagsAliases.mock.t.Fatalf("PublishStorageMock.GetTagsAliases mock is already set by Set")
+	}
+
+	if mmGetTagsAliases.defaultExpectation == nil {
+		mmGetTagsAliases.defaultExpectation = &PublishStorageMockGetTagsAliasesExpectation{}
+	}
+
+	return mmGetTagsAliases
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetTagsAliases
+func (mmGetTagsAliases *mPublishStorageMockGetTagsAliases) Inspect(f func()) *mPublishStorageMockGetTagsAliases {
+	if mmGetTagsAliases.mock.inspectFuncGetTagsAliases != nil {
+		mmGetTagsAliases.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetTagsAliases")
+	}
+
+	mmGetTagsAliases.mock.inspectFuncGetTagsAliases = f
+
+	return mmGetTagsAliases
+}
+
+// Return sets up results that will be returned by publishStorage.GetTagsAliases
+func (mmGetTagsAliases *mPublishStorageMockGetTagsAliases) Return(m1 map[string]string) *PublishStorageMock {
+	if mmGetTagsAliases.mock.funcGetTagsAliases != nil {
+		mmGetTagsAliases.mock.t.Fatalf("PublishStorageMock.GetTagsAliases mock is already set by Set")
+	}
+
+	if mmGetTagsAliases.defaultExpectation == nil {
+		mmGetTagsAliases.defaultExpectation = &PublishStorageMockGetTagsAliasesExpectation{mock: mmGetTagsAliases.mock}
+	}
+	mmGetTagsAliases.defaultExpectation.results = &PublishStorageMockGetTagsAliasesResults{m1}
+	return mmGetTagsAliases.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetTagsAliases method
+func (mmGetTagsAliases *mPublishStorageMockGetTagsAliases) Set(f func() (m1 map[string]string)) *PublishStorageMock {
+	if mmGetTagsAliases.defaultExpectation != nil {
+		mmGetTagsAliases.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetTagsAliases method")
+	}
+
+	if len(mmGetTagsAliases.expectations) > 0 {
+		mmGetTagsAliases.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetTagsAliases method")
+	}
+
+	mmGetTagsAliases.mock.funcGetTagsAliases = f
+	return mmGetTagsAliases.mock
+}
+
+// GetTagsAliases implements publishStorage
+func (mmGetTagsAliases *PublishStorageMock) GetTagsAliases() (m1 map[string]string) {
+	mm_atomic.AddUint64(&mmGetTagsAliases.beforeGetTagsAliasesCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetTagsAliases.afterGetTagsAliasesCounter, 1)
+
+	if mmGetTagsAliases.inspectFuncGetTagsAliases != nil {
+		mmGetTagsAliases.inspectFuncGetTagsAliases()
+	}
+
+	if mmGetTagsAliases.GetTagsAliasesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetTagsAliases.GetTagsAliasesMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetTagsAliases.GetTagsAliasesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetTagsAliases.t.Fatal("No results are set for the PublishStorageMock.GetTagsAliases")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetTagsAliases.funcGetTagsAliases != nil {
+		return mmGetTagsAliases.funcGetTagsAliases()
+	}
+	mmGetTagsAliases.t.Fatalf("Unexpected call to PublishStorageMock.GetTagsAliases.")
+	return
+}
+
+// GetTagsAliasesAfterCounter returns a count of finished PublishStorageMock.GetTagsAliases invocations
+func (mmGetTagsAliases *PublishStorageMock) GetTagsAliasesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTagsAliases.afterGetTagsAliasesCounter)
+}
+
+// GetTagsAliasesBeforeCounter returns a count of PublishStorageMock.GetTagsAliases invocations
+func (mmGetTagsAliases *PublishStorageMock) GetTagsAliasesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTagsAliases.beforeGetTagsAliasesCounter)
+}
+
+// MinimockGetTagsAliasesDone returns true if the count of the GetTagsAliases invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetTagsAliasesDone() bool {
+	for _, e := range m.GetTagsAliasesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsAliasesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsAliasesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTagsAliases != nil && mm_atomic.LoadUint64(&m.afterGetTagsAliasesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetTagsAliasesInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetTagsAliasesInspect() {
+	for _, e := range m.GetTagsAliasesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetTagsAliases")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsAliasesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsAliasesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetTagsAliases")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTagsAliases != nil && mm_atomic.LoadUint64(&m.afterGetTagsAliasesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetTagsAliases")
+	}
+}
+
+type mPublishStorageMockGetTagsIndex struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetTagsIndexExpectation
+	expectations       []*PublishStorageMockGetTagsIndexExpectation
+}
+
+// PublishStorageMockGetTagsIndexExpectation specifies expectation struct of the publishStorage.GetTagsIndex
+type PublishStorageMockGetTagsIndexExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetTagsIndexResults
+	Counter uint64
+}
+
+// PublishStorageMockGetTagsIndexResults contains results of the publishStorage.GetTagsIndex
+type PublishStorageMockGetTagsIndexResults struct {
+	tpa1 []*storage.TagsIndexMessage
+}
+
+// Expect sets up expected params for publishStorage.GetTagsIndex
+func (mmGetTagsIndex *mPublishStorageMockGetTagsIndex) Expect() *mPublishStorageMockGetTagsIndex {
+	if mmGetTagsIndex.mock.funcGetTagsIndex != nil {
+		mmGetTagsIndex.mock.t.Fatalf("PublishStorageMock.GetTagsIndex mock is already set by Set")
+	}
+
+	if mmGetTagsIndex.defaultExpectation == nil {
+		mmGetTagsIndex.defaultExpectation = &PublishStorageMockGetTagsIndexExpectation{}
+	}
+
+	return mmGetTagsIndex
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetTagsIndex
+func (mmGetTagsIndex *mPublishStorageMockGetTagsIndex) Inspect(f func()) *mPublishStorageMockGetTagsIndex {
+	if mmGetTagsIndex.mock.inspectFuncGetTagsIndex != nil {
+		mmGetTagsIndex.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetTagsIndex")
+	}
+
+	mmGetTagsIndex.mock.inspectFuncGetTagsIndex = f
+
+	return mmGetTagsIndex
+}
+
+// Return sets up results that will be returned by publishStorage.GetTagsIndex
+func (mmGetTagsIndex *mPublishStorageMockGetTagsIndex) Return(tpa1 []*storage.TagsIndexMessage) *PublishStorageMock {
+	if mmGetTagsIndex.mock.funcGetTagsIndex != nil {
+		mmGetTagsIndex.mock.t.Fatalf("PublishStorageMock.GetTagsIndex mock is already set by Set")
+	}
+
+	if mmGetTagsIndex.defaultExpectation == nil {
+		mmGetTagsIndex.defaultExpectation = &PublishStorageMockGetTagsIndexExpectation{mock: mmGetTagsIndex.mock}
+	}
+	mmGetTagsIndex.defaultExpectation.results = &PublishStorageMockGetTagsIndexResults{tpa1}
+	return mmGetTagsIndex.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetTagsIndex method
+func (mmGetTagsIndex *mPublishStorageMockGetTagsIndex) Set(f func() (tpa1 []*storage.TagsIndexMessage)) *PublishStorageMock {
+	if mmGetTagsIndex.defaultExpectation != nil {
+		mmGetTagsIndex.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetTagsIndex method")
+	}
+
+	if len(mmGetTagsIndex.expectations) > 0 {
+		mmGetTagsIndex.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetTagsIndex method")
+	}
+
+	mmGetTagsIndex.mock.funcGetTagsIndex = f
+	return mmGetTagsIndex.mock
+}
+
+// GetTagsIndex implements publishStorage
+func (mmGetTagsIndex *PublishStorageMock) GetTagsIndex() (tpa1 []*storage.TagsIndexMessage) {
+	mm_atomic.AddUint64(&mmGetTagsIndex.beforeGetTagsIndexCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetTagsIndex.afterGetTagsIndexCounter, 1)
+
+	if mmGetTagsIndex.inspectFuncGetTagsIndex != nil {
+		mmGetTagsIndex.inspectFuncGetTagsIndex()
+	}
+
+	if mmGetTagsIndex.GetTagsIndexMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetTagsIndex.GetTagsIndexMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetTagsIndex.GetTagsIndexMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetTagsIndex.t.Fatal("No results are set for the PublishStorageMock.GetTagsIndex")
+		}
+		return (*mm_results).tpa1
+	}
+	if mmGetTagsIndex.funcGetTagsIndex != nil {
+		return mmGetTagsIndex.funcGetTagsIndex()
+	}
+	mmGetTagsIndex.t.Fatalf("Unexpected call to PublishStorageMock.GetTagsIndex.")
+	return
+}
+
+// GetTagsIndexAfterCounter returns a count of finished PublishStorageMock.GetTagsIndex invocations
+func (mmGetTagsIndex *PublishStorageMock) GetTagsIndexAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTagsIndex.afterGetTagsIndexCounter)
+}
+
+// GetTagsIndexBeforeCounter returns a count of PublishStorageMock.GetTagsIndex invocations
+func (mmGetTagsIndex *PublishStorageMock) GetTagsIndexBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetTagsIndex.beforeGetTagsIndexCounter)
+}
+
+// MinimockGetTagsIndexDone returns true if the count of the GetTagsIndex invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetTagsIndexDone() bool {
+	for _, e := range m.GetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetTagsIndexInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetTagsIndexInspect() {
+	for _, e := range m.GetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetTagsIndex")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetTagsIndex")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterGetTagsIndexCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetTagsIndex")
+	}
+}
+
+type mPublishStorageMockGetUserRoles struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockGetUserRolesExpectation
+	expectations       []*PublishStorageMockGetUserRolesExpectation
+}
+
+// PublishStorageMockGetUserRolesExpectation specifies expectation struct of the publishStorage.GetUserRoles
+type PublishStorageMockGetUserRolesExpectation struct {
+	mock *PublishStorageMock
+
+	results *PublishStorageMockGetUserRolesResults
+	Counter uint64
+}
+
+// PublishStorageMockGetUserRolesResults contains results of the publishStorage.GetUserRoles
+type PublishStorageMockGetUserRolesResults struct {
+	m1 map[int]string
+}
+
+// Expect sets up expected params for publishStorage.GetUserRoles
+func (mmGetUserRoles *mPublishStorageMockGetUserRoles) Expect() *mPublishStorageMockGetUserRoles {
+	if mmGetUserRoles.mock.funcGetUserRoles != nil {
+		mmGetUserRoles.mock.t.Fatalf("PublishStorageMock.GetUserRoles mock is already set by Set")
+	}
+
+	if mmGetUserRoles.defaultExpectation == nil {
+		mmGetUserRoles.defaultExpectation = &PublishStorageMockGetUserRolesExpectation{}
+	}
+
+	return mmGetUserRoles
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.GetUserRoles
+func (mmGetUserRoles *mPublishStorageMockGetUserRoles) Inspect(f func()) *mPublishStorageMockGetUserRoles {
+	if mmGetUserRoles.mock.inspectFuncGetUserRoles != nil {
+		mmGetUserRoles.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.GetUserRoles")
+	}
+
+	mmGetUserRoles.mock.inspectFuncGetUserRoles = f
+
+	return mmGetUserRoles
+}
+
+// Return sets up results that will be returned by publishStorage.GetUserRoles
+func (mmGetUserRoles *mPublishStorageMockGetUserRoles) Return(m1 map[int]string) *PublishStorageMock {
+	if mmGetUserRoles.mock.funcGetUserRoles != nil {
+		mmGetUserRoles.mock.t.Fatalf("PublishStorageMock.GetUserRoles mock is already set by Set")
+	}
+
+	if mmGetUserRoles.defaultExpectation == nil {
+		mmGetUserRoles.defaultExpectation = &PublishStorageMockGetUserRolesExpectation{mock: mmGetUserRoles.mock}
+	}
+	mmGetUserRoles.defaultExpectation.results = &PublishStorageMockGetUserRolesResults{m1}
+	return mmGetUserRoles.mock
+}
+
+// Set uses given function f to mock the publishStorage.GetUserRoles method
+func (mmGetUserRoles *mPublishStorageMockGetUserRoles) Set(f func() (m1 map[int]string)) *PublishStorageMock {
+	if mmGetUserRoles.defaultExpectation != nil {
+		mmGetUserRoles.mock.t.Fatalf("Default expectation is already set for the publishStorage.GetUserRoles method")
+	}
+
+	if len(mmGetUserRoles.expectations) > 0 {
+		mmGetUserRoles.mock.t.Fatalf("Some expectations are already set for the publishStorage.GetUserRoles method")
+	}
+
+	mmGetUserRoles.mock.funcGetUserRoles = f
+	return mmGetUserRoles.mock
+}
+
+// GetUserRoles implements publishStorage
+func (mmGetUserRoles *PublishStorageMock) GetUserRoles() (m1 map[int]string) {
+	mm_atomic.AddUint64(&mmGetUserRoles.beforeGetUserRolesCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetUserRoles.afterGetUserRolesCounter, 1)
+
+	if mmGetUserRoles.inspectFuncGetUserRoles != nil {
+		mmGetUserRoles.inspectFuncGetUserRoles()
+	}
+
+	if mmGetUserRoles.GetUserRolesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetUserRoles.GetUserRolesMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmGetUserRoles.GetUserRolesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetUserRoles.t.Fatal("No results are set for the PublishStorageMock.GetUserRoles")
+		}
+		return (*mm_results).m1
+	}
+	if mmGetUserRoles.funcGetUserRoles != nil {
+		return mmGetUserRoles.funcGetUserRoles()
+	}
+	mmGetUserRoles.t.Fatalf("Unexpected call to PublishStorageMock.GetUserRoles.")
+	return
+}
+
+// GetUserRolesAfterCounter returns a count of finished PublishStorageMock.GetUserRoles invocations
+func (mmGetUserRoles *PublishStorageMock) GetUserRolesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetUserRoles.afterGetUserRolesCounter)
+}
+
+// GetUserRolesBeforeCounter returns a count of PublishStorageMock.GetUserRoles invocations
+func (mmGetUserRoles *PublishStorageMock) GetUserRolesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetUserRoles.beforeGetUserRolesCounter)
+}
+
+// MinimockGetUserRolesDone returns true if the count of the GetUserRoles invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockGetUserRolesDone() bool {
+	for _, e := range m.GetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetUserRoles != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetUserRolesInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockGetUserRolesInspect() {
+	for _, e := range m.GetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to PublishStorageMock.GetUserRoles")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetUserRoles")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetUserRoles != nil && mm_atomic.LoadUint64(&m.afterGetUserRolesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.GetUserRoles")
+	}
+}
+
+type mPublishStorageMockSetPendingTypos struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockSetPendingTyposExpectation
+	expectations       []*PublishStorageMockSetPendingTyposExpectation
+
+	callArgs []*PublishStorageMockSetPendingTyposParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockSetPendingTyposExpectation specifies expectation struct of the publishStorage.SetPendingTypos
+type PublishStorageMockSetPendingTyposExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockSetPendingTyposParams
+
+	Counter uint64
+}
+
+// PublishStorageMockSetPendingTyposParams contains parameters of the publishStorage.SetPendingTypos
+type PublishStorageMockSetPendingTyposParams struct {
+	m1 map[string]*storage.PendingTypos
+}
+
+// Expect sets up expected params for publishStorage.SetPendingTypos
+func (mmSetPendingTypos *mPublishStorageMockSetPendingTypos) Expect(m1 map[string]*storage.PendingTypos) *mPublishStorageMockSetPendingTypos {
+	if mmSetPendingTypos.mock.funcSetPendingTypos != nil {
+		mmSetPendingTypos.mock.t.Fatalf("PublishStorageMock.SetPendingTypos mock is already set by Set")
+	}
+
+	if mmSetPendingTypos.defaultExpectation == nil {
+		mmSetPendingTypos.defaultExpectation = &PublishStorageMockSetPendingTyposExpectation{}
+	}
+
+	mmSetPendingTypos.defaultExpectation.params = &PublishStorageMockSetPendingTyposParams{m1}
+	for _, e := range mmSetPendingTypos.expectations {
+		if minimock.Equal(e.params, mmSetPendingTypos.defaultExpectation.params) {
+			mmSetPendingTypos.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetPendingTypos.defaultExpectation.params)
+		}
+	}
+
+	return mmSetPendingTypos
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.SetPendingTypos
+func (mmSetPendingTypos *mPublishStorageMockSetPendingTypos) Inspect(f func(m1 map[string]*storage.PendingTypos)) *mPublishStorageMockSetPendingTypos {
+	if mmSetPendingTypos.mock.inspectFuncSetPendingTypos != nil {
+		mmSetPendingTypos.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.SetPendingTypos")
+	}
+
+	mmSetPendingTypos.mock.inspectFuncSetPendingTypos = f
+
+	return mmSetPendingTypos
+}
+
+// Return sets up results that will be returned by publishStorage.SetPendingTypos
+func (mmSetPendingTypos *mPublishStorageMockSetPendingTypos) Return() *PublishStorageMock {
+	if mmSetPendingTypos.mock.funcSetPendingTypos != nil {
+		mmSetPendingTypos.mock.t.Fatalf("PublishStorageMock.SetPendingTypos mock is already set by Set")
+	}
+
+	if mmSetPendingTypos.defaultExpectation == nil {
+		mmSetPendingTypos.defaultExpectation = &PublishStorageMockSetPendingTyposExpectation{mock: mmSetPendingTypos.mock}
+	}
+
+	return mmSetPendingTypos.mock
+}
+
+// Set uses given function f to mock the publishStorage.SetPendingTypos method
+func (mmSetPendingTypos *mPublishStorageMockSetPendingTypos) Set(f func(m1 map[string]*storage.PendingTypos)) *PublishStorageMock {
+	if mmSetPendingTypos.defaultExpectation != nil {
+		mmSetPendingTypos.mock.t.Fatalf("Default expectation is already set for the publishStorage.SetPendingTypos method")
+	}
+
+	if len(mmSetPendingTypos.expectations) > 0 {
+		mmSetPendingTypos.mock.t.Fatalf("Some expectations are already set for the publishStorage.SetPendingTypos method")
+	}
+
+	mmSetPendingTypos.mock.funcSetPendingTypos = f
+	return mmSetPendingTypos.mock
+}
+
+// SetPendingTypos implements publishStorage
+func (mmSetPendingTypos *PublishStorageMock) SetPendingTypos(m1 map[string]*storage.PendingTypos) {
+	mm_atomic.AddUint64(&mmSetPendingTypos.beforeSetPendingTyposCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetPendingTypos.afterSetPendingTyposCounter, 1)
+
+	if mmSetPendingTypos.inspectFuncSetPendingTypos != nil {
+		mmSetPendingTypos.inspectFuncSetPendingTypos(m1)
+	}
+
+	mm_params := &PublishStorageMockSetPendingTyposParams{m1}
+
+	// Record call args
+	mmSetPendingTypos.SetPendingTyposMock.mutex.Lock()
+	mmSetPendingTypos.SetPendingTyposMock.callArgs = append(mmSetPendingTypos.SetPendingTyposMock.callArgs, mm_params)
+	mmSetPendingTypos.SetPendingTyposMock.mutex.Unlock()
+
+	for _, e := range mmSetPendingTypos.SetPendingTyposMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetPendingTypos.SetPendingTyposMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetPendingTypos.SetPendingTyposMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetPendingTypos.SetPendingTyposMock.defaultExpectation.params
+		mm_got := PublishStorageMockSetPendingTyposParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetPendingTypos.t.Errorf("PublishStorageMock.SetPendingTypos got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetPendingTypos.funcSetPendingTypos != nil {
+		mmSetPendingTypos.funcSetPendingTypos(m1)
+		return
+	}
+	mmSetPendingTypos.t.Fatalf("Unexpected call to PublishStorageMock.SetPendingTypos. %v", m1)
+
+}
+
+// SetPendingTyposAfterCounter returns a count of finished PublishStorageMock.SetPendingTypos invocations
+func (mmSetPendingTypos *PublishStorageMock) SetPendingTyposAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetPendingTypos.afterSetPendingTyposCounter)
+}
+
+// SetPendingTyposBeforeCounter returns a count of PublishStorageMock.SetPendingTypos invocations
+func (mmSetPendingTypos *PublishStorageMock) SetPendingTyposBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetPendingTypos.beforeSetPendingTyposCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.SetPendingTypos.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetPendingTypos *mPublishStorageMockSetPendingTypos) Calls() []*PublishStorageMockSetPendingTyposParams {
+	mmSetPendingTypos.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockSetPendingTyposParams, len(mmSetPendingTypos.callArgs))
+	copy(argCopy, mmSetPendingTypos.callArgs)
+
+	mmSetPendingTypos.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetPendingTyposDone returns true if the count of the SetPendingTypos invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockSetPendingTyposDone() bool {
+	for _, e := range m.SetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetPendingTyposInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockSetPendingTyposInspect() {
+	for _, e := range m.SetPendingTyposMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.SetPendingTypos with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetPendingTyposMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		if m.SetPendingTyposMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.SetPendingTypos")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.SetPendingTypos with params: %#v", *m.SetPendingTyposMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetPendingTypos != nil && mm_atomic.LoadUint64(&m.afterSetPendingTyposCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.SetPendingTypos")
+	}
+}
+
+type mPublishStorageMockSetTags struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockSetTagsExpectation
+	expectations       []*PublishStorageMockSetTagsExpectation
+
+	callArgs []*PublishStorageMockSetTagsParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockSetTagsExpectation specifies expectation struct of the publishStorage.SetTags
+type PublishStorageMockSetTagsExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockSetTagsParams
+
+	Counter uint64
+}
+
+// PublishStorageMockSetTagsParams contains parameters of the publishStorage.SetTags
+type PublishStorageMockSetTagsParams struct {
+	sa1 []string
+}
+
+// Expect sets up expected params for publishStorage.SetTags
+func (mmSetTags *mPublishStorageMockSetTags) Expect(sa1 []string) *mPublishStorageMockSetTags {
+	if mmSetTags.mock.funcSetTags != nil {
+		mmSetTags.mock.t.Fatalf("PublishStorageMock.SetTags mock is already set by Set")
+	}
+
+	if mmSetTags.defaultExpectation == nil {
+		mmSetTags.defaultExpectation = &PublishStorageMockSetTagsExpectation{}
+	}
+
+	mmSetTags.defaultExpectation.params = &PublishStorageMockSetTagsParams{sa1}
+	for _, e := range mmSetTags.expectations {
+		if minimock.Equal(e.params, mmSetTags.defaultExpectation.params) {
+			mmSetTags.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetTags.defaultExpectation.params)
+		}
+	}
+
+	return mmSetTags
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.SetTags
+func (mmSetTags *mPublishStorageMockSetTags) Inspect(f func(sa1 []string)) *mPublishStorageMockSetTags {
+	if mmSetTags.mock.inspectFuncSetTags != nil {
+		mmSetTags.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.SetTags")
+	}
+
+	mmSetTags.mock.inspectFuncSetTags = f
+
+	return mmSetTags
+}
+
+// Return sets up results that will be returned by publishStorage.SetTags
+func (mmSetTags *mPublishStorageMockSetTags) Return() *PublishStorageMock {
+	if mmSetTags.mock.funcSetTags != nil {
+		mmSetTags.mock.t.Fatalf("PublishStorageMock.SetTags mock is already set by Set")
+	}
+
+	if mmSetTags.defaultExpectation == nil {
+		mmSetTags.defaultExpectation = &PublishStorageMockSetTagsExpectation{mock: mmSetTags.mock}
+	}
+
+	return mmSetTags.mock
+}
+
+// Set uses given function f to mock the publishStorage.SetTags method
+func (mmSetTags *mPublishStorageMockSetTags) Set(f func(sa1 []string)) *PublishStorageMock {
+	if mmSetTags.defaultExpectation != nil {
+		mmSetTags.mock.t.Fatalf("Default expectation is already set for the publishStorage.SetTags method")
+	}
+
+	if len(mmSetTags.expectations) > 0 {
+		mmSetTags.mock.t.Fatalf("Some expectations are already set for the publishStorage.SetTags method")
+	}
+
+	mmSetTags.mock.funcSetTags = f
+	return mmSetTags.mock
+}
+
+// SetTags implements publishStorage
+func (mmSetTags *PublishStorageMock) SetTags(sa1 []string) {
+	mm_atomic.AddUint64(&mmSetTags.beforeSetTagsCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetTags.afterSetTagsCounter, 1)
+
+	if mmSetTags.inspectFuncSetTags != nil {
+		mmSetTags.inspectFuncSetTags(sa1)
+	}
+
+	mm_params := &PublishStorageMockSetTagsParams{sa1}
+
+	// Record call args
+	mmSetTags.SetTagsMock.mutex.Lock()
+	mmSetTags.SetTagsMock.callArgs = append(mmSetTags.SetTagsMock.callArgs, mm_params)
+	mmSetTags.SetTagsMock.mutex.Unlock()
+
+	for _, e := range mmSetTags.SetTagsMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetTags.SetTagsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetTags.SetTagsMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetTags.SetTagsMock.defaultExpectation.params
+		mm_got := PublishStorageMockSetTagsParams{sa1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetTags.t.Errorf("PublishStorageMock.SetTags got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetTags.funcSetTags != nil {
+		mmSetTags.funcSetTags(sa1)
+		return
+	}
+	mmSetTags.t.Fatalf("Unexpected call to PublishStorageMock.SetTags. %v", sa1)
+
+}
+
+// SetTagsAfterCounter returns a count of finished PublishStorageMock.SetTags invocations
+func (mmSetTags *PublishStorageMock) SetTagsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTags.afterSetTagsCounter)
+}
+
+// SetTagsBeforeCounter returns a count of PublishStorageMock.SetTags invocations
+func (mmSetTags *PublishStorageMock) SetTagsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTags.beforeSetTagsCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.SetTags.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetTags *mPublishStorageMockSetTags) Calls() []*PublishStorageMockSetTagsParams {
+	mmSetTags.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockSetTagsParams, len(mmSetTags.callArgs))
+	copy(argCopy, mmSetTags.callArgs)
+
+	mmSetTags.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetTagsDone returns true if the count of the SetTags invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockSetTagsDone() bool {
+	for _, e := range m.SetTagsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTags != nil && mm_atomic.LoadUint64(&m.afterSetTagsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetTagsInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockSetTagsInspect() {
+	for _, e := range m.SetTagsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.SetTags with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsCounter) < 1 {
+		if m.SetTagsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.SetTags")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.SetTags with params: %#v", *m.SetTagsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTags != nil && mm_atomic.LoadUint64(&m.afterSetTagsCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.SetTags")
+	}
+}
+
+type mPublishStorageMockSetTagsAliases struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockSetTagsAliasesExpectation
+	expectations       []*PublishStorageMockSetTagsAliasesExpectation
+
+	callArgs []*PublishStorageMockSetTagsAliasesParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockSetTagsAliasesExpectation specifies expectation struct of the publishStorage.SetTagsAliases
+type PublishStorageMockSetTagsAliasesExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockSetTagsAliasesParams
+
+	Counter uint64
+}
+
+// PublishStorageMockSetTagsAliasesParams contains parameters of the publishStorage.SetTagsAliases
+type PublishStorageMockSetTagsAliasesParams struct {
+	m1 map[string]string
+}
+
+// Expect sets up expected params for publishStorage.SetTagsAliases
+func (mmSetTagsAliases *mPublishStorageMockSetTagsAliases) Expect(m1 map[string]string) *mPublishStorageMockSetTagsAliases {
+	if mmSetTagsAliases.mock.funcSetTagsAliases != nil {
+		mmSetTagsAliases.mock.t.Fatalf("PublishStorageMock.SetTagsAliases mock is already set by Set")
+	}
+
+	if mmSetTagsAliases.defaultExpectation == nil {
+		mmSetTagsAliases.defaultExpectation = &PublishStorageMockSetTagsAliasesExpectation{}
+	}
+
+	mmSetTagsAliases.defaultExpectation.params = &PublishStorageMockSetTagsAliasesParams{m1}
+	for _, e := range mmSetTagsAliases.expectations {
+		if minimock.Equal(e.params, mmSetTagsAliases.defaultExpectation.params) {
+			mmSetTagsAliases.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetTagsAliases.defaultExpectation.params)
+		}
+	}
+
+	return mmSetTagsAliases
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.SetTagsAliases
+func (mmSetTagsAliases *mPublishStorageMockSetTagsAliases) Inspect(f func(m1 map[string]string)) *mPublishStorageMockSetTagsAliases {
+	if mmSetTagsAliases.mock.inspectFuncSetTagsAliases != nil {
+		mmSetTagsAliases.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.SetTagsAliases")
+	}
+
+	mmSetTagsAliases.mock.inspectFuncSetTagsAliases = f
+
+	return mmSetTagsAliases
+}
+
+// Return sets up results that will be returned by publishStorage.SetTagsAliases
+func (mmSetTagsAliases *mPublishStorageMockSetTagsAliases) Return() *PublishStorageMock {
+	if mmSetTagsAliases.mock.funcSetTagsAliases != nil {
+		mmSetTagsAliases.mock.t.Fatalf("PublishStorageMock.SetTagsAliases mock is already set by Set")
+	}
+
+	if mmSetTagsAliases.defaultExpectation == nil {
+		mmSetTagsAliases.defaultExpectation = &PublishStorageMockSetTagsAliasesExpectation{mock: mmSetTagsAliases.mock}
+	}
+
+	return mmSetTagsAliases.mock
+}
+
+// Set uses given function f to mock the publishStorage.SetTagsAliases method
+func (mmSetTagsAliases *mPublishStorageMockSetTagsAliases) Set(f func(m1 map[string]string)) *PublishStorageMock {
+	if mmSetTagsAliases.defaultExpectation != nil {
+		mmSetTagsAliases.mock.t.Fatalf("Default expectation is already set for the publishStorage.SetTagsAliases method")
+	}
+
+	if len(mmSetTagsAliases.expectations) > 0 {
+		mmSetTagsAliases.mock.t.Fatalf("Some expectations are already set for the publishStorage.SetTagsAliases method")
+	}
+
+	mmSetTagsAliases.mock.funcSetTagsAliases = f
+	return mmSetTagsAliases.mock
+}
+
+// SetTagsAliases implements publishStorage
+func (mmSetTagsAliases *PublishStorageMock) SetTagsAliases(m1 map[string]string) {
+	mm_atomic.AddUint64(&mmSetTagsAliases.beforeSetTagsAliasesCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetTagsAliases.afterSetTagsAliasesCounter, 1)
+
+	if mmSetTagsAliases.inspectFuncSetTagsAliases != nil {
+		mmSetTagsAliases.inspectFuncSetTagsAliases(m1)
+	}
+
+	mm_params := &PublishStorageMockSetTagsAliasesParams{m1}
+
+	// Record call args
+	mmSetTagsAliases.SetTagsAliasesMock.mutex.Lock()
+	mmSetTagsAliases.SetTagsAliasesMock.callArgs = append(mmSetTagsAliases.SetTagsAliasesMock.callArgs, mm_params)
+	mmSetTagsAliases.SetTagsAliasesMock.mutex.Unlock()
+
+	for _, e := range mmSetTagsAliases.SetTagsAliasesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetTagsAliases.SetTagsAliasesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetTagsAliases.SetTagsAliasesMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetTagsAliases.SetTagsAliasesMock.defaultExpectation.params
+		mm_got := PublishStorageMockSetTagsAliasesParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetTagsAliases.t.Errorf("PublishStorageMock.SetTagsAliases got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetTagsAliases.funcSetTagsAliases != nil {
+		mmSetTagsAliases.funcSetTagsAliases(m1)
+		return
+	}
+	mmSetTagsAliases.t.Fatalf("Unexpected call to PublishStorageMock.SetTagsAliases. %v", m1)
+
+}
+
+// SetTagsAliasesAfterCounter returns a count of finished PublishStorageMock.SetTagsAliases invocations
+func (mmSetTagsAliases *PublishStorageMock) SetTagsAliasesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTagsAliases.afterSetTagsAliasesCounter)
+}
+
+// SetTagsAliasesBeforeCounter returns a count of PublishStorageMock.SetTagsAliases invocations
+func (mmSetTagsAliases *PublishStorageMock) SetTagsAliasesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTagsAliases.beforeSetTagsAliasesCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.SetTagsAliases.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetTagsAliases *mPublishStorageMockSetTagsAliases) Calls() []*PublishStorageMockSetTagsAliasesParams {
+	mmSetTagsAliases.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockSetTagsAliasesParams, len(mmSetTagsAliases.callArgs))
+	copy(argCopy, mmSetTagsAliases.callArgs)
+
+	mmSetTagsAliases.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetTagsAliasesDone returns true if the count of the SetTagsAliases invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockSetTagsAliasesDone() bool {
+	for _, e := range m.SetTagsAliasesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsAliasesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsAliasesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTagsAliases != nil && mm_atomic.LoadUint64(&m.afterSetTagsAliasesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetTagsAliasesInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockSetTagsAliasesInspect() {
+	for _, e := range m.SetTagsAliasesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.SetTagsAliases with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsAliasesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsAliasesCounter) < 1 {
+		if m.SetTagsAliasesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.SetTagsAliases")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.SetTagsAliases with params: %#v", *m.SetTagsAliasesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTagsAliases != nil && mm_atomic.LoadUint64(&m.afterSetTagsAliasesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.SetTagsAliases")
+	}
+}
+
+type mPublishStorageMockSetTagsIndex struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockSetTagsIndexExpectation
+	expectations       []*PublishStorageMockSetTagsIndexExpectation
+
+	callArgs []*PublishStorageMockSetTagsIndexParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockSetTagsIndexExpectation specifies expectation struct of the publishStorage.SetTagsIndex
+type PublishStorageMockSetTagsIndexExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockSetTagsIndexParams
+
+	Counter uint64
+}
+
+// PublishStorageMockSetTagsIndexParams contains parameters of the publishStorage.SetTagsIndex
+type PublishStorageMockSetTagsIndexParams struct {
+	tpa1 []*storage.TagsIndexMessage
+}
+
+// Expect sets up expected params for publishStorage.SetTagsIndex
+func (mmSetTagsIndex *mPublishStorageMockSetTagsIndex) Expect(tpa1 []*storage.TagsIndexMessage) *mPublishStorageMockSetTagsIndex {
+	if mmSetTagsIndex.mock.funcSetTagsIndex != nil {
+		mmSetTagsIndex.mock.t.Fatalf("PublishStorageMock.SetTagsIndex mock is already set by Set")
+	}
+
+	if mmSetTagsIndex.defaultExpectation == nil {
+		mmSetTagsIndex.defaultExpectation = &PublishStorageMockSetTagsIndexExpectation{}
+	}
+
+	mmSetTagsIndex.defaultExpectation.params = &PublishStorageMockSetTagsIndexParams{tpa1}
+	for _, e := range mmSetTagsIndex.expectations {
+		if minimock.Equal(e.params, mmSetTagsIndex.defaultExpectation.params) {
+			mmSetTagsIndex.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetTagsIndex.defaultExpectation.params)
+		}
+	}
+
+	return mmSetTagsIndex
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.SetTagsIndex
+func (mmSetTagsIndex *mPublishStorageMockSetTagsIndex) Inspect(f func(tpa1 []*storage.TagsIndexMessage)) *mPublishStorageMockSetTagsIndex {
+	if mmSetTagsIndex.mock.inspectFuncSetTagsIndex != nil {
+		mmSetTagsIndex.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.SetTagsIndex")
+	}
+
+	mmSetTagsIndex.mock.inspectFuncSetTagsIndex = f
+
+	return mmSetTagsIndex
+}
+
+// Return sets up results that will be returned by publishStorage.SetTagsIndex
+func (mmSetTagsIndex *mPublishStorageMockSetTagsIndex) Return() *PublishStorageMock {
+	if mmSetTagsIndex.mock.funcSetTagsIndex != nil {
+		mmSetTagsIndex.mock.t.Fatalf("PublishStorageMock.SetTagsIndex mock is already set by Set")
+	}
+
+	if mmSetTagsIndex.defaultExpectation == nil {
+		mmSetTagsIndex.defaultExpectation = &PublishStorageMockSetTagsIndexExpectation{mock: mmSetTagsIndex.mock}
+	}
+
+	return mmSetTagsIndex.mock
+}
+
+// Set uses given function f to mock the publishStorage.SetTagsIndex method
+func (mmSetTagsIndex *mPublishStorageMockSetTagsIndex) Set(f func(tpa1 []*storage.TagsIndexMessage)) *PublishStorageMock {
+	if mmSetTagsIndex.defaultExpectation != nil {
+		mmSetTagsIndex.mock.t.Fatalf("Default expectation is already set for the publishStorage.SetTagsIndex method")
+	}
+
+	if len(mmSetTagsIndex.expectations) > 0 {
+		mmSetTagsIndex.mock.t.Fatalf("Some expectations are already set for the publishStorage.SetTagsIndex method")
+	}
+
+	mmSetTagsIndex.mock.funcSetTagsIndex = f
+	return mmSetTagsIndex.mock
+}
+
+// SetTagsIndex implements publishStorage
+func (mmSetTagsIndex *PublishStorageMock) SetTagsIndex(tpa1 []*storage.TagsIndexMessage) {
+	mm_atomic.AddUint64(&mmSetTagsIndex.beforeSetTagsIndexCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetTagsIndex.afterSetTagsIndexCounter, 1)
+
+	if mmSetTagsIndex.inspectFuncSetTagsIndex != nil {
+		mmSetTagsIndex.inspectFuncSetTagsIndex(tpa1)
+	}
+
+	mm_params := &PublishStorageMockSetTagsIndexParams{tpa1}
+
+	// Record call args
+	mmSetTagsIndex.SetTagsIndexMock.mutex.Lock()
+	mmSetTagsIndex.SetTagsIndexMock.callArgs = append(mmSetTagsIndex.SetTagsIndexMock.callArgs, mm_params)
+	mmSetTagsIndex.SetTagsIndexMock.mutex.Unlock()
+
+	for _, e := range mmSetTagsIndex.SetTagsIndexMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetTagsIndex.SetTagsIndexMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetTagsIndex.SetTagsIndexMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetTagsIndex.SetTagsIndexMock.defaultExpectation.params
+		mm_got := PublishStorageMockSetTagsIndexParams{tpa1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetTagsIndex.t.Errorf("PublishStorageMock.SetTagsIndex got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetTagsIndex.funcSetTagsIndex != nil {
+		mmSetTagsIndex.funcSetTagsIndex(tpa1)
+		return
+	}
+	mmSetTagsIndex.t.Fatalf("Unexpected call to PublishStorageMock.SetTagsIndex. %v", tpa1)
+
+}
+
+// SetTagsIndexAfterCounter returns a count of finished PublishStorageMock.SetTagsIndex invocations
+func (mmSetTagsIndex *PublishStorageMock) SetTagsIndexAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTagsIndex.afterSetTagsIndexCounter)
+}
+
+// SetTagsIndexBeforeCounter returns a count of PublishStorageMock.SetTagsIndex invocations
+func (mmSetTagsIndex *PublishStorageMock) SetTagsIndexBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetTagsIndex.beforeSetTagsIndexCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.SetTagsIndex.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetTagsIndex *mPublishStorageMockSetTagsIndex) Calls() []*PublishStorageMockSetTagsIndexParams {
+	mmSetTagsIndex.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockSetTagsIndexParams, len(mmSetTagsIndex.callArgs))
+	copy(argCopy, mmSetTagsIndex.callArgs)
+
+	mmSetTagsIndex.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetTagsIndexDone returns true if the count of the SetTagsIndex invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockSetTagsIndexDone() bool {
+	for _, e := range m.SetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetTagsIndexInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockSetTagsIndexInspect() {
+	for _, e := range m.SetTagsIndexMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.SetTagsIndex with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetTagsIndexMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		if m.SetTagsIndexMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.SetTagsIndex")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.SetTagsIndex with params: %#v", *m.SetTagsIndexMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetTagsIndex != nil && mm_atomic.LoadUint64(&m.afterSetTagsIndexCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.SetTagsIndex")
+	}
+}
+
+type mPublishStorageMockSetUserRoles struct {
+	mock               *PublishStorageMock
+	defaultExpectation *PublishStorageMockSetUserRolesExpectation
+	expectations       []*PublishStorageMockSetUserRolesExpectation
+
+	callArgs []*PublishStorageMockSetUserRolesParams
+	mutex    sync.RWMutex
+}
+
+// PublishStorageMockSetUserRolesExpectation specifies expectation struct of the publishStorage.SetUserRoles
+type PublishStorageMockSetUserRolesExpectation struct {
+	mock   *PublishStorageMock
+	params *PublishStorageMockSetUserRolesParams
+
+	Counter uint64
+}
+
+// PublishStorageMockSetUserRolesParams contains parameters of the publishStorage.SetUserRoles
+type PublishStorageMockSetUserRolesParams struct {
+	m1 map[int]string
+}
+
+// Expect sets up expected params for publishStorage.SetUserRoles
+func (mmSetUserRoles *mPublishStorageMockSetUserRoles) Expect(m1 map[int]string) *mPublishStorageMockSetUserRoles {
+	if mmSetUserRoles.mock.funcSetUserRoles != nil {
+		mmSetUserRoles.mock.t.Fatalf("PublishStorageMock.SetUserRoles mock is already set by Set")
+	}
+
+	if mmSetUserRoles.defaultExpectation == nil {
+		mmSetUserRoles.defaultExpectation = &PublishStorageMockSetUserRolesExpectation{}
+	}
+
+	mmSetUserRoles.defaultExpectation.params = &PublishStorageMockSetUserRolesParams{m1}
+	for _, e := range mmSetUserRoles.expectations {
+		if minimock.Equal(e.params, mmSetUserRoles.defaultExpectation.params) {
+			mmSetUserRoles.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetUserRoles.defaultExpectation.params)
+		}
+	}
+
+	return mmSetUserRoles
+}
+
+// Inspect accepts an inspector function that has same arguments as the publishStorage.SetUserRoles
+func (mmSetUserRoles *mPublishStorageMockSetUserRoles) Inspect(f func(m1 map[int]string)) *mPublishStorageMockSetUserRoles {
+	if mmSetUserRoles.mock.inspectFuncSetUserRoles != nil {
+		mmSetUserRoles.mock.t.Fatalf("Inspect function is already set for PublishStorageMock.SetUserRoles")
+	}
+
+	mmSetUserRoles.mock.inspectFuncSetUserRoles = f
+
+	return mmSetUserRoles
+}
+
+// Return sets up results that will be returned by publishStorage.SetUserRoles
+func (mmSetUserRoles *mPublishStorageMockSetUserRoles) Return() *PublishStorageMock {
+	if mmSetUserRoles.mock.funcSetUserRoles != nil {
+		mmSetUserRoles.mock.t.Fatalf("PublishStorageMock.SetUserRoles mock is already set by Set")
+	}
+
+	if mmSetUserRoles.defaultExpectation == nil {
+		mmSetUserRoles.defaultExpectation = &PublishStorageMockSetUserRolesExpectation{mock: mmSetUserRoles.mock}
+	}
+
+	return mmSetUserRoles.mock
+}
+
+// Set uses given function f to mock the publishStorage.SetUserRoles method
+func (mmSetUserRoles *mPublishStorageMockSetUserRoles) Set(f func(m1 map[int]string)) *PublishStorageMock {
+	if mmSetUserRoles.defaultExpectation != nil {
+		mmSetUserRoles.mock.t.Fatalf("Default expectation is already set for the publishStorage.SetUserRoles method")
+	}
+
+	if len(mmSetUserRoles.expectations) > 0 {
+		mmSetUserRoles.mock.t.Fatalf("Some expectations are already set for the publishStorage.SetUserRoles method")
+	}
+
+	mmSetUserRoles.mock.funcSetUserRoles = f
+	return mmSetUserRoles.mock
+}
+
+// SetUserRoles implements publishStorage
+func (mmSetUserRoles *PublishStorageMock) SetUserRoles(m1 map[int]string) {
+	mm_atomic.AddUint64(&mmSetUserRoles.beforeSetUserRolesCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetUserRoles.afterSetUserRolesCounter, 1)
+
+	if mmSetUserRoles.inspectFuncSetUserRoles != nil {
+		mmSetUserRoles.inspectFuncSetUserRoles(m1)
+	}
+
+	mm_params := &PublishStorageMockSetUserRolesParams{m1}
+
+	// Record call args
+	mmSetUserRoles.SetUserRolesMock.mutex.Lock()
+	mmSetUserRoles.SetUserRolesMock.callArgs = append(mmSetUserRoles.SetUserRolesMock.callArgs, mm_params)
+	mmSetUserRoles.SetUserRolesMock.mutex.Unlock()
+
+	for _, e := range mmSetUserRoles.SetUserRolesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmSetUserRoles.SetUserRolesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetUserRoles.SetUserRolesMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetUserRoles.SetUserRolesMock.defaultExpectation.params
+		mm_got := PublishStorageMockSetUserRolesParams{m1}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetUserRoles.t.Errorf("PublishStorageMock.SetUserRoles got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmSetUserRoles.funcSetUserRoles != nil {
+		mmSetUserRoles.funcSetUserRoles(m1)
+		return
+	}
+	mmSetUserRoles.t.Fatalf("Unexpected call to PublishStorageMock.SetUserRoles. %v", m1)
+
+}
+
+// SetUserRolesAfterCounter returns a count of finished PublishStorageMock.SetUserRoles invocations
+func (mmSetUserRoles *PublishStorageMock) SetUserRolesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetUserRoles.afterSetUserRolesCounter)
+}
+
+// SetUserRolesBeforeCounter returns a count of PublishStorageMock.SetUserRoles invocations
+func (mmSetUserRoles *PublishStorageMock) SetUserRolesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetUserRoles.beforeSetUserRolesCounter)
+}
+
+// Calls returns a list of arguments used in each call to PublishStorageMock.SetUserRoles.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetUserRoles *mPublishStorageMockSetUserRoles) Calls() []*PublishStorageMockSetUserRolesParams {
+	mmSetUserRoles.mutex.RLock()
+
+	argCopy := make([]*PublishStorageMockSetUserRolesParams, len(mmSetUserRoles.callArgs))
+	copy(argCopy, mmSetUserRoles.callArgs)
+
+	mmSetUserRoles.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetUserRolesDone returns true if the count of the SetUserRoles invocations corresponds
+// the number of defined expectations
+func (m *PublishStorageMock) MinimockSetUserRolesDone() bool {
+	for _, e := range m.SetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetUserRoles != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSetUserRolesInspect logs each unmet expectation
+func (m *PublishStorageMock) MinimockSetUserRolesInspect() {
+	for _, e := range m.SetUserRolesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to PublishStorageMock.SetUserRoles with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetUserRolesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		if m.SetUserRolesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to PublishStorageMock.SetUserRoles")
+		} else {
+			m.t.Errorf("Expected call to PublishStorageMock.SetUserRoles with params: %#v", *m.SetUserRolesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetUserRoles != nil && mm_atomic.LoadUint64(&m.afterSetUserRolesCounter) < 1 {
+		m.t.Error("Expected call to PublishStorageMock.SetUserRoles")
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *PublishStorageMock) MinimockFinish() {
+	if !m.minimockDone() {
+		m.MinimockAddFavChannelAnimationsInspect()
+
+		m.MinimockAddSentAnimationsInspect()
+
+		m.MinimockAddTagChangesInspect()
+
+		m.MinimockGetFavChannelAnimationsInspect()
+
+		m.MinimockGetPendingTyposInspect()
+
+		m.MinimockGetSentAnimationsInspect()
+
+		m.MinimockGetTagsInspect()
+
+		m.MinimockGetTagsAliasesInspect()
+
+		m.MinimockGetTagsIndexInspect()
+
+		m.MinimockGetUserRolesInspect()
+
+		m.MinimockSetPendingTyposInspect()
+
+		m.MinimockSetTagsInspect()
+
+		m.MinimockSetTagsAliasesInspect()
+
+		m.MinimockSetTagsIndexInspect()
+
+		m.MinimockSetUserRolesInspect()
+		m.t.FailNow()
+	}
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *PublishStorageMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *PublishStorageMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockAddFavChannelAnimationsDone() &&
+		m.MinimockAddSentAnimationsDone() &&
+		m.MinimockAddTagChangesDone() &&
+		m.MinimockGetFavChannelAnimationsDone() &&
+		m.MinimockGetPendingTyposDone() &&
+		m.MinimockGetSentAnimationsDone() &&
+		m.MinimockGetTagsDone() &&
+		m.MinimockGetTagsAliasesDone() &&
+		m.MinimockGetTagsIndexDone() &&
+		m.MinimockGetUserRolesDone() &&
+		m.MinimockSetPendingTyposDone() &&
+		m.MinimockSetTagsDone() &&
+		m.MinimockSetTagsAliasesDone() &&
+		m.MinimockSetTagsIndexDone() &&
+		m.MinimockSetUserRolesDone()
+}
@@ -115,7 +115,13 @@ func (t *TdLibClient) RemoveMessages(chatID int64, messageIDs []int64) error {
 	return nil
 }
 
-func (t *TdLibClient) SendAnimation(chatID int64, fileID string, caption string) (int64, error) {
+// SendAnimation sends gif by remote file id, parseMode is a Bot API one: HTML, MarkdownV2 or empty for plain text
+func (t *TdLibClient) SendAnimation(chatID int64, fileID string, caption string, parseMode string) (int64, error) {
+	text, err := t.formattedText(caption, parseMode)
+	if err != nil {
+		return 0, err
+	}
+
 	msg, err := t.Client.SendMessage(
 		chatID,
 		0,
@@ -124,7 +130,7 @@ func (t *TdLibClient) SendAnimation(chatID int64, fileID string, caption string)
 		nil,
 		&tdlib.InputMessageAnimation{
 			Animation: tdlib.NewInputFileRemote(fileID),
-			Caption:   tdlib.NewFormattedText(caption, nil),
+			Caption:   text,
 		},
 	)
 
@@ -135,6 +141,34 @@ func (t *TdLibClient) SendAnimation(chatID int64, fileID string, caption string)
 	return msg.ID, nil
 }
 
+// textParseModeMarkdownV2 go-tdlib knows only the first markdown version, Bot API MarkdownV2 is version 2
+type textParseModeMarkdownV2 struct {
+	tdlib.TextParseModeMarkdown
+	Version int32 `json:"version"`
+}
+
+// formattedText parses markup the same way Bot API does, so captions look like the bot's ones
+func (t *TdLibClient) formattedText(text string, parseMode string) (*tdlib.FormattedText, error) {
+	var mode tdlib.TextParseMode
+	switch parseMode {
+	case "":
+		return tdlib.NewFormattedText(text, nil), nil
+	case "HTML":
+		mode = tdlib.NewTextParseModeHTML()
+	case "MarkdownV2":
+		mode = &textParseModeMarkdownV2{TextParseModeMarkdown: *tdlib.NewTextParseModeMarkdown(), Version: 2}
+	default:
+		return nil, fmt.Errorf("unknown parse mode '%s'", parseMode)
+	}
+
+	formatted, err := t.Client.ParseTextEntities(text, mode)
+	if err != nil {
+		return nil, fmt.Errorf("parse caption entities: %w", err)
+	}
+
+	return formatted, nil
+}
+
 func (t *TdLibClient) EditMessageCaption(chatID int64, messageID int64, caption string) error {
 	_, err := t.Client.EditMessageCaption(chatID, messageID, nil, tdlib.NewFormattedText(caption, nil))
 	if err != nil {
@@ -9,9 +9,12 @@ require (
 	github.com/go-telegram-bot-api/telegram-bot-api v1.0.1-0.20200811182351-15c95b8a8418
 	github.com/gojuno/minimock/v3 v3.0.8
 	github.com/mitchellh/go-homedir v1.1.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/pflag v1.0.3
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.3.0
 	github.com/technoweenie/multipartstreamer v1.0.1 // indirect
+	go.etcd.io/bbolt v1.3.5
+	golang.org/x/text v0.3.2
 )
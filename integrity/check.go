@@ -0,0 +1,507 @@
+package integrity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+// Kind вид проблемы в базе
+type Kind string
+
+const (
+	// KindUnusedTag тег есть в списке тегов, но ни у одной гифки его нет
+	KindUnusedTag Kind = "unused_tag"
+	// KindMissingTag тег есть у гифки, но его нет в списке тегов
+	KindMissingTag Kind = "missing_tag"
+	// KindMalformedTag тег с пробелами, заглавными буквами, лишними # или пустой
+	KindMalformedTag Kind = "malformed_tag"
+	// KindAliasChain алиас указывает на другой алиас, а не на тег
+	KindAliasChain Kind = "alias_chain"
+	// KindAliasCycle алиасы ссылаются друг на друга по кругу
+	KindAliasCycle Kind = "alias_cycle"
+	// KindDuplicateMessageID несколько гифок ссылаются на одно сообщение в канале
+	KindDuplicateMessageID Kind = "duplicate_message_id"
+	// KindZeroMessageID гифка в базе, но сообщения нет ни в одном канале
+	KindZeroMessageID Kind = "zero_message_id"
+	// KindDescriptionInTags описание гифки записано среди тегов, как хранилось раньше
+	KindDescriptionInTags Kind = "description_in_tags"
+)
+
+// kindsOrder порядок вывода в отчете
+var kindsOrder = []Kind{
+	KindMalformedTag,
+	KindDescriptionInTags,
+	KindMissingTag,
+	KindUnusedTag,
+	KindAliasChain,
+	KindAliasCycle,
+	KindDuplicateMessageID,
+	KindZeroMessageID,
+}
+
+// Problem одна найденная проблема
+type Problem struct {
+	Kind Kind
+	// Key тег, алиас или fileID, к которому относится проблема
+	Key    string
+	Detail string
+	// Fixable проблему можно исправить автоматически, остальные придется решать руками
+	Fixable bool
+	Fixed   bool
+}
+
+func (p Problem) String() string {
+	if p.Detail == "" {
+		return p.Key
+	}
+
+	return fmt.Sprintf("%s: %s", p.Key, p.Detail)
+}
+
+// Report результат проверки
+type Report struct {
+	Problems []Problem
+}
+
+// ByKind проблемы, сгруппированные по виду, в порядке вывода
+func (r *Report) ByKind() ([]Kind, map[Kind][]Problem) {
+	groups := make(map[Kind][]Problem)
+	for _, p := range r.Problems {
+		groups[p.Kind] = append(groups[p.Kind], p)
+	}
+
+	var kinds []Kind
+	for _, kind := range kindsOrder {
+		if len(groups[kind]) > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	return kinds, groups
+}
+
+// Unresolved количество проблем, которые остались после проверки
+func (r *Report) Unresolved() int {
+	count := 0
+	for _, p := range r.Problems {
+		if !p.Fixed {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Check проверяет базу. С fix исправляет то, что можно исправить без участия человека:
+// чинит кривые теги, схлопывает цепочки алиасов и пересобирает список тегов по гифкам.
+// Теги считаются кривыми, если norm их меняет.
+// Вызывать внутри storage.MetaStorage.Update, чтобы исправления применились вместе
+func Check(tx storage.MetaTx, fix bool, norm *tagnorm.Normalizer) *Report {
+	c := newChecker(tx, fix, norm)
+
+	c.checkMalformedTags()
+	c.checkTagsList()
+	c.checkAliases()
+	c.checkMessageIDs()
+
+	if fix {
+		c.save()
+	}
+
+	return c.report
+}
+
+// Renormalize прогоняет все теги гифок, списка тегов и алиасов через norm и пересобирает список тегов.
+// Нужен после изменения правил нормализации. С dryRun только покажет, что изменится
+func Renormalize(tx storage.MetaTx, norm *tagnorm.Normalizer, dryRun bool) *Report {
+	c := newChecker(tx, !dryRun, norm)
+
+	c.checkMalformedTags()
+	c.checkTagsList()
+
+	if c.fix {
+		c.save()
+	}
+
+	return c.report
+}
+
+func newChecker(tx storage.MetaTx, fix bool, norm *tagnorm.Normalizer) *checker {
+	c := &checker{
+		tx:         tx,
+		fix:        fix,
+		norm:       norm,
+		report:     &Report{},
+		tags:       tx.GetTags(),
+		aliases:    tx.GetTagsAliases(),
+		animations: tx.GetSentAnimations(),
+	}
+	if c.aliases == nil {
+		c.aliases = make(map[string]string)
+	}
+
+	return c
+}
+
+type checker struct {
+	tx         storage.MetaTx
+	fix        bool
+	norm       *tagnorm.Normalizer
+	report     *Report
+	tags       []string
+	aliases    map[string]string
+	animations map[string]*storage.SentAnimation
+	// changed гифки, у которых исправлены теги
+	changed        map[string]*storage.SentAnimation
+	tagsChanged    bool
+	aliasesChanged bool
+}
+
+func (c *checker) add(p Problem) {
+	p.Fixed = p.Fixable && c.fix
+	c.report.Problems = append(c.report.Problems, p)
+}
+
+// checkMalformedTags теги у гифок, в списке тегов и в алиасах
+func (c *checker) checkMalformedTags() {
+	c.changed = make(map[string]*storage.SentAnimation)
+
+	for _, fileID := range c.sortedFileIDs() {
+		anim := c.animations[fileID]
+		var fixedTags, description []string
+		hasMalformed := false
+
+		for _, tag := range anim.Tags {
+			// все что без # это описание, переедет в поле описания
+			if !strings.HasPrefix(strings.TrimSpace(tag), "#") {
+				if text := strings.TrimSpace(tag); text != "" {
+					description = append(description, text)
+				}
+
+				continue
+			}
+
+			normalized, ok := c.norm.Tag(tag)
+			if normalized == tag && !contains(fixedTags, tag) {
+				fixedTags = append(fixedTags, tag)
+
+				continue
+			}
+
+			detail := describeFix(tag, normalized, ok)
+			if normalized == tag {
+				// другой тег гифки уже нормализовался в этот
+				detail = fmt.Sprintf("'%s' повторяется", tag)
+			}
+
+			hasMalformed = true
+			c.add(Problem{
+				Kind:    KindMalformedTag,
+				Key:     fileID,
+				Detail:  detail,
+				Fixable: true,
+			})
+			if ok && !contains(fixedTags, normalized) {
+				fixedTags = append(fixedTags, normalized)
+			}
+		}
+
+		if len(description) > 0 {
+			hasMalformed = true
+			c.add(Problem{
+				Kind:    KindDescriptionInTags,
+				Key:     fileID,
+				Detail:  fmt.Sprintf("'%s' будет перенесено в описание", strings.Join(description, " ")),
+				Fixable: true,
+			})
+		}
+
+		if hasMalformed && c.fix {
+			fixed := *anim
+			fixed.Tags = fixedTags
+			if len(description) > 0 {
+				if fixed.Description != "" {
+					description = append([]string{fixed.Description}, description...)
+				}
+				fixed.Description = strings.Join(description, " ")
+			}
+			c.animations[fileID] = &fixed
+			c.changed[fileID] = &fixed
+		}
+	}
+
+	var fixedList []string
+	for _, tag := range c.tags {
+		normalized, ok := c.norm.Tag(tag)
+		if normalized == tag {
+			fixedList = append(fixedList, tag)
+
+			continue
+		}
+
+		c.add(Problem{
+			Kind:    KindMalformedTag,
+			Key:     tag,
+			Detail:  "в списке тегов, " + describeFix(tag, normalized, ok),
+			Fixable: true,
+		})
+		if ok {
+			fixedList = append(fixedList, normalized)
+		}
+		c.tagsChanged = true
+	}
+	if c.fix {
+		c.tags = fixedList
+	}
+
+	fixedAliases := make(map[string]string, len(c.aliases))
+	for _, alias := range sortedKeys(c.aliases) {
+		tag := c.aliases[alias]
+		normalizedAlias, aliasOK := c.norm.Tag(alias)
+		normalizedTag, tagOK := c.norm.Tag(tag)
+		// после нормализации алиас может совпасть с тегом, например #ёжик => #ежик
+		selfAlias := normalizedAlias == normalizedTag
+
+		if normalizedAlias != alias || normalizedTag != tag || selfAlias {
+			c.add(Problem{
+				Kind:    KindMalformedTag,
+				Key:     alias,
+				Detail:  fmt.Sprintf("алиас '%s' => '%s'", alias, tag),
+				Fixable: true,
+			})
+			c.aliasesChanged = true
+		}
+
+		if aliasOK && tagOK && !selfAlias {
+			fixedAliases[normalizedAlias] = normalizedTag
+		}
+	}
+	if c.fix {
+		c.aliases = fixedAliases
+	}
+}
+
+// checkTagsList сверяет список тегов с тегами гифок
+func (c *checker) checkTagsList() {
+	used := make(map[string][]string)
+	for _, fileID := range c.sortedFileIDs() {
+		for _, tag := range c.animations[fileID].Tags {
+			if strings.HasPrefix(tag, "#") {
+				used[tag] = append(used[tag], fileID)
+			}
+		}
+	}
+
+	listed := make(map[string]bool, len(c.tags))
+	for _, tag := range c.tags {
+		listed[tag] = true
+
+		if len(used[tag]) == 0 {
+			c.add(Problem{Kind: KindUnusedTag, Key: tag, Fixable: true})
+		}
+	}
+
+	usedTags := make([]string, 0, len(used))
+	for tag := range used {
+		usedTags = append(usedTags, tag)
+	}
+	sort.Strings(usedTags)
+
+	for _, tag := range usedTags {
+		if listed[tag] {
+			continue
+		}
+
+		c.add(Problem{
+			Kind:    KindMissingTag,
+			Key:     tag,
+			Detail:  "у гифок " + strings.Join(used[tag], ", "),
+			Fixable: true,
+		})
+	}
+
+	if !c.fix {
+		return
+	}
+
+	if !equalStrings(usedTags, c.tags) {
+		c.tags = usedTags
+		c.tagsChanged = true
+	}
+}
+
+// checkAliases ищет цепочки и циклы. Цепочки схлопываются до конечного тега, циклы надо разбирать руками
+func (c *checker) checkAliases() {
+	for _, alias := range sortedKeys(c.aliases) {
+		path := []string{alias}
+		seen := map[string]bool{alias: true}
+		target := c.aliases[alias]
+		cycle := false
+
+		for {
+			next, ok := c.aliases[target]
+			if !ok {
+				break
+			}
+			if seen[target] {
+				cycle = true
+
+				break
+			}
+
+			seen[target] = true
+			path = append(path, target)
+			target = next
+		}
+
+		switch {
+		case cycle:
+			c.add(Problem{
+				Kind:   KindAliasCycle,
+				Key:    alias,
+				Detail: strings.Join(append(path, target), " => "),
+			})
+		case len(path) > 1:
+			c.add(Problem{
+				Kind:    KindAliasChain,
+				Key:     alias,
+				Detail:  strings.Join(append(path, target), " => "),
+				Fixable: true,
+			})
+			if c.fix {
+				c.aliases[alias] = target
+				c.aliasesChanged = true
+			}
+		}
+	}
+}
+
+// messageKey сообщение в канале, chatID 0 - основной канал
+type messageKey struct {
+	chatID    int64
+	messageID int
+}
+
+func (k messageKey) String() string {
+	if k.chatID == 0 {
+		return fmt.Sprintf("#%d", k.messageID)
+	}
+
+	return fmt.Sprintf("%d#%d", k.chatID, k.messageID)
+}
+
+func (c *checker) checkMessageIDs() {
+	byMessage := make(map[messageKey][]string)
+
+	for _, fileID := range c.sortedFileIDs() {
+		anim := c.animations[fileID]
+		if anim.MessageID == 0 && len(anim.ChannelMessages) == 0 {
+			c.add(Problem{
+				Kind:   KindZeroMessageID,
+				Key:    fileID,
+				Detail: strings.Join(anim.Tags, " "),
+			})
+
+			continue
+		}
+
+		if anim.MessageID != 0 {
+			key := messageKey{messageID: anim.MessageID}
+			byMessage[key] = append(byMessage[key], fileID)
+		}
+		for chatID, messageID := range anim.ChannelMessages {
+			key := messageKey{chatID: chatID, messageID: messageID}
+			byMessage[key] = append(byMessage[key], fileID)
+		}
+	}
+
+	keys := make([]messageKey, 0, len(byMessage))
+	for key, fileIDs := range byMessage {
+		if len(fileIDs) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].chatID == keys[j].chatID {
+			return keys[i].messageID < keys[j].messageID
+		}
+
+		return keys[i].chatID < keys[j].chatID
+	})
+
+	for _, key := range keys {
+		c.add(Problem{
+			Kind:   KindDuplicateMessageID,
+			Key:    key.String(),
+			Detail: strings.Join(byMessage[key], ", "),
+		})
+	}
+}
+
+func (c *checker) save() {
+	if len(c.changed) > 0 {
+		c.tx.AddSentAnimations(c.changed)
+	}
+	if c.tagsChanged {
+		c.tx.SetTags(c.tags)
+	}
+	if c.aliasesChanged {
+		c.tx.SetTagsAliases(c.aliases)
+	}
+}
+
+func (c *checker) sortedFileIDs() []string {
+	ids := make([]string, 0, len(c.animations))
+	for id := range c.animations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func describeFix(tag, normalized string, ok bool) string {
+	if !ok {
+		return fmt.Sprintf("'%s' будет удален", tag)
+	}
+
+	return fmt.Sprintf("'%s' => '%s'", tag, normalized)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,155 @@
+package integrity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+var defaultNormalizer = tagnorm.New(config.TagNormalization{})
+
+func newTestStorage(t *testing.T) storage.MetaStorage {
+	store, err := storage.NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+
+	store.SetTags([]string{"#cat", "#Dog", "#unused"})
+	store.SetTagsAliases(map[string]string{
+		"#kot":    "#koshka",
+		"#koshka": "#cat",
+		"#a":      "#b",
+		"#b":      "#a",
+	})
+	store.AddSentAnimations(map[string]*storage.SentAnimation{
+		"file_1": {MessageID: 1, FileID: "file_1", Tags: []string{"#cat", "funny cat"}},
+		"file_2": {MessageID: 1, FileID: "file_2", Tags: []string{"#Dog", "#cat"}},
+		"file_3": {MessageID: 0, FileID: "file_3", Tags: []string{"#bird", "#"}},
+	})
+
+	return store
+}
+
+func TestCheck(t *testing.T) {
+	store := newTestStorage(t)
+	defer store.Close()
+
+	// в другом канале id сообщений свои, с #1 основного канала не пересекаются
+	store.AddSentAnimations(map[string]*storage.SentAnimation{
+		"file_4": {FileID: "file_4", Tags: []string{"#cat"}, ChannelMessages: map[int64]int{-200: 1}},
+		"file_5": {MessageID: 5, FileID: "file_5", Tags: []string{"#cat"}, ChannelMessages: map[int64]int{-200: 1}},
+	})
+
+	report := Check(store, false, defaultNormalizer)
+
+	assert.Equal(t, []Problem{
+		{Kind: KindDescriptionInTags, Key: "file_1", Detail: "'funny cat' будет перенесено в описание", Fixable: true},
+		{Kind: KindMalformedTag, Key: "file_2", Detail: "'#Dog' => '#dog'", Fixable: true},
+		{Kind: KindMalformedTag, Key: "file_3", Detail: "'#' будет удален", Fixable: true},
+		{Kind: KindMalformedTag, Key: "#Dog", Detail: "в списке тегов, '#Dog' => '#dog'", Fixable: true},
+		{Kind: KindUnusedTag, Key: "#unused", Fixable: true},
+		{Kind: KindMissingTag, Key: "#", Detail: "у гифок file_3", Fixable: true},
+		{Kind: KindMissingTag, Key: "#bird", Detail: "у гифок file_3", Fixable: true},
+		{Kind: KindAliasCycle, Key: "#a", Detail: "#a => #b => #a"},
+		{Kind: KindAliasCycle, Key: "#b", Detail: "#b => #a => #b"},
+		{Kind: KindAliasChain, Key: "#kot", Detail: "#kot => #koshka => #cat", Fixable: true},
+		{Kind: KindZeroMessageID, Key: "file_3", Detail: "#bird #"},
+		{Kind: KindDuplicateMessageID, Key: "-200#1", Detail: "file_4, file_5"},
+		{Kind: KindDuplicateMessageID, Key: "#1", Detail: "file_1, file_2"},
+	}, report.Problems)
+	assert.Equal(t, len(report.Problems), report.Unresolved())
+	assert.Equal(t, []string{"#cat", "#Dog", "#unused"}, store.GetTags(), "storage should not be changed without fix")
+}
+
+func TestCheck_fix(t *testing.T) {
+	store := newTestStorage(t)
+	defer store.Close()
+
+	var report *Report
+	err := store.Update(func(tx storage.MetaTx) error {
+		report = Check(tx, true, defaultNormalizer)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+
+	assert.Equal(t, 4, report.Unresolved(), "alias cycles and message ids can not be fixed")
+	assert.Equal(t, []string{"#bird", "#cat", "#dog"}, store.GetTags())
+	assert.Equal(t, map[string]string{
+		"#kot":    "#cat",
+		"#koshka": "#cat",
+		"#a":      "#b",
+		"#b":      "#a",
+	}, store.GetTagsAliases())
+	assert.Equal(t, map[string]*storage.SentAnimation{
+		"file_1": {MessageID: 1, FileID: "file_1", Tags: []string{"#cat"}, Description: "funny cat"},
+		"file_2": {MessageID: 1, FileID: "file_2", Tags: []string{"#dog", "#cat"}},
+		"file_3": {MessageID: 0, FileID: "file_3", Tags: []string{"#bird"}},
+	}, store.GetSentAnimations())
+
+	report = Check(store, false, defaultNormalizer)
+	assert.Equal(t, 4, report.Unresolved(), "second check should find only unfixable problems")
+	assert.Equal(t, 4, len(report.Problems))
+}
+
+func TestRenormalize(t *testing.T) {
+	store, err := storage.NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+
+	store.SetTags([]string{"#ежик", "#ёжик", "#кот"})
+	store.SetTagsAliases(map[string]string{"#ёжик": "#ежик", "#kot": "#кот!"})
+	store.AddSentAnimations(map[string]*storage.SentAnimation{
+		"file_1": {MessageID: 1, FileID: "file_1", Tags: []string{"#ёжик", "#ежик", "ёжик в тумане"}},
+		"file_2": {MessageID: 2, FileID: "file_2", Tags: []string{"#кот!"}},
+	})
+	norm := tagnorm.New(config.TagNormalization{FoldYo: true})
+
+	var report *Report
+	err = store.Update(func(tx storage.MetaTx) error {
+		report = Renormalize(tx, norm, true)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("dry run: %s", err)
+	}
+	assert.NotEmpty(t, report.Problems)
+	assert.Equal(t, []string{"#ежик", "#ёжик", "#кот"}, store.GetTags(), "dry run should not change storage")
+
+	err = store.Update(func(tx storage.MetaTx) error {
+		report = Renormalize(tx, norm, false)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("renormalize: %s", err)
+	}
+
+	assert.Equal(t, 0, report.Unresolved())
+	assert.Equal(t, []string{"#ежик", "#кот"}, store.GetTags())
+	assert.Equal(t, map[string]string{"#kot": "#кот"}, store.GetTagsAliases())
+	assert.Equal(t, map[string]*storage.SentAnimation{
+		"file_1": {MessageID: 1, FileID: "file_1", Tags: []string{"#ежик"}, Description: "ёжик в тумане"},
+		"file_2": {MessageID: 2, FileID: "file_2", Tags: []string{"#кот"}},
+	}, store.GetSentAnimations())
+
+	err = store.Update(func(tx storage.MetaTx) error {
+		report = Renormalize(tx, norm, true)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("dry run after renormalize: %s", err)
+	}
+	assert.Empty(t, report.Problems)
+}
@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+const (
+	// defaultGlobalPerSecond общий лимит Bot API на все чаты
+	defaultGlobalPerSecond = 30
+	// defaultChatPerMinute лимит телеги на сообщения в одну группу или канал
+	defaultChatPerMinute = 20
+	defaultChatBurst     = 3
+	// privatePerSecond лимит телеги на сообщения в личку одному пользователю
+	privatePerSecond = 1
+)
+
+// Limiter token bucket перед отправкой и редактированием сообщений: общий на все чаты и отдельный на каждый чат.
+// Лимит из конфига для групп и каналов (id меньше нуля), в личку телега пускает сообщение в секунду.
+// Токены выдаются в порядке вызовов, поэтому сообщения, отправленные по очереди, по очереди и уйдут.
+// nil Limiter ничего не ограничивает
+type Limiter struct {
+	mu        sync.Mutex
+	global    *bucket
+	chatRate  float64
+	chatBurst float64
+	chats     map[int64]*bucket
+	now       func() time.Time
+	sleep     func(time.Duration)
+}
+
+// New лимитер с настройками из конфига, незаданные берутся по умолчанию
+func New(conf config.RateLimit) *Limiter {
+	globalPerSecond := conf.GlobalPerSecond
+	if globalPerSecond <= 0 {
+		globalPerSecond = defaultGlobalPerSecond
+	}
+
+	chatPerMinute := conf.ChatPerMinute
+	if chatPerMinute <= 0 {
+		chatPerMinute = defaultChatPerMinute
+	}
+
+	chatBurst := conf.ChatBurst
+	if chatBurst <= 0 {
+		chatBurst = defaultChatBurst
+	}
+
+	l := &Limiter{
+		chatRate:  chatPerMinute / 60,
+		chatBurst: float64(chatBurst),
+		chats:     make(map[int64]*bucket),
+		now:       time.Now,
+		sleep:     time.Sleep,
+	}
+	l.global = newBucket(globalPerSecond, globalPerSecond, l.now())
+
+	return l
+}
+
+// Wait заблокирует, пока нельзя отправить сообщение в chatID
+func (l *Limiter) Wait(chatID int64) {
+	if l == nil {
+		return
+	}
+
+	if delay := l.Reserve(chatID); delay > 0 {
+		l.sleep(delay)
+	}
+}
+
+// Reserve займет токен на отправку в chatID и вернет, сколько ждать до отправки
+func (l *Limiter) Reserve(chatID int64) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	chat, ok := l.chats[chatID]
+	if !ok {
+		chat = l.newChatBucket(chatID, now)
+		l.chats[chatID] = chat
+	}
+
+	delay := l.global.wait(now)
+	if chatDelay := chat.wait(now); chatDelay > delay {
+		delay = chatDelay
+	}
+
+	l.global.take()
+	chat.take()
+
+	return delay
+}
+
+func (l *Limiter) newChatBucket(chatID int64, now time.Time) *bucket {
+	if chatID > 0 {
+		return newBucket(privatePerSecond, privatePerSecond, now)
+	}
+
+	return newBucket(l.chatRate, l.chatBurst, now)
+}
+
+// bucket токены копятся со скоростью rate в секунду, но не больше burst.
+// Токенов может быть меньше нуля, это уже занятые наперед отправки
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate, burst float64, now time.Time) *bucket {
+	return &bucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// wait пополнит токены на момент now и вернет, через сколько появится целый токен
+func (b *bucket) wait(now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *bucket) take() {
+	b.tokens--
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+func TestLimiter_Reserve(t *testing.T) {
+	t.Parallel()
+
+	type reserve struct {
+		chatID int64
+		// at сколько прошло от начала
+		at time.Duration
+	}
+	tests := []struct {
+		name      string
+		conf      config.RateLimit
+		reserves  []reserve
+		wantDelay []time.Duration
+	}{
+		{
+			"should allow burst and then pace chat",
+			config.RateLimit{GlobalPerSecond: 30, ChatPerMinute: 20, ChatBurst: 2},
+			[]reserve{{-1, 0}, {-1, 0}, {-1, 0}, {-1, 0}},
+			[]time.Duration{0, 0, 3 * time.Second, 6 * time.Second},
+		},
+		{
+			"should refill chat tokens with time",
+			config.RateLimit{GlobalPerSecond: 30, ChatPerMinute: 20, ChatBurst: 1},
+			[]reserve{{-1, 0}, {-1, time.Second}, {-1, 10 * time.Second}},
+			[]time.Duration{0, 2 * time.Second, 0},
+		},
+		{
+			"should limit chats separately",
+			config.RateLimit{GlobalPerSecond: 30, ChatPerMinute: 20, ChatBurst: 1},
+			[]reserve{{-1, 0}, {-2, 0}, {-1, 0}, {-2, 0}},
+			[]time.Duration{0, 0, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			"should apply global limit for all chats",
+			config.RateLimit{GlobalPerSecond: 2, ChatPerMinute: 60, ChatBurst: 10},
+			[]reserve{{-1, 0}, {-2, 0}, {-3, 0}, {-4, 0}},
+			[]time.Duration{0, 0, 500 * time.Millisecond, time.Second},
+		},
+		{
+			"should use private chat limit for users",
+			config.RateLimit{GlobalPerSecond: 30, ChatPerMinute: 20, ChatBurst: 3},
+			[]reserve{{42, 0}, {42, 0}, {42, 0}, {-1, 0}, {-1, 0}},
+			[]time.Duration{0, time.Second, 2 * time.Second, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+			now := start
+
+			l := New(tt.conf)
+			l.now = func() time.Time { return now }
+			l.global.last = start
+
+			var delays []time.Duration
+			for _, r := range tt.reserves {
+				now = start.Add(r.at)
+				delays = append(delays, l.Reserve(r.chatID).Round(time.Millisecond))
+			}
+
+			assert.Equal(t, tt.wantDelay, delays)
+		})
+	}
+}
+
+func TestLimiter_nil(t *testing.T) {
+	var l *Limiter
+
+	assert.Equal(t, time.Duration(0), l.Reserve(1))
+	l.Wait(1)
+}
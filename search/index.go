@@ -0,0 +1,180 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+)
+
+// Index обратный индекс тег => гифки, чтобы не перебирать все отправленные анимации на каждый запрос
+type Index struct {
+	mu sync.RWMutex
+	// animations все гифки по fileID
+	animations map[string]*storage.SentAnimation
+	// tags тег => множество fileID
+	tags map[string]map[string]bool
+	// sortedTags теги по алфавиту, для поиска по префиксу
+	sortedTags []string
+}
+
+// NewIndex строит индекс по отправленным анимациям
+func NewIndex(animations map[string]*storage.SentAnimation) *Index {
+	idx := &Index{
+		animations: make(map[string]*storage.SentAnimation, len(animations)),
+		tags:       make(map[string]map[string]bool),
+	}
+
+	for _, anim := range animations {
+		idx.add(anim)
+	}
+	idx.sortTags()
+
+	return idx
+}
+
+// Add добавит или заменит гифку в индексе
+func (i *Index) Add(anims ...*storage.SentAnimation) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, anim := range anims {
+		i.remove(anim.FileID)
+		i.add(anim)
+	}
+	i.sortTags()
+}
+
+// Remove уберет гифку из индекса
+func (i *Index) Remove(fileID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(fileID)
+	i.sortTags()
+}
+
+// Tags все теги индекса по алфавиту
+func (i *Index) Tags() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return append([]string(nil), i.sortedTags...)
+}
+
+// Count сколько гифок с этим тегом
+func (i *Index) Count(tag string) int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.tags[tag])
+}
+
+// Search вернет гифки, подходящие под запрос
+func (i *Index) Search(q Query, opts ...Option) []*storage.SentAnimation {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	i.mu.RLock()
+	ids := q.match(i)
+	result := make([]*storage.SentAnimation, 0, len(ids))
+	for id := range ids {
+		result = append(result, i.animations[id])
+	}
+	i.mu.RUnlock()
+
+	sortAnimations(result, o.sort)
+
+	if o.offset > 0 {
+		if o.offset >= len(result) {
+			return nil
+		}
+		result = result[o.offset:]
+	}
+	if o.limit > 0 && len(result) > o.limit {
+		result = result[:o.limit]
+	}
+
+	return result
+}
+
+func (i *Index) add(anim *storage.SentAnimation) {
+	i.animations[anim.FileID] = anim
+
+	for _, tag := range anim.Tags {
+		// в старых записях описание лежит среди тегов, но это не тег
+		if !strings.HasPrefix(tag, "#") {
+			continue
+		}
+
+		if i.tags[tag] == nil {
+			i.tags[tag] = make(map[string]bool)
+		}
+		i.tags[tag][anim.FileID] = true
+	}
+}
+
+func (i *Index) remove(fileID string) {
+	anim, ok := i.animations[fileID]
+	if !ok {
+		return
+	}
+
+	for _, tag := range anim.Tags {
+		delete(i.tags[tag], fileID)
+		if len(i.tags[tag]) == 0 {
+			delete(i.tags, tag)
+		}
+	}
+	delete(i.animations, fileID)
+}
+
+func (i *Index) sortTags() {
+	i.sortedTags = i.sortedTags[:0]
+	for tag := range i.tags {
+		i.sortedTags = append(i.sortedTags, tag)
+	}
+	sort.Strings(i.sortedTags)
+}
+
+// tagsWithPrefix теги, начинающиеся с prefix, бинарным поиском по отсортированному списку
+func (i *Index) tagsWithPrefix(prefix string) []string {
+	start := sort.SearchStrings(i.sortedTags, prefix)
+
+	var tags []string
+	for _, tag := range i.sortedTags[start:] {
+		if !strings.HasPrefix(tag, prefix) {
+			break
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+func (i *Index) all() fileIDSet {
+	ids := make(fileIDSet, len(i.animations))
+	for id := range i.animations {
+		ids[id] = true
+	}
+
+	return ids
+}
+func sortAnimations(anims []*storage.SentAnimation, s Sort) {
+	sort.Slice(anims, func(i, j int) bool {
+		a, b := anims[i], anims[j]
+		if a.MessageID == b.MessageID {
+			// для стабильной постраничной выдачи
+			return a.FileID < b.FileID
+		}
+
+		if s == SortOldest {
+			return a.MessageID < b.MessageID
+		}
+
+		return a.MessageID > b.MessageID
+	})
+}
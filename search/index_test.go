@@ -0,0 +1,106 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+	"github.com/cyhalothrin/gifkoskladbot/storage"
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex(map[string]*storage.SentAnimation{
+		"cat":       {FileID: "cat", MessageID: 1, Tags: []string{"#cat", "#funny", "description"}},
+		"cat_dog":   {FileID: "cat_dog", MessageID: 2, Tags: []string{"#cat", "#dog"}},
+		"dog":       {FileID: "dog", MessageID: 3, Tags: []string{"#dog", "#funny"}},
+		"catapult":  {FileID: "catapult", MessageID: 4, Tags: []string{"#catapult"}},
+		"untagged":  {FileID: "untagged", MessageID: 5},
+		"doggo_cat": {FileID: "doggo_cat", MessageID: 6, Tags: []string{"#doggo", "#cat"}},
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		opts  []Option
+		want  []string
+	}{
+		{"single tag, recent first", "#cat", nil, []string{"doggo_cat", "cat_dog", "cat"}},
+		{"tag without hash", "CAT", nil, []string{"doggo_cat", "cat_dog", "cat"}},
+		{"and", "#cat #funny", nil, []string{"cat"}},
+		{"or", "#funny | #catapult", nil, []string{"catapult", "dog", "cat"}},
+		{"not", "#cat -#dog", nil, []string{"doggo_cat", "cat"}},
+		{"only not", "!#cat", nil, []string{"untagged", "catapult", "dog"}},
+		{"prefix", "#cat*", nil, []string{"doggo_cat", "catapult", "cat_dog", "cat"}},
+		{"prefix and not", "#dog* -#funny", nil, []string{"doggo_cat", "cat_dog"}},
+		{"description is not a tag", "description", nil, nil},
+		{"oldest with offset and limit", "#cat*", []Option{WithSort(SortOldest), WithOffset(1), WithLimit(2)}, []string{"cat_dog", "catapult"}},
+		{"offset out of range", "#cat", []Option{WithOffset(10)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query, nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var got []string
+			for _, anim := range idx.Search(q, tt.opts...) {
+				got = append(got, anim.FileID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_normalizer(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex(map[string]*storage.SentAnimation{
+		"hedgehog": {FileID: "hedgehog", MessageID: 1, Tags: []string{"#ежик", "#в_тумане"}},
+	})
+	normalizer := tagnorm.New(config.TagNormalization{FoldYo: true})
+
+	for _, text := range []string{"#Ёжик", "ёж*", "#в-тумане!", "-#кот ежик"} {
+		q, err := Parse(text, normalizer)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", text, err)
+		}
+
+		found := idx.Search(q)
+		if assert.Len(t, found, 1, "query %q", text) {
+			assert.Equal(t, "hedgehog", found[0].FileID)
+		}
+	}
+
+	_, err := Parse("#!!!", normalizer)
+	assert.Error(t, err, "nothing left after normalization")
+}
+
+func TestIndex_AddRemove(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex(nil)
+	idx.Add(&storage.SentAnimation{FileID: "1", MessageID: 1, Tags: []string{"#old"}})
+	idx.Add(&storage.SentAnimation{FileID: "1", MessageID: 1, Tags: []string{"#new"}})
+
+	assert.Equal(t, []string{"#new"}, idx.Tags())
+	assert.Equal(t, 1, idx.Count("#new"))
+	assert.Empty(t, idx.Search(Tag("#old")))
+
+	idx.Remove("1")
+	assert.Empty(t, idx.Tags())
+	assert.Empty(t, idx.Search(All()))
+}
+
+func TestParse_errors(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{"", "   ", "#cat |", "| #cat", "#", "-"} {
+		_, err := Parse(text, nil)
+		assert.Error(t, err, "query %q", text)
+	}
+}
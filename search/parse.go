@@ -0,0 +1,113 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cyhalothrin/gifkoskladbot/tagnorm"
+)
+
+// ErrEmptyQuery в запросе нет ни одного тега
+var ErrEmptyQuery = errors.New("empty query")
+
+// Parse разбирает текстовый запрос, как его пишут в боте или в консоли:
+//
+//	#cat #funny  - оба тега
+//	#cat | #dog  - любой из тегов, также можно "or"
+//	-#ugly       - без тега, также можно "!#ugly"
+//	#ca*         - любой тег, начинающийся с #ca
+//
+// Решетку можно не писать, регистр не важен. Теги из запроса проходят через normalizer,
+// тот же, что у бота, иначе #ёжик не найдет #ежик. nil - только нижний регистр
+func Parse(text string, normalizer *tagnorm.Normalizer) (Query, error) {
+	var groups []Query
+	var group []Query
+
+	closeGroup := func() error {
+		if len(group) == 0 {
+			return errors.New("empty part around '|'")
+		}
+
+		if len(group) == 1 {
+			groups = append(groups, group[0])
+		} else {
+			groups = append(groups, And(group...))
+		}
+		group = nil
+
+		return nil
+	}
+
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	for _, word := range words {
+		if word == "|" || word == "or" {
+			if err := closeGroup(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		term, err := parseTerm(word, normalizer)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, term)
+	}
+
+	if err := closeGroup(); err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 1 {
+		return groups[0], nil
+	}
+
+	return Or(groups...), nil
+}
+
+func parseTerm(word string, normalizer *tagnorm.Normalizer) (Query, error) {
+	negate := false
+	if strings.HasPrefix(word, "-") || strings.HasPrefix(word, "!") {
+		negate = true
+		word = word[1:]
+	}
+
+	prefix := false
+	if strings.HasSuffix(word, "*") {
+		prefix = true
+		word = strings.TrimSuffix(word, "*")
+	}
+
+	word = strings.TrimPrefix(word, "#")
+	if word == "" {
+		return nil, fmt.Errorf("empty tag in query")
+	}
+	word = "#" + word
+
+	if normalizer != nil {
+		tag, ok := normalizer.Tag(word)
+		if !ok {
+			return nil, fmt.Errorf("empty tag in query")
+		}
+		word = tag
+	}
+
+	var q Query
+	if prefix {
+		q = Prefix(word)
+	} else {
+		q = Tag(word)
+	}
+
+	if negate {
+		q = Not(q)
+	}
+
+	return q, nil
+}
@@ -0,0 +1,171 @@
+package search
+
+type fileIDSet map[string]bool
+
+// Query условие поиска по тегам, собирается из Tag, Prefix, And, Or, Not
+type Query interface {
+	match(idx *Index) fileIDSet
+}
+
+// Tag гифки с этим тегом
+func Tag(tag string) Query {
+	return tagQuery(tag)
+}
+
+// Prefix гифки с любым тегом, начинающимся с prefix
+func Prefix(prefix string) Query {
+	return prefixQuery(prefix)
+}
+
+// And гифки, подходящие под все условия
+func And(queries ...Query) Query {
+	return andQuery(queries)
+}
+
+// Or гифки, подходящие хотя бы под одно условие
+func Or(queries ...Query) Query {
+	return orQuery(queries)
+}
+
+// Not гифки, не подходящие под условие
+func Not(query Query) Query {
+	return notQuery{query: query}
+}
+
+// All все гифки
+func All() Query {
+	return allQuery{}
+}
+
+type tagQuery string
+
+func (q tagQuery) match(idx *Index) fileIDSet {
+	ids := make(fileIDSet, len(idx.tags[string(q)]))
+	for id := range idx.tags[string(q)] {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+type prefixQuery string
+
+func (q prefixQuery) match(idx *Index) fileIDSet {
+	ids := make(fileIDSet)
+	for _, tag := range idx.tagsWithPrefix(string(q)) {
+		for id := range idx.tags[tag] {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+type andQuery []Query
+
+func (q andQuery) match(idx *Index) fileIDSet {
+	var positive []Query
+	var negative []Query
+	for _, sub := range q {
+		if not, ok := sub.(notQuery); ok {
+			negative = append(negative, not.query)
+		} else {
+			positive = append(positive, sub)
+		}
+	}
+
+	// только исключения, значит исключаем из всех
+	var ids fileIDSet
+	if len(positive) == 0 {
+		ids = idx.all()
+	} else {
+		ids = positive[0].match(idx)
+		for _, sub := range positive[1:] {
+			if len(ids) == 0 {
+				return ids
+			}
+
+			subIDs := sub.match(idx)
+			for id := range ids {
+				if !subIDs[id] {
+					delete(ids, id)
+				}
+			}
+		}
+	}
+
+	for _, sub := range negative {
+		for id := range sub.match(idx) {
+			delete(ids, id)
+		}
+	}
+
+	return ids
+}
+
+type orQuery []Query
+
+func (q orQuery) match(idx *Index) fileIDSet {
+	ids := make(fileIDSet)
+	for _, sub := range q {
+		for id := range sub.match(idx) {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+type notQuery struct {
+	query Query
+}
+
+func (q notQuery) match(idx *Index) fileIDSet {
+	return andQuery{q}.match(idx)
+}
+
+type allQuery struct{}
+
+func (allQuery) match(idx *Index) fileIDSet {
+	return idx.all()
+}
+
+// Sort порядок результатов поиска
+type Sort int
+
+const (
+	// SortRecent сначала недавно опубликованные, в канале id сообщений растут со временем
+	SortRecent Sort = iota
+	// SortOldest сначала старые
+	SortOldest
+)
+
+type options struct {
+	sort   Sort
+	offset int
+	limit  int
+}
+
+// Option настройка выдачи Search
+type Option func(o *options)
+
+// WithSort задает порядок, по умолчанию SortRecent
+func WithSort(s Sort) Option {
+	return func(o *options) {
+		o.sort = s
+	}
+}
+
+// WithOffset пропустит первые offset результатов, для постраничной выдачи
+func WithOffset(offset int) Option {
+	return func(o *options) {
+		o.offset = offset
+	}
+}
+
+// WithLimit вернет не больше limit результатов
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.limit = limit
+	}
+}
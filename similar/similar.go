@@ -0,0 +1,120 @@
+// Package similar ищет похожие теги: опечатки и один и тот же тег латиницей и кириллицей
+package similar
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// translitTable кириллица => латиница, как обычно пишут теги латиницей
+var translitTable = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// Closest самый похожий на tag тег из known, при равной похожести берется первый по алфавиту.
+// Вернет false, если похожих нет или tag сам есть в known, тогда это не опечатка
+func Closest(tag string, known []string) (string, bool) {
+	name := strings.TrimPrefix(tag, "#")
+	maxDistance := MaxDistance(name)
+	translit := Translit(name)
+
+	best := ""
+	bestDistance := maxDistance + 1
+
+	for _, candidate := range known {
+		if candidate == tag {
+			return "", false
+		}
+
+		candidateName := strings.TrimPrefix(candidate, "#")
+		distance := Distance(name, candidateName)
+		if d := Distance(translit, Translit(candidateName)); d < distance {
+			distance = d
+		}
+
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	if bestDistance > maxDistance {
+		return "", false
+	}
+
+	return best, true
+}
+
+// MaxDistance сколько опечаток допустимо в слове: в коротких словах любая опечатка
+// дает другое слово, а в длинных легко ошибиться дважды
+func MaxDistance(word string) int {
+	switch n := utf8.RuneCountInString(word); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Translit кириллица латиницей, остальные символы не меняются
+func Translit(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if latin, ok := translitTable[r]; ok {
+			b.WriteString(latin)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// Distance расстояние Дамерау-Левенштейна: вставка, удаление, замена и перестановка соседних символов
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// prev2, prev, cur три последние строки матрицы расстояний
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,73 @@
+package similar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"cat", "", 3},
+		{"kitten", "kitten", 0},
+		{"kittne", "kitten", 1},
+		{"kiten", "kitten", 1},
+		{"kitten", "sitting", 3},
+		{"котик", "котки", 1},
+		{"кот", "кит", 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Distance(tt.a, tt.b))
+			assert.Equal(t, tt.want, Distance(tt.b, tt.a))
+		})
+	}
+}
+
+func TestClosest(t *testing.T) {
+	t.Parallel()
+
+	known := []string{"#kitten", "#котик", "#cat", "#dog", "#like_a_boss", "#mitten"}
+
+	tests := []struct {
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{"#kittne", "#kitten", true},
+		{"#kotik", "#котик", true},
+		{"#kotki", "#котик", true},
+		{"#like_a_bos", "#like_a_boss", true},
+		{"#bitten", "#kitten", true},
+		{"#cot", "", false},
+		{"#kitten", "", false},
+		{"#horse", "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := Closest(tt.tag, known)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestTranslit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "kotik_schenok", Translit("котик_щенок"))
+	assert.Equal(t, "ezhik", Translit("ёжик"))
+	assert.Equal(t, "cat", Translit("cat"))
+}
@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+const (
+	backupTimeFormat  = "20060102T150405Z"
+	backupExt         = ".gz"
+	defaultBackupKeep = 10
+)
+
+// ErrBackupNotFound нет бэкапа с таким id
+var ErrBackupNotFound = errors.New("backup not found")
+
+// Backup сжатая копия файла хранилища
+type Backup struct {
+	// ID время создания, по нему бэкап восстанавливается
+	ID        string
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// BackupManager делает, ротирует и восстанавливает бэкапы файла хранилища
+type BackupManager struct {
+	storagePath string
+	driver      string
+	dir         string
+	keep        int
+	maxAge      time.Duration
+	now         func() time.Time
+}
+
+func NewBackupManager(conf config.Config) *BackupManager {
+	dir := conf.Backup.Directory
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(conf.StoragePath), "backups")
+	}
+
+	keep := conf.Backup.Keep
+	if keep <= 0 {
+		keep = defaultBackupKeep
+	}
+
+	return &BackupManager{
+		storagePath: conf.StoragePath,
+		driver:      conf.StorageDriver,
+		dir:         dir,
+		keep:        keep,
+		maxAge:      time.Duration(conf.Backup.MaxAgeDays) * 24 * time.Hour,
+		now:         time.Now,
+	}
+}
+
+// Create сжимает текущий файл хранилища в новый бэкап и удаляет лишние старые. Журнал json хранилища
+// перед этим сворачивается в файл, чтобы в бэкап попали и последние изменения.
+// Если файла еще нет или он не изменился с последнего бэкапа, вернет nil. Хранилище не должно быть открыто
+func (m *BackupManager) Create() (*Backup, error) {
+	if m.driver != DriverBolt {
+		if err := compactJournal(m.storagePath); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := ioutil.ReadFile(m.storagePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read storage file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	backups, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// при каждом запуске делать одинаковые бэкапы смысла нет, они вытеснят полезные
+	if len(backups) > 0 {
+		last, err := readBackup(backups[0].Path)
+		if err == nil && bytes.Equal(last, data) {
+			return nil, nil
+		}
+	}
+
+	if err := os.MkdirAll(m.dir, 0777); err != nil {
+		return nil, fmt.Errorf("create backups dir: %w", err)
+	}
+
+	createdAt := m.now().UTC()
+	id := createdAt.Format(backupTimeFormat)
+	path := m.backupPath(id)
+	for i := 2; fileExists(path); i++ {
+		id = fmt.Sprintf("%s-%d", createdAt.Format(backupTimeFormat), i)
+		path = m.backupPath(id)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Name = filepath.Base(m.storagePath)
+	zw.ModTime = createdAt
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("compress backup: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress backup: %w", err)
+	}
+
+	if err := writeFileAtomic(path, buf.Bytes(), 0666); err != nil {
+		return nil, fmt.Errorf("write backup: %w", err)
+	}
+
+	backup := &Backup{
+		ID:        id,
+		Path:      path,
+		CreatedAt: createdAt,
+		Size:      int64(buf.Len()),
+	}
+
+	if err := m.rotate(); err != nil {
+		log.Println("backups rotation:", err)
+	}
+
+	return backup, nil
+}
+
+// List бэкапы от новых к старым
+func (m *BackupManager) List() ([]Backup, error) {
+	files, err := ioutil.ReadDir(m.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read backups dir: %w", err)
+	}
+
+	prefix := filepath.Base(m.storagePath) + "."
+	var backups []Backup
+
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, backupExt) {
+			continue
+		}
+
+		id := strings.TrimSuffix(strings.TrimPrefix(name, prefix), backupExt)
+		createdAt, err := parseBackupID(id)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, Backup{
+			ID:        id,
+			Path:      filepath.Join(m.dir, name),
+			CreatedAt: createdAt,
+			Size:      f.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].CreatedAt.Equal(backups[j].CreatedAt) {
+			return backups[i].ID > backups[j].ID
+		}
+
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// Restore проверяет бэкап и подменяет им файл хранилища. Текущий файл вместе с журналом перед этим тоже бэкапится.
+// Хранилище в этот момент не должно быть открыто
+func (m *BackupManager) Restore(id string) error {
+	// id попадает в путь, поэтому ничего кроме времени создания в нем быть не может
+	if _, err := parseBackupID(id); err != nil {
+		return fmt.Errorf("%w: invalid id %q", ErrBackupNotFound, id)
+	}
+
+	path := m.backupPath(id)
+	if !fileExists(path) {
+		return fmt.Errorf("%w: %s", ErrBackupNotFound, id)
+	}
+
+	data, err := readBackup(path)
+	if err != nil {
+		return err
+	}
+
+	if err := m.validate(data); err != nil {
+		return fmt.Errorf("backup %s is broken: %w", id, err)
+	}
+
+	// журнал свернется в текущий файл и вместе с ним попадет в бэкап, так что ничего не потеряется
+	if _, err := m.Create(); err != nil {
+		return fmt.Errorf("backup current storage: %w", err)
+	}
+
+	// изменения из журнала относятся к старому файлу, поверх бэкапа их проигрывать нельзя
+	if fileExists(journalPath(m.storagePath)) {
+		return fmt.Errorf("journal %s is not folded into storage file", journalPath(m.storagePath))
+	}
+
+	if err := writeFileAtomic(m.storagePath, data, 0666); err != nil {
+		return fmt.Errorf("restore storage file: %w", err)
+	}
+
+	return nil
+}
+
+// validate проверяет, что из бэкапа можно открыть хранилище
+func (m *BackupManager) validate(data []byte) error {
+	if m.driver != DriverBolt {
+		_, _, err := loadMetaData(data)
+
+		return err
+	}
+
+	// bolt открывается только из файла
+	tmp, err := ioutil.TempFile("", "gifkosklad-backup-*.bolt")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	db, err := bolt.Open(tmp.Name(), 0666, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketTagsAliases, bucketAnimations, bucketMeta} {
+			if tx.Bucket(name) == nil {
+				return fmt.Errorf("bucket '%s' not found", name)
+			}
+		}
+
+		return nil
+	})
+}
+
+// rotate оставляет keep последних бэкапов и удаляет те, что старше maxAge
+func (m *BackupManager) rotate() error {
+	backups, err := m.List()
+	if err != nil {
+		return err
+	}
+
+	for i, b := range backups {
+		tooOld := m.maxAge > 0 && m.now().Sub(b.CreatedAt) > m.maxAge
+		// самый свежий не удаляем никогда
+		if i == 0 || (i < m.keep && !tooOld) {
+			continue
+		}
+
+		if err := os.Remove(b.Path); err != nil {
+			return fmt.Errorf("remove backup %s: %w", b.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// compactJournal допишет изменения из журнала json хранилища в его файл, как при обычном открытии и закрытии
+func compactJournal(storagePath string) error {
+	info, err := os.Stat(journalPath(storagePath))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat journal: %w", err)
+	}
+	// пустой журнал остается после закрытия без изменений, сворачивать в нем нечего
+	if info.Size() == 0 {
+		if err := os.Remove(journalPath(storagePath)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove journal: %w", err)
+		}
+
+		return nil
+	}
+
+	store, err := NewFileMetaStorage(storagePath)
+	if err != nil {
+		return fmt.Errorf("open storage to fold journal: %w", err)
+	}
+
+	if err := store.Close(); err != nil {
+		return fmt.Errorf("fold journal into storage file: %w", err)
+	}
+
+	return nil
+}
+
+// parseBackupID время создания бэкапа из id. У повторных бэкапов в ту же секунду есть суффикс -N
+func parseBackupID(id string) (time.Time, error) {
+	parts := strings.SplitN(id, "-", 2)
+
+	createdAt, err := time.Parse(backupTimeFormat, parts[0])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(parts) == 2 {
+		n, err := strconv.Atoi(parts[1])
+		if err != nil || n < 1 || strconv.Itoa(n) != parts[1] {
+			return time.Time{}, fmt.Errorf("invalid backup suffix %q", parts[1])
+		}
+	}
+
+	return createdAt, nil
+}
+
+func (m *BackupManager) backupPath(id string) string {
+	return filepath.Join(m.dir, filepath.Base(m.storagePath)+"."+id+backupExt)
+}
+
+func readBackup(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open backup: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("decompress backup: %w", err)
+	}
+	defer zr.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(zr, 1<<30))
+	if err != nil {
+		return nil, fmt.Errorf("decompress backup: %w", err)
+	}
+
+	return data, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+func newTestBackupManager(t *testing.T, keep, maxAgeDays int) (*BackupManager, *time.Time) {
+	conf := config.Config{
+		StoragePath: filepath.Join(t.TempDir(), "db.json"),
+		Backup:      config.Backup{Keep: keep, MaxAgeDays: maxAgeDays},
+	}
+
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := NewBackupManager(conf)
+	manager.now = func() time.Time { return now }
+
+	return manager, &now
+}
+
+func writeTestStorage(t *testing.T, path string, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0666); err != nil {
+		t.Fatalf("write storage: %s", err)
+	}
+}
+
+func TestBackupManager_Create(t *testing.T) {
+	manager, now := newTestBackupManager(t, 2, 0)
+
+	backup, err := manager.Create()
+	assert.NoError(t, err)
+	assert.Nil(t, backup, "no storage file yet")
+
+	writeTestStorage(t, manager.storagePath, `{"Version":1,"Tags":["#a"]}`)
+	first, err := manager.Create()
+	assert.NoError(t, err)
+	assert.Equal(t, "20210301T120000Z", first.ID)
+
+	backup, err = manager.Create()
+	assert.NoError(t, err)
+	assert.Nil(t, backup, "storage has not changed")
+
+	writeTestStorage(t, manager.storagePath, `{"Version":1,"Tags":["#b"]}`)
+	second, err := manager.Create()
+	assert.NoError(t, err)
+	assert.Equal(t, "20210301T120000Z-2", second.ID, "same second")
+
+	*now = now.Add(time.Hour)
+	writeTestStorage(t, manager.storagePath, `{"Version":1,"Tags":["#c"]}`)
+	third, err := manager.Create()
+	assert.NoError(t, err)
+
+	backups, err := manager.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{third.ID, second.ID}, backupIDs(backups), "oldest should be rotated")
+}
+
+func TestBackupManager_rotateByAge(t *testing.T) {
+	manager, now := newTestBackupManager(t, 10, 1)
+
+	for i, content := range []string{`{"Tags":["#a"]}`, `{"Tags":["#b"]}`, `{"Tags":["#c"]}`} {
+		writeTestStorage(t, manager.storagePath, content)
+		if _, err := manager.Create(); err != nil {
+			t.Fatalf("create backup %d: %s", i, err)
+		}
+		*now = now.Add(20 * time.Hour)
+	}
+
+	backups, err := manager.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"20210303T040000Z", "20210302T080000Z"}, backupIDs(backups))
+}
+
+func TestBackupManager_Restore(t *testing.T) {
+	manager, now := newTestBackupManager(t, 10, 0)
+
+	store, err := NewFileMetaStorage(manager.storagePath)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	store.SetTags([]string{"#old"})
+	assert.NoError(t, store.Close())
+
+	old, err := manager.Create()
+	if err != nil {
+		t.Fatalf("create backup: %s", err)
+	}
+
+	store, err = NewFileMetaStorage(manager.storagePath)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	store.SetTags([]string{"#new"})
+	// "падаем", изменения есть только в журнале
+	store.journal.close()
+
+	err = manager.Restore("20000101T000000Z")
+	assert.True(t, errors.Is(err, ErrBackupNotFound))
+
+	*now = now.Add(time.Minute)
+	writeTestStorage(t, manager.backupPath("20210301T130000Z"), "not gzip")
+	assert.Error(t, manager.Restore("20210301T130000Z"), "broken backup")
+
+	assert.NoError(t, manager.Restore(old.ID))
+
+	store, err = NewFileMetaStorage(manager.storagePath)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+	assert.Equal(t, []string{"#old"}, store.GetTags())
+
+	backups, err := manager.List()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 3, "storage before restore is backed up too")
+
+	// изменения из журнала не потерялись, а попали в бэкап перед восстановлением
+	beforeRestore, err := readBackup(manager.backupPath("20210301T120100Z"))
+	assert.NoError(t, err)
+	meta, _, err := loadMetaData(beforeRestore)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"#new"}, meta.Tags)
+}
+
+func TestBackupManager_Restore_invalidID(t *testing.T) {
+	manager, _ := newTestBackupManager(t, 10, 0)
+
+	writeTestStorage(t, manager.storagePath, `{"Version":1,"Tags":["#old"]}`)
+	backup, err := manager.Create()
+	if err != nil {
+		t.Fatalf("create backup: %s", err)
+	}
+	writeTestStorage(t, manager.storagePath, `{"Version":1,"Tags":["#new"]}`)
+
+	// по этому пути лежит настоящий бэкап, найтись он не должен
+	data, err := ioutil.ReadFile(backup.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(manager.backupPath("../../x"), data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"../../x", backup.ID + "/../../x", backup.ID + "-0", backup.ID + "-01", backup.ID + "-a", ""} {
+		err := manager.Restore(id)
+		assert.True(t, errors.Is(err, ErrBackupNotFound), "id %q: %v", id, err)
+	}
+
+	content, err := ioutil.ReadFile(manager.storagePath)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Version":1,"Tags":["#new"]}`, string(content), "storage should not change")
+}
+
+func TestBackupManager_Create_withJournal(t *testing.T) {
+	manager, _ := newTestBackupManager(t, 10, 0)
+
+	store, err := NewFileMetaStorage(manager.storagePath)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	store.SetTags([]string{"#a"})
+	assert.NoError(t, store.Flush())
+	store.SetTags([]string{"#a", "#b"})
+	store.journal.close()
+
+	backup, err := manager.Create()
+	if err != nil {
+		t.Fatalf("create backup: %s", err)
+	}
+	assert.False(t, fileExists(journalPath(manager.storagePath)), "journal should be folded into storage file")
+
+	data, err := readBackup(backup.Path)
+	assert.NoError(t, err)
+	meta, _, err := loadMetaData(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"#a", "#b"}, meta.Tags)
+}
+
+func TestNewMetaStorage_backup(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.Config{StoragePath: filepath.Join(dir, "db.json")}
+	writeTestStorage(t, conf.StoragePath, `{"Version":1,"Tags":["#a"]}`)
+
+	store, err := NewMetaStorage(conf)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	assert.NoError(t, store.Close())
+
+	backups, err := NewBackupManager(conf).List()
+	assert.NoError(t, err)
+	assert.Empty(t, backups, "read only open should not make backups")
+
+	store, err = NewMetaStorageForWrite(conf)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	assert.NoError(t, store.Close())
+
+	backups, err = NewBackupManager(conf).List()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func backupIDs(backups []Backup) []string {
+	ids := make([]string, 0, len(backups))
+	for _, b := range backups {
+		ids = append(ids, b.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,1060 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucketTags теги до появления версии схемы лежали ключами бакета, и их порядок терялся.
+	// Теперь это список в keyTags, бакет остается только у старых баз до миграции
+	bucketTags        = []byte("tags")
+	bucketTagsAliases = []byte("tags_aliases")
+	bucketAnimations  = []byte("animations")
+	bucketMeta        = []byte("meta")
+	bucketTagHistory  = []byte("tag_history")
+	bucketFavChannel  = []byte("fav_channel_animations")
+
+	keyVersion                              = []byte("version")
+	keyTags                                 = []byte("tags")
+	keyLastForwardedMessageIDWithoutCaption = []byte("last_forwarded_message_id_without_caption")
+	keyLastUpdateID                         = []byte("last_update_id")
+	keyHandledUpdateIDs                     = []byte("handled_update_ids")
+	keyUpdateFailures                       = []byte("update_failures")
+	keyTagsIndex                            = []byte("tags_index")
+	keyUserRoles                            = []byte("user_roles")
+	keyPendingTypos                         = []byte("pending_typos")
+)
+
+// BoltMetaStorage хранилище в bbolt, в отличие от FileMetaStorage каждое изменение пишется сразу
+// и только оно, весь архив не перезаписывается
+type BoltMetaStorage struct {
+	db *bolt.DB
+	mu sync.Mutex
+	// err первая ошибка записи, сеттеры ошибок не возвращают, поэтому отдадим ее в Flush/Close
+	err error
+	// readErr первая ошибка чтения, геттеры тоже ошибок не возвращают. После нее запись запрещена:
+	// геттер отдал пустые данные, и сеттер мог бы затереть ими базу
+	readErr error
+}
+
+// NewBoltMetaStorage откроет базу bolt. Если по пути лежит json файлового хранилища (в конфиге сменили драйвер),
+// данные из него один раз переносятся в bolt, а сам json остается рядом с расширением .json
+func NewBoltMetaStorage(path string) (*BoltMetaStorage, error) {
+	isJSON, err := isJSONStorageFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isJSON {
+		if err := importFileStorage(path); err != nil {
+			return nil, fmt.Errorf("import file storage into bolt: %w", err)
+		}
+	}
+
+	return openBoltMetaStorage(path)
+}
+
+// NewBoltMetaStorageReadOnly откроет базу bolt только для чтения. Json файлового хранилища тут не переносится,
+// для этого базу нужно один раз открыть на запись
+func NewBoltMetaStorageReadOnly(path string) (*BoltMetaStorage, error) {
+	isJSON, err := isJSONStorageFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isJSON {
+		return nil, fmt.Errorf("%s is not imported into bolt yet, open it for write once", path)
+	}
+
+	db, err := bolt.Open(path, 0666, &bolt.Options{Timeout: 5 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt storage: %w", err)
+	}
+
+	// мигрировать без записи нельзя, а читать старую схему геттеры не умеют
+	var version int
+	err = db.View(func(btx *bolt.Tx) error {
+		tx := &boltTx{tx: btx}
+		version, _ = tx.version()
+
+		return tx.err
+	})
+	if err == nil && version > CurrentVersion {
+		err = fmt.Errorf("%w: %d, supported %d", ErrNewerVersion, version, CurrentVersion)
+	}
+	if err == nil && version < CurrentVersion {
+		err = fmt.Errorf("%s has schema version %d, open it for write once to migrate", path, version)
+	}
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &BoltMetaStorage{
+		db: db,
+	}, nil
+}
+
+func openBoltMetaStorage(path string) (*BoltMetaStorage, error) {
+	db, err := bolt.Open(path, 0666, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt storage: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketTagsAliases, bucketAnimations, bucketMeta, bucketTagHistory, bucketFavChannel} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return fmt.Errorf("create bucket '%s': %w", name, err)
+			}
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = migrateBolt(db)
+	}
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &BoltMetaStorage{
+		db: db,
+	}, nil
+}
+
+// migrateBolt поднимает версию схемы базы до текущей теми же миграциями, что и у файла:
+// содержимое базы собирается в metaData, мигрирует как json и записывается обратно.
+// Базы без версии созданы до ее появления и мигрируют с нуля, как старые файлы
+func migrateBolt(db *bolt.DB) error {
+	return db.Update(func(btx *bolt.Tx) error {
+		tx := &boltTx{tx: btx}
+
+		version, ok := tx.version()
+		if tx.err != nil {
+			return tx.err
+		}
+		if version > CurrentVersion {
+			return fmt.Errorf("%w: %d, supported %d", ErrNewerVersion, version, CurrentVersion)
+		}
+		if version == CurrentVersion {
+			return nil
+		}
+		if !ok && tx.isEmpty() {
+			// новая база, мигрировать нечего
+			tx.setVersion(CurrentVersion)
+
+			return tx.err
+		}
+
+		meta := tx.exportMeta()
+		meta.Version = version
+		if !ok {
+			meta.Tags = tx.legacyTags()
+		}
+		if tx.err != nil {
+			return tx.err
+		}
+
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("marshal bolt storage: %w", err)
+		}
+
+		migrated, _, err := loadMetaData(data)
+		if err != nil {
+			return err
+		}
+
+		tx.importMeta(migrated)
+		if err := btx.DeleteBucket(bucketTags); err != nil && err != bolt.ErrBucketNotFound {
+			tx.check(fmt.Errorf("delete bucket '%s': %w", bucketTags, err))
+		}
+
+		return tx.err
+	})
+}
+
+// isJSONStorageFile файл bolt начинается с заголовка страницы, а json файлового хранилища с '{'
+func isJSONStorageFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("open storage file: %w", err)
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, fmt.Errorf("read storage file: %w", err)
+	}
+
+	return bytes.HasPrefix(bytes.TrimLeft(head[:n], " \t\r\n"), []byte("{")), nil
+}
+
+// importFileStorage переносит json файлового хранилища вместе с журналом в новую базу bolt по тому же пути.
+// База сначала собирается во временном файле, json переименовывается только после ее успешной записи
+func importFileStorage(path string) error {
+	jsonPath := path + ".json"
+	if fileExists(jsonPath) {
+		return fmt.Errorf("%s already exists, move it away to import %s", jsonPath, path)
+	}
+
+	file, err := NewFileMetaStorage(path)
+	if err != nil {
+		return err
+	}
+	// журнал свернется в файл, переименовывать будем уже полный json
+	meta := file.meta
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	tmpPath := path + ".import"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove old import file: %w", err)
+	}
+
+	store, err := openBoltMetaStorage(tmpPath)
+	if err != nil {
+		return err
+	}
+	err = store.Update(func(tx MetaTx) error {
+		tx.(*boltTx).importMeta(meta)
+
+		return nil
+	})
+	if closeErr := store.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err := os.Rename(path, jsonPath); err != nil {
+		os.Remove(tmpPath)
+
+		return fmt.Errorf("keep file storage: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		// вернем json на место, чтобы хранилище можно было открыть старым драйвером
+		os.Rename(jsonPath, path)
+
+		return fmt.Errorf("move bolt storage: %w", err)
+	}
+
+	log.Printf("file storage imported into bolt, old file kept as %s\n", jsonPath)
+
+	return nil
+}
+
+func (b *BoltMetaStorage) GetTags() (tags []string) {
+	b.view(func(tx *boltTx) {
+		tags = tx.GetTags()
+	})
+
+	return tags
+}
+
+func (b *BoltMetaStorage) SetTags(tags []string) {
+	b.update(func(tx *boltTx) {
+		tx.SetTags(tags)
+	})
+}
+
+func (b *BoltMetaStorage) GetTagsAliases() (aliases map[string]string) {
+	b.view(func(tx *boltTx) {
+		aliases = tx.GetTagsAliases()
+	})
+
+	return aliases
+}
+
+func (b *BoltMetaStorage) SetTagsAliases(aliases map[string]string) {
+	b.update(func(tx *boltTx) {
+		tx.SetTagsAliases(aliases)
+	})
+}
+
+func (b *BoltMetaStorage) GetSentAnimations() (messages map[string]*SentAnimation) {
+	b.view(func(tx *boltTx) {
+		messages = tx.GetSentAnimations()
+	})
+
+	return messages
+}
+
+func (b *BoltMetaStorage) AddSentAnimations(messages map[string]*SentAnimation) {
+	b.update(func(tx *boltTx) {
+		tx.AddSentAnimations(messages)
+	})
+}
+
+func (b *BoltMetaStorage) DeleteSentAnimations(fileIDs ...string) {
+	b.update(func(tx *boltTx) {
+		tx.DeleteSentAnimations(fileIDs...)
+	})
+}
+
+func (b *BoltMetaStorage) SetFavChannelLastForwardedMessageIDWithoutCaption(id int64) {
+	b.update(func(tx *boltTx) {
+		tx.SetFavChannelLastForwardedMessageIDWithoutCaption(id)
+	})
+}
+
+func (b *BoltMetaStorage) GetFavChannelLastForwardedMessageIDWithoutCaption() (id int64) {
+	b.view(func(tx *boltTx) {
+		id = tx.GetFavChannelLastForwardedMessageIDWithoutCaption()
+	})
+
+	return id
+}
+
+func (b *BoltMetaStorage) SetLastUpdateID(id int) {
+	b.update(func(tx *boltTx) {
+		tx.SetLastUpdateID(id)
+	})
+}
+
+func (b *BoltMetaStorage) GetLastUpdateID() (id int) {
+	b.view(func(tx *boltTx) {
+		id = tx.GetLastUpdateID()
+	})
+
+	return id
+}
+
+func (b *BoltMetaStorage) GetHandledUpdateIDs() (ids []int) {
+	b.view(func(tx *boltTx) {
+		ids = tx.GetHandledUpdateIDs()
+	})
+
+	return ids
+}
+
+func (b *BoltMetaStorage) SetHandledUpdateIDs(ids []int) {
+	b.update(func(tx *boltTx) {
+		tx.SetHandledUpdateIDs(ids)
+	})
+}
+
+func (b *BoltMetaStorage) GetUpdateFailures() (failures map[int]int) {
+	b.view(func(tx *boltTx) {
+		failures = tx.GetUpdateFailures()
+	})
+
+	return failures
+}
+
+func (b *BoltMetaStorage) SetUpdateFailures(failures map[int]int) {
+	b.update(func(tx *boltTx) {
+		tx.SetUpdateFailures(failures)
+	})
+}
+
+func (b *BoltMetaStorage) GetTagsIndex() (index []*TagsIndexMessage) {
+	b.view(func(tx *boltTx) {
+		index = tx.GetTagsIndex()
+	})
+
+	return index
+}
+
+func (b *BoltMetaStorage) SetTagsIndex(index []*TagsIndexMessage) {
+	b.update(func(tx *boltTx) {
+		tx.SetTagsIndex(index)
+	})
+}
+
+func (b *BoltMetaStorage) GetUserRoles() (roles map[int]string) {
+	b.view(func(tx *boltTx) {
+		roles = tx.GetUserRoles()
+	})
+
+	return roles
+}
+
+func (b *BoltMetaStorage) SetUserRoles(roles map[int]string) {
+	b.update(func(tx *boltTx) {
+		tx.SetUserRoles(roles)
+	})
+}
+
+func (b *BoltMetaStorage) GetPendingTypos() (pending map[string]*PendingTypos) {
+	b.view(func(tx *boltTx) {
+		pending = tx.GetPendingTypos()
+	})
+
+	return pending
+}
+
+func (b *BoltMetaStorage) SetPendingTypos(pending map[string]*PendingTypos) {
+	b.update(func(tx *boltTx) {
+		tx.SetPendingTypos(pending)
+	})
+}
+
+func (b *BoltMetaStorage) AddTagChanges(changes ...*TagChange) {
+	b.update(func(tx *boltTx) {
+		tx.AddTagChanges(changes...)
+	})
+}
+
+func (b *BoltMetaStorage) GetTagHistory(fileID string) (history []*TagChange) {
+	b.view(func(tx *boltTx) {
+		history = tx.GetTagHistory(fileID)
+	})
+
+	return history
+}
+
+func (b *BoltMetaStorage) GetFavChannelAnimations() (animations map[string]*FavChannelAnimation) {
+	b.view(func(tx *boltTx) {
+		animations = tx.GetFavChannelAnimations()
+	})
+
+	return animations
+}
+
+func (b *BoltMetaStorage) AddFavChannelAnimations(animations map[string]*FavChannelAnimation) {
+	b.update(func(tx *boltTx) {
+		tx.AddFavChannelAnimations(animations)
+	})
+}
+
+// Update выполнит fn в транзакции bolt, при ошибке, в том числе чтения, все изменения откатятся
+func (b *BoltMetaStorage) Update(fn func(tx MetaTx) error) error {
+	b.mu.Lock()
+	readErr := b.readErr
+	b.mu.Unlock()
+	if readErr != nil {
+		return fmt.Errorf("bolt storage is read only after read error: %w", readErr)
+	}
+
+	return b.db.Update(func(btx *bolt.Tx) error {
+		tx := &boltTx{tx: btx}
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		return tx.err
+	})
+}
+
+// Flush каждое изменение и так пишется сразу, вернет ошибку записи или чтения, если она была
+func (b *BoltMetaStorage) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+	if b.readErr != nil {
+		return fmt.Errorf("bolt storage read: %w", b.readErr)
+	}
+
+	return nil
+}
+
+// Close закроет базу и вернет первую ошибку записи, если она была
+func (b *BoltMetaStorage) Close() error {
+	if err := b.db.Close(); err != nil {
+		return fmt.Errorf("close bolt storage: %w", err)
+	}
+
+	return b.Flush()
+}
+
+func (b *BoltMetaStorage) view(fn func(tx *boltTx)) {
+	err := b.db.View(func(btx *bolt.Tx) error {
+		tx := &boltTx{tx: btx}
+		fn(tx)
+
+		return tx.err
+	})
+	if err != nil {
+		log.Println("bolt storage read:", err)
+
+		b.mu.Lock()
+		if b.readErr == nil {
+			b.readErr = err
+		}
+		b.mu.Unlock()
+	}
+}
+
+func (b *BoltMetaStorage) update(fn func(tx *boltTx)) {
+	err := b.Update(func(tx MetaTx) error {
+		fn(tx.(*boltTx))
+
+		return nil
+	})
+	if err != nil {
+		log.Println("bolt storage write:", err)
+
+		b.mu.Lock()
+		if b.err == nil {
+			b.err = err
+		}
+		b.mu.Unlock()
+	}
+}
+
+// boltTx реализует MetaTx поверх транзакции bolt, запоминает первую ошибку, чтобы откатить транзакцию
+type boltTx struct {
+	tx  *bolt.Tx
+	err error
+}
+
+func (t *boltTx) GetTags() []string {
+	value := t.tx.Bucket(bucketMeta).Get(keyTags)
+	if value == nil {
+		return nil
+	}
+
+	var tags []string
+	if err := json.Unmarshal(value, &tags); err != nil {
+		t.check(fmt.Errorf("unmarshal tags: %w", err))
+
+		return nil
+	}
+
+	return tags
+}
+
+// SetTags теги хранятся одним списком, чтобы сохранить порядок, как у файлового хранилища
+func (t *boltTx) SetTags(tags []string) {
+	if len(tags) == 0 {
+		t.check(t.tx.Bucket(bucketMeta).Delete(keyTags))
+
+		return
+	}
+
+	value, err := json.Marshal(tags)
+	if err != nil {
+		t.check(fmt.Errorf("marshal tags: %w", err))
+
+		return
+	}
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyTags, value))
+}
+
+// legacyTags теги из бакета баз до появления версии схемы
+func (t *boltTx) legacyTags() []string {
+	bucket := t.tx.Bucket(bucketTags)
+	if bucket == nil {
+		return nil
+	}
+
+	var tags []string
+	err := bucket.ForEach(func(k, _ []byte) error {
+		tags = append(tags, string(k))
+
+		return nil
+	})
+	if err != nil {
+		t.check(fmt.Errorf("read tags: %w", err))
+
+		return nil
+	}
+
+	return tags
+}
+
+func (t *boltTx) GetTagsAliases() map[string]string {
+	aliases := make(map[string]string)
+
+	err := t.tx.Bucket(bucketTagsAliases).ForEach(func(k, v []byte) error {
+		aliases[string(k)] = string(v)
+
+		return nil
+	})
+	if err != nil {
+		t.check(fmt.Errorf("read tags aliases: %w", err))
+
+		return nil
+	}
+
+	return aliases
+}
+
+func (t *boltTx) SetTagsAliases(aliases map[string]string) {
+	bucket := t.recreateBucket(bucketTagsAliases)
+	if bucket == nil {
+		return
+	}
+
+	for alias, tag := range aliases {
+		if err := bucket.Put([]byte(alias), []byte(tag)); err != nil {
+			t.check(fmt.Errorf("put alias '%s': %w", alias, err))
+
+			return
+		}
+	}
+}
+
+func (t *boltTx) GetSentAnimations() map[string]*SentAnimation {
+	messages := make(map[string]*SentAnimation)
+
+	err := t.tx.Bucket(bucketAnimations).ForEach(func(k, v []byte) error {
+		msg := &SentAnimation{}
+		if err := json.Unmarshal(v, msg); err != nil {
+			return fmt.Errorf("unmarshal animation '%s': %w", k, err)
+		}
+		messages[string(k)] = msg
+
+		return nil
+	})
+	if err != nil {
+		t.check(err)
+
+		return nil
+	}
+
+	return messages
+}
+
+func (t *boltTx) AddSentAnimations(messages map[string]*SentAnimation) {
+	bucket := t.tx.Bucket(bucketAnimations)
+
+	for key, msg := range messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			t.check(fmt.Errorf("marshal animation '%s': %w", key, err))
+
+			return
+		}
+		if err := bucket.Put([]byte(key), data); err != nil {
+			t.check(fmt.Errorf("put animation '%s': %w", key, err))
+
+			return
+		}
+	}
+}
+
+func (t *boltTx) DeleteSentAnimations(fileIDs ...string) {
+	bucket := t.tx.Bucket(bucketAnimations)
+
+	for _, id := range fileIDs {
+		if err := bucket.Delete([]byte(id)); err != nil {
+			t.check(fmt.Errorf("delete animation '%s': %w", id, err))
+
+			return
+		}
+	}
+}
+
+func (t *boltTx) SetFavChannelLastForwardedMessageIDWithoutCaption(id int64) {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(id))
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyLastForwardedMessageIDWithoutCaption, value))
+}
+
+func (t *boltTx) GetFavChannelLastForwardedMessageIDWithoutCaption() int64 {
+	value := t.tx.Bucket(bucketMeta).Get(keyLastForwardedMessageIDWithoutCaption)
+	if value == nil {
+		return 0
+	}
+	if len(value) != 8 {
+		t.check(fmt.Errorf("read last forwarded message id: unexpected length %d", len(value)))
+
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+func (t *boltTx) SetLastUpdateID(id int) {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(id))
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyLastUpdateID, value))
+}
+
+func (t *boltTx) GetLastUpdateID() int {
+	value := t.tx.Bucket(bucketMeta).Get(keyLastUpdateID)
+	if value == nil {
+		return 0
+	}
+	if len(value) != 8 {
+		t.check(fmt.Errorf("read last update id: unexpected length %d", len(value)))
+
+		return 0
+	}
+
+	return int(binary.BigEndian.Uint64(value))
+}
+
+func (t *boltTx) GetHandledUpdateIDs() []int {
+	value := t.tx.Bucket(bucketMeta).Get(keyHandledUpdateIDs)
+	if value == nil {
+		return nil
+	}
+
+	var ids []int
+	if err := json.Unmarshal(value, &ids); err != nil {
+		t.check(fmt.Errorf("unmarshal handled update ids: %w", err))
+
+		return nil
+	}
+
+	return ids
+}
+
+func (t *boltTx) SetHandledUpdateIDs(ids []int) {
+	if len(ids) == 0 {
+		t.check(t.tx.Bucket(bucketMeta).Delete(keyHandledUpdateIDs))
+
+		return
+	}
+
+	value, err := json.Marshal(ids)
+	if err != nil {
+		t.check(fmt.Errorf("marshal handled update ids: %w", err))
+
+		return
+	}
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyHandledUpdateIDs, value))
+}
+
+func (t *boltTx) GetUpdateFailures() map[int]int {
+	value := t.tx.Bucket(bucketMeta).Get(keyUpdateFailures)
+	if value == nil {
+		return nil
+	}
+
+	var failures map[int]int
+	if err := json.Unmarshal(value, &failures); err != nil {
+		t.check(fmt.Errorf("unmarshal update failures: %w", err))
+
+		return nil
+	}
+
+	return failures
+}
+
+func (t *boltTx) SetUpdateFailures(failures map[int]int) {
+	if len(failures) == 0 {
+		t.check(t.tx.Bucket(bucketMeta).Delete(keyUpdateFailures))
+
+		return
+	}
+
+	value, err := json.Marshal(failures)
+	if err != nil {
+		t.check(fmt.Errorf("marshal update failures: %w", err))
+
+		return
+	}
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyUpdateFailures, value))
+}
+
+func (t *boltTx) GetTagsIndex() []*TagsIndexMessage {
+	value := t.tx.Bucket(bucketMeta).Get(keyTagsIndex)
+	if value == nil {
+		return nil
+	}
+
+	var index []*TagsIndexMessage
+	if err := json.Unmarshal(value, &index); err != nil {
+		t.check(fmt.Errorf("unmarshal tags index: %w", err))
+
+		return nil
+	}
+
+	return index
+}
+
+func (t *boltTx) SetTagsIndex(index []*TagsIndexMessage) {
+	value, err := json.Marshal(index)
+	if err != nil {
+		t.check(fmt.Errorf("marshal tags index: %w", err))
+
+		return
+	}
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyTagsIndex, value))
+}
+
+func (t *boltTx) GetUserRoles() map[int]string {
+	value := t.tx.Bucket(bucketMeta).Get(keyUserRoles)
+	if value == nil {
+		return nil
+	}
+
+	var roles map[int]string
+	if err := json.Unmarshal(value, &roles); err != nil {
+		t.check(fmt.Errorf("unmarshal user roles: %w", err))
+
+		return nil
+	}
+
+	return roles
+}
+
+func (t *boltTx) SetUserRoles(roles map[int]string) {
+	value, err := json.Marshal(roles)
+	if err != nil {
+		t.check(fmt.Errorf("marshal user roles: %w", err))
+
+		return
+	}
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyUserRoles, value))
+}
+
+func (t *boltTx) GetPendingTypos() map[string]*PendingTypos {
+	value := t.tx.Bucket(bucketMeta).Get(keyPendingTypos)
+	if value == nil {
+		return nil
+	}
+
+	var pending map[string]*PendingTypos
+	if err := json.Unmarshal(value, &pending); err != nil {
+		t.check(fmt.Errorf("unmarshal pending typos: %w", err))
+
+		return nil
+	}
+
+	return pending
+}
+
+func (t *boltTx) SetPendingTypos(pending map[string]*PendingTypos) {
+	value, err := json.Marshal(pending)
+	if err != nil {
+		t.check(fmt.Errorf("marshal pending typos: %w", err))
+
+		return
+	}
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyPendingTypos, value))
+}
+
+func (t *boltTx) AddTagChanges(changes ...*TagChange) {
+	for _, change := range changes {
+		history := t.GetTagHistory(change.FileID)
+		if t.err != nil {
+			return
+		}
+
+		t.setTagHistory(change.FileID, append(history, change))
+	}
+}
+
+// setTagHistory заменит историю гифки целиком, нужно при импорте
+func (t *boltTx) setTagHistory(fileID string, history []*TagChange) {
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.check(fmt.Errorf("marshal tag history '%s': %w", fileID, err))
+
+		return
+	}
+
+	if err := t.tx.Bucket(bucketTagHistory).Put([]byte(fileID), data); err != nil {
+		t.check(fmt.Errorf("put tag history '%s': %w", fileID, err))
+	}
+}
+
+func (t *boltTx) GetTagHistory(fileID string) []*TagChange {
+	data := t.tx.Bucket(bucketTagHistory).Get([]byte(fileID))
+	if data == nil {
+		return nil
+	}
+
+	var history []*TagChange
+	if err := json.Unmarshal(data, &history); err != nil {
+		t.check(fmt.Errorf("unmarshal tag history '%s': %w", fileID, err))
+
+		return nil
+	}
+
+	return history
+}
+
+func (t *boltTx) GetFavChannelAnimations() map[string]*FavChannelAnimation {
+	animations := make(map[string]*FavChannelAnimation)
+
+	err := t.tx.Bucket(bucketFavChannel).ForEach(func(k, v []byte) error {
+		anim := &FavChannelAnimation{}
+		if err := json.Unmarshal(v, anim); err != nil {
+			return fmt.Errorf("unmarshal fav channel animation '%s': %w", k, err)
+		}
+		animations[string(k)] = anim
+
+		return nil
+	})
+	if err != nil {
+		t.check(err)
+
+		return nil
+	}
+
+	return animations
+}
+
+func (t *boltTx) AddFavChannelAnimations(animations map[string]*FavChannelAnimation) {
+	bucket := t.tx.Bucket(bucketFavChannel)
+
+	for key, anim := range animations {
+		data, err := json.Marshal(anim)
+		if err != nil {
+			t.check(fmt.Errorf("marshal fav channel animation '%s': %w", key, err))
+
+			return
+		}
+		if err := bucket.Put([]byte(key), data); err != nil {
+			t.check(fmt.Errorf("put fav channel animation '%s': %w", key, err))
+
+			return
+		}
+	}
+}
+
+// tagHistories истории всех гифок по fileID
+func (t *boltTx) tagHistories() map[string][]*TagChange {
+	histories := make(map[string][]*TagChange)
+
+	err := t.tx.Bucket(bucketTagHistory).ForEach(func(k, v []byte) error {
+		var history []*TagChange
+		if err := json.Unmarshal(v, &history); err != nil {
+			return fmt.Errorf("unmarshal tag history '%s': %w", k, err)
+		}
+		histories[string(k)] = history
+
+		return nil
+	})
+	if err != nil {
+		t.check(err)
+
+		return nil
+	}
+
+	return histories
+}
+
+func (t *boltTx) version() (version int, ok bool) {
+	// бакета нет только у базы, которую еще ни разу не открывали на запись
+	bucket := t.tx.Bucket(bucketMeta)
+	if bucket == nil {
+		return 0, false
+	}
+
+	value := bucket.Get(keyVersion)
+	if value == nil {
+		return 0, false
+	}
+	if len(value) != 8 {
+		t.check(fmt.Errorf("read schema version: unexpected length %d", len(value)))
+
+		return 0, false
+	}
+
+	return int(binary.BigEndian.Uint64(value)), true
+}
+
+func (t *boltTx) setVersion(version int) {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(version))
+
+	t.check(t.tx.Bucket(bucketMeta).Put(keyVersion, value))
+}
+
+func (t *boltTx) isEmpty() bool {
+	for _, name := range [][]byte{bucketTags, bucketTagsAliases, bucketAnimations, bucketMeta, bucketTagHistory, bucketFavChannel} {
+		bucket := t.tx.Bucket(name)
+		if bucket == nil {
+			continue
+		}
+		if k, _ := bucket.Cursor().First(); k != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// exportMeta все содержимое базы одним metaData, для миграций
+func (t *boltTx) exportMeta() *metaData {
+	return &metaData{
+		Tags:                                 t.GetTags(),
+		TagsAliases:                          t.GetTagsAliases(),
+		Messages:                             t.GetSentAnimations(),
+		LastForwardedMessageIDWithoutCaption: t.GetFavChannelLastForwardedMessageIDWithoutCaption(),
+		TagHistory:                           t.tagHistories(),
+		FavChannelAnimations:                 t.GetFavChannelAnimations(),
+		LastUpdateID:                         t.GetLastUpdateID(),
+		HandledUpdateIDs:                     t.GetHandledUpdateIDs(),
+		UpdateFailures:                       t.GetUpdateFailures(),
+		TagsIndex:                            t.GetTagsIndex(),
+		UserRoles:                            t.GetUserRoles(),
+		PendingTypos:                         t.GetPendingTypos(),
+	}
+}
+
+// importMeta запишет meta поверх содержимого базы вместе с версией схемы
+func (t *boltTx) importMeta(meta *metaData) {
+	for _, name := range [][]byte{bucketAnimations, bucketTagHistory, bucketFavChannel} {
+		if t.recreateBucket(name) == nil {
+			return
+		}
+	}
+
+	t.SetTags(meta.Tags)
+	t.SetTagsAliases(meta.TagsAliases)
+	t.AddSentAnimations(meta.Messages)
+	t.SetFavChannelLastForwardedMessageIDWithoutCaption(meta.LastForwardedMessageIDWithoutCaption)
+	for fileID, history := range meta.TagHistory {
+		t.setTagHistory(fileID, history)
+	}
+	t.AddFavChannelAnimations(meta.FavChannelAnimations)
+	t.SetLastUpdateID(meta.LastUpdateID)
+	t.SetHandledUpdateIDs(meta.HandledUpdateIDs)
+	t.SetUpdateFailures(meta.UpdateFailures)
+	t.SetTagsIndex(meta.TagsIndex)
+	t.SetUserRoles(meta.UserRoles)
+	t.SetPendingTypos(meta.PendingTypos)
+	t.setVersion(meta.Version)
+}
+
+func (t *boltTx) recreateBucket(name []byte) *bolt.Bucket {
+	if err := t.tx.DeleteBucket(name); err != nil && err != bolt.ErrBucketNotFound {
+		t.check(fmt.Errorf("delete bucket '%s': %w", name, err))
+
+		return nil
+	}
+
+	bucket, err := t.tx.CreateBucket(name)
+	if err != nil {
+		t.check(fmt.Errorf("create bucket '%s': %w", name, err))
+
+		return nil
+	}
+
+	return bucket
+}
+
+func (t *boltTx) check(err error) {
+	if err != nil && t.err == nil {
+		t.err = err
+	}
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestNewBoltMetaStorage_migration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.bolt")
+
+	// база до появления версии: теги ключами бакета, описание в тегах
+	db, err := bolt.Open(path, 0666, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		tags, err := tx.CreateBucket(bucketTags)
+		if err != nil {
+			return err
+		}
+		for _, tag := range []string{"#dog", "#cat"} {
+			if err := tags.Put([]byte(tag), nil); err != nil {
+				return err
+			}
+		}
+
+		animations, err := tx.CreateBucket(bucketAnimations)
+		if err != nil {
+			return err
+		}
+
+		return animations.Put([]byte("file_1"), []byte(`{"MessageID":10,"FileID":"file_1","Tags":["#cat","рыжий"]}`))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewBoltMetaStorageReadOnly(path)
+	assert.Error(t, err, "read only storage can not be migrated")
+
+	store, err := NewBoltMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+
+	assert.Equal(t, []string{"#cat", "#dog"}, store.GetTags())
+	assert.Equal(t, map[string]*SentAnimation{
+		"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#cat"}, Description: "рыжий"},
+	}, store.GetSentAnimations())
+
+	err = store.db.View(func(btx *bolt.Tx) error {
+		tx := &boltTx{tx: btx}
+		version, ok := tx.version()
+		assert.True(t, ok)
+		assert.Equal(t, CurrentVersion, version)
+		assert.Nil(t, btx.Bucket(bucketTags), "old tags bucket should be removed")
+
+		return tx.err
+	})
+	assert.NoError(t, err)
+
+	// более новую схему не трогаем
+	err = store.db.Update(func(btx *bolt.Tx) error {
+		tx := &boltTx{tx: btx}
+		tx.setVersion(CurrentVersion + 1)
+
+		return tx.err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("close storage: %s", err)
+	}
+
+	_, err = NewBoltMetaStorage(path)
+	assert.True(t, errors.Is(err, ErrNewerVersion))
+	_, err = NewBoltMetaStorageReadOnly(path)
+	assert.True(t, errors.Is(err, ErrNewerVersion))
+}
+
+func TestNewBoltMetaStorage_importFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	changedAt := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	file, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open file storage: %s", err)
+	}
+	file.SetTags([]string{"#cat"})
+	file.AddSentAnimations(map[string]*SentAnimation{
+		"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#cat"}},
+	})
+	file.SetUserRoles(map[int]string{42: "admin"})
+	if err := file.Flush(); err != nil {
+		t.Fatalf("flush file storage: %s", err)
+	}
+	// это изменение останется только в журнале, как после падения
+	file.AddTagChanges(&TagChange{FileID: "file_1", ChangedAt: changedAt, NewTags: []string{"#cat"}})
+	if err := file.journal.close(); err != nil {
+		t.Fatalf("close journal: %s", err)
+	}
+
+	store, err := NewBoltMetaStorage(path)
+	if err != nil {
+		t.Fatalf("import storage: %s", err)
+	}
+
+	assert.Equal(t, []string{"#cat"}, store.GetTags())
+	assert.Equal(t, map[string]*SentAnimation{
+		"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#cat"}},
+	}, store.GetSentAnimations())
+	assert.Equal(t, map[int]string{42: "admin"}, store.GetUserRoles())
+	assert.Equal(t, []*TagChange{{FileID: "file_1", ChangedAt: changedAt, NewTags: []string{"#cat"}}}, store.GetTagHistory("file_1"))
+
+	store.SetTags([]string{"#cat", "#dog"})
+	if err := store.Close(); err != nil {
+		t.Fatalf("close storage: %s", err)
+	}
+
+	assert.FileExists(t, path+".json", "old file should be kept")
+	assert.False(t, fileExists(journalPath(path)), "journal should be folded into the file")
+
+	// второй раз импорта нет, открывается уже bolt
+	store, err = NewBoltMetaStorage(path)
+	if err != nil {
+		t.Fatalf("reopen storage: %s", err)
+	}
+	defer store.Close()
+
+	assert.Equal(t, []string{"#cat", "#dog"}, store.GetTags())
+}
+
+func TestNewBoltMetaStorage_importFileExists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	if err := ioutil.WriteFile(path, []byte(`{"Version": 1}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path+".json", []byte(`{}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewBoltMetaStorage(path)
+	assert.Error(t, err, "should not overwrite previous import")
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Version": 1}`, string(data), "file storage should stay as is")
+}
+
+func TestBoltMetaStorage_readError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.bolt")
+
+	store, err := NewBoltMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+
+	store.SetTags([]string{"#cat"})
+	store.AddSentAnimations(map[string]*SentAnimation{"file_1": {FileID: "file_1"}})
+	err = store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAnimations).Put([]byte("file_2"), []byte("not json"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, store.GetSentAnimations(), "should not return partial data")
+	assert.Error(t, store.Flush())
+
+	store.SetTags(nil)
+	assert.Equal(t, []string{"#cat"}, store.GetTags(), "writes should be refused after read error")
+
+	err = store.Update(func(tx MetaTx) error {
+		tx.SetTags([]string{"#dog"})
+
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestNewBoltMetaStorageReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.bolt")
+
+	store, err := NewBoltMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	store.SetTags([]string{"#cat"})
+	if err := store.Close(); err != nil {
+		t.Fatalf("close storage: %s", err)
+	}
+
+	store, err = NewBoltMetaStorageReadOnly(path)
+	if err != nil {
+		t.Fatalf("open storage read only: %s", err)
+	}
+	defer store.Close()
+
+	assert.Equal(t, []string{"#cat"}, store.GetTags())
+	assert.Error(t, store.Update(func(tx MetaTx) error {
+		tx.SetTags(nil)
+
+		return nil
+	}))
+
+	_, err = NewBoltMetaStorageReadOnly(filepath.Join(t.TempDir(), "missing.bolt"))
+	assert.Error(t, err)
+}
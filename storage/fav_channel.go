@@ -0,0 +1,32 @@
+package storage
+
+// PublishStatus этап, на котором находится гифка из избранного при переносе в канал
+type PublishStatus string
+
+const (
+	// PublishStatusCollected гифка с тегами найдена в избранном, но еще не отправлена
+	PublishStatusCollected PublishStatus = "collected"
+	// PublishStatusSent гифка отправлена в канал
+	PublishStatusSent PublishStatus = "sent"
+	// PublishStatusDeleted гифка удалена из избранного, отправлять ее больше не нужно
+	PublishStatusDeleted PublishStatus = "deleted"
+)
+
+// FavChannelAnimation гифка с тегами из избранного, которую переносят в канал командой publish
+type FavChannelAnimation struct {
+	FileID      string
+	Tags        []string
+	Description string `json:",omitempty"`
+	// SourceMessageID id сообщения в избранном
+	SourceMessageID int64
+	Status          PublishStatus
+	// ChannelMessageID id сообщения в канале, есть только у отправленных
+	ChannelMessageID int64 `json:",omitempty"`
+}
+
+func (f *FavChannelAnimation) clone() *FavChannelAnimation {
+	c := *f
+	c.Tags = append([]string(nil), f.Tags...)
+
+	return &c
+}
@@ -0,0 +1,326 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// fileTx транзакция FileMetaStorage, работает с копией данных и запоминает операции для журнала
+type fileTx struct {
+	meta *metaData
+	ops  []journalEntry
+	err  error
+}
+
+func newFileTx(meta *metaData) *fileTx {
+	return &fileTx{meta: meta}
+}
+
+func (t *fileTx) GetTags() []string {
+	if t.meta.Tags == nil {
+		return nil
+	}
+
+	return append([]string(nil), t.meta.Tags...)
+}
+
+func (t *fileTx) SetTags(tags []string) {
+	t.record(opSetTags, tags)
+	t.meta.Tags = append([]string(nil), tags...)
+}
+
+func (t *fileTx) GetTagsAliases() map[string]string {
+	if t.meta.TagsAliases == nil {
+		return nil
+	}
+
+	aliases := make(map[string]string, len(t.meta.TagsAliases))
+	for alias, tag := range t.meta.TagsAliases {
+		aliases[alias] = tag
+	}
+
+	return aliases
+}
+
+func (t *fileTx) SetTagsAliases(aliases map[string]string) {
+	t.record(opSetTagsAliases, aliases)
+
+	t.meta.TagsAliases = make(map[string]string, len(aliases))
+	for alias, tag := range aliases {
+		t.meta.TagsAliases[alias] = tag
+	}
+}
+
+func (t *fileTx) GetSentAnimations() map[string]*SentAnimation {
+	if t.meta.Messages == nil {
+		return nil
+	}
+
+	messages := make(map[string]*SentAnimation, len(t.meta.Messages))
+	for key, msg := range t.meta.Messages {
+		messages[key] = msg.clone()
+	}
+
+	return messages
+}
+
+func (t *fileTx) AddSentAnimations(messages map[string]*SentAnimation) {
+	t.record(opAddSentAnimations, messages)
+
+	if t.meta.Messages == nil {
+		t.meta.Messages = make(map[string]*SentAnimation, len(messages))
+	}
+
+	for key, msg := range messages {
+		t.meta.Messages[key] = msg.clone()
+	}
+}
+
+func (t *fileTx) DeleteSentAnimations(fileIDs ...string) {
+	t.record(opDeleteSentAnimations, fileIDs)
+
+	for _, id := range fileIDs {
+		delete(t.meta.Messages, id)
+	}
+}
+
+func (t *fileTx) SetFavChannelLastForwardedMessageIDWithoutCaption(id int64) {
+	if t.meta.LastForwardedMessageIDWithoutCaption == id {
+		return
+	}
+
+	t.record(opSetFavChannelLastForwardedMessageIDWithoutCaption, id)
+	t.meta.LastForwardedMessageIDWithoutCaption = id
+}
+
+func (t *fileTx) GetFavChannelLastForwardedMessageIDWithoutCaption() int64 {
+	return t.meta.LastForwardedMessageIDWithoutCaption
+}
+
+func (t *fileTx) SetLastUpdateID(id int) {
+	if t.meta.LastUpdateID == id {
+		return
+	}
+
+	t.record(opSetLastUpdateID, id)
+	t.meta.LastUpdateID = id
+}
+
+func (t *fileTx) GetLastUpdateID() int {
+	return t.meta.LastUpdateID
+}
+
+func (t *fileTx) GetHandledUpdateIDs() []int {
+	return append([]int(nil), t.meta.HandledUpdateIDs...)
+}
+
+func (t *fileTx) SetHandledUpdateIDs(ids []int) {
+	if len(ids) == 0 && len(t.meta.HandledUpdateIDs) == 0 {
+		return
+	}
+
+	t.record(opSetHandledUpdateIDs, ids)
+	t.meta.HandledUpdateIDs = append([]int(nil), ids...)
+}
+
+func (t *fileTx) GetUpdateFailures() map[int]int {
+	return cloneUpdateFailures(t.meta.UpdateFailures)
+}
+
+func (t *fileTx) SetUpdateFailures(failures map[int]int) {
+	if len(failures) == 0 && len(t.meta.UpdateFailures) == 0 {
+		return
+	}
+
+	t.record(opSetUpdateFailures, failures)
+	if len(failures) == 0 {
+		t.meta.UpdateFailures = nil
+
+		return
+	}
+	t.meta.UpdateFailures = cloneUpdateFailures(failures)
+}
+
+func (t *fileTx) GetTagsIndex() []*TagsIndexMessage {
+	return cloneTagsIndex(t.meta.TagsIndex)
+}
+
+func (t *fileTx) SetTagsIndex(index []*TagsIndexMessage) {
+	t.record(opSetTagsIndex, index)
+	t.meta.TagsIndex = cloneTagsIndex(index)
+}
+
+func (t *fileTx) GetUserRoles() map[int]string {
+	return cloneUserRoles(t.meta.UserRoles)
+}
+
+func (t *fileTx) SetUserRoles(roles map[int]string) {
+	t.record(opSetUserRoles, roles)
+	t.meta.UserRoles = cloneUserRoles(roles)
+}
+
+func (t *fileTx) GetPendingTypos() map[string]*PendingTypos {
+	return clonePendingTypos(t.meta.PendingTypos)
+}
+
+func (t *fileTx) SetPendingTypos(pending map[string]*PendingTypos) {
+	t.record(opSetPendingTypos, pending)
+	t.meta.PendingTypos = clonePendingTypos(pending)
+}
+
+// AddTagChanges в журнал пишет итоговые истории затронутых гифок, а не сами изменения,
+// чтобы повторное применение журнала не задваивало записи
+func (t *fileTx) AddTagChanges(changes ...*TagChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	histories := make(map[string][]*TagChange)
+	for _, change := range changes {
+		history, ok := histories[change.FileID]
+		if !ok {
+			history = cloneTagHistory(t.meta.TagHistory[change.FileID])
+		}
+		histories[change.FileID] = append(history, change.clone())
+	}
+
+	t.setTagHistory(histories)
+}
+
+func (t *fileTx) GetTagHistory(fileID string) []*TagChange {
+	return cloneTagHistory(t.meta.TagHistory[fileID])
+}
+
+func (t *fileTx) GetFavChannelAnimations() map[string]*FavChannelAnimation {
+	animations := make(map[string]*FavChannelAnimation, len(t.meta.FavChannelAnimations))
+	for key, anim := range t.meta.FavChannelAnimations {
+		animations[key] = anim.clone()
+	}
+
+	return animations
+}
+
+func (t *fileTx) AddFavChannelAnimations(animations map[string]*FavChannelAnimation) {
+	t.record(opAddFavChannelAnimations, animations)
+
+	if t.meta.FavChannelAnimations == nil {
+		t.meta.FavChannelAnimations = make(map[string]*FavChannelAnimation, len(animations))
+	}
+
+	for key, anim := range animations {
+		t.meta.FavChannelAnimations[key] = anim.clone()
+	}
+}
+
+func (t *fileTx) setTagHistory(histories map[string][]*TagChange) {
+	t.record(opSetTagHistory, histories)
+
+	if t.meta.TagHistory == nil {
+		t.meta.TagHistory = make(map[string][]*TagChange, len(histories))
+	}
+
+	for key, history := range histories {
+		t.meta.TagHistory[key] = cloneTagHistory(history)
+	}
+}
+
+func (t *fileTx) record(op string, data interface{}) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		if t.err == nil {
+			t.err = fmt.Errorf("marshal %s: %w", op, err)
+		}
+
+		return
+	}
+
+	t.ops = append(t.ops, journalEntry{Op: op, Data: raw})
+}
+
+// apply повторяет операцию из журнала
+func (t *fileTx) apply(entry journalEntry) error {
+	switch entry.Op {
+	case opSetTags:
+		var tags []string
+		if err := json.Unmarshal(entry.Data, &tags); err != nil {
+			return err
+		}
+		t.SetTags(tags)
+	case opSetTagsAliases:
+		var aliases map[string]string
+		if err := json.Unmarshal(entry.Data, &aliases); err != nil {
+			return err
+		}
+		t.SetTagsAliases(aliases)
+	case opAddSentAnimations:
+		var messages map[string]*SentAnimation
+		if err := json.Unmarshal(entry.Data, &messages); err != nil {
+			return err
+		}
+		t.AddSentAnimations(messages)
+	case opDeleteSentAnimations:
+		var fileIDs []string
+		if err := json.Unmarshal(entry.Data, &fileIDs); err != nil {
+			return err
+		}
+		t.DeleteSentAnimations(fileIDs...)
+	case opSetFavChannelLastForwardedMessageIDWithoutCaption:
+		var id int64
+		if err := json.Unmarshal(entry.Data, &id); err != nil {
+			return err
+		}
+		t.SetFavChannelLastForwardedMessageIDWithoutCaption(id)
+	case opSetTagHistory:
+		var histories map[string][]*TagChange
+		if err := json.Unmarshal(entry.Data, &histories); err != nil {
+			return err
+		}
+		t.setTagHistory(histories)
+	case opAddFavChannelAnimations:
+		var animations map[string]*FavChannelAnimation
+		if err := json.Unmarshal(entry.Data, &animations); err != nil {
+			return err
+		}
+		t.AddFavChannelAnimations(animations)
+	case opSetLastUpdateID:
+		var id int
+		if err := json.Unmarshal(entry.Data, &id); err != nil {
+			return err
+		}
+		t.SetLastUpdateID(id)
+	case opSetHandledUpdateIDs:
+		var ids []int
+		if err := json.Unmarshal(entry.Data, &ids); err != nil {
+			return err
+		}
+		t.SetHandledUpdateIDs(ids)
+	case opSetUpdateFailures:
+		var failures map[int]int
+		if err := json.Unmarshal(entry.Data, &failures); err != nil {
+			return err
+		}
+		t.SetUpdateFailures(failures)
+	case opSetTagsIndex:
+		var index []*TagsIndexMessage
+		if err := json.Unmarshal(entry.Data, &index); err != nil {
+			return err
+		}
+		t.SetTagsIndex(index)
+	case opSetUserRoles:
+		var roles map[int]string
+		if err := json.Unmarshal(entry.Data, &roles); err != nil {
+			return err
+		}
+		t.SetUserRoles(roles)
+	case opSetPendingTypos:
+		var pending map[string]*PendingTypos
+		if err := json.Unmarshal(entry.Data, &pending); err != nil {
+			return err
+		}
+		t.SetPendingTypos(pending)
+	default:
+		return fmt.Errorf("unknown operation '%s'", entry.Op)
+	}
+
+	return nil
+}
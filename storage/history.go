@@ -0,0 +1,40 @@
+package storage
+
+import "time"
+
+// TagChange одно изменение тегов гифки, по ним можно узнать, кто и когда перетегал гифку, и откатить ее
+type TagChange struct {
+	FileID    string
+	ChangedAt time.Time
+	// UserID и UserName автор изменения в телеграме, пустые если изменение сделано из консоли
+	UserID   int    `json:",omitempty"`
+	UserName string `json:",omitempty"`
+	// OldTags теги до изменения, пустые если гифка была отправлена впервые
+	OldTags []string
+	NewTags []string
+	// OldDescription и NewDescription описание до и после изменения. В старых записях описания нет,
+	// оно лежит в тегах
+	OldDescription string `json:",omitempty"`
+	NewDescription string `json:",omitempty"`
+}
+
+func (c *TagChange) clone() *TagChange {
+	cc := *c
+	cc.OldTags = append([]string(nil), c.OldTags...)
+	cc.NewTags = append([]string(nil), c.NewTags...)
+
+	return &cc
+}
+
+func cloneTagHistory(history []*TagChange) []*TagChange {
+	if history == nil {
+		return nil
+	}
+
+	c := make([]*TagChange, 0, len(history))
+	for _, change := range history {
+		c = append(c, change.clone())
+	}
+
+	return c
+}
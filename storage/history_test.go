@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaStorage_tagHistory(t *testing.T) {
+	changedAt := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := &TagChange{
+		FileID:    "file_1",
+		ChangedAt: changedAt,
+		UserID:    1,
+		UserName:  "cyhalothrin",
+		NewTags:   []string{"#cat"},
+	}
+	second := &TagChange{
+		FileID:    "file_1",
+		ChangedAt: changedAt.Add(time.Hour),
+		OldTags:   []string{"#cat"},
+		NewTags:   []string{"#cat", "#funny"},
+	}
+	other := &TagChange{
+		FileID:    "file_2",
+		ChangedAt: changedAt,
+		NewTags:   []string{"#dog"},
+	}
+
+	tests := []struct {
+		name string
+		open func(path string) (MetaStorage, error)
+	}{
+		{
+			"file",
+			func(path string) (MetaStorage, error) {
+				return NewFileMetaStorage(path)
+			},
+		},
+		{
+			"bolt",
+			func(path string) (MetaStorage, error) {
+				return NewBoltMetaStorage(path)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "db")
+
+			store, err := tt.open(path)
+			if err != nil {
+				t.Fatalf("open storage: %s", err)
+			}
+
+			store.AddTagChanges(first)
+			store.AddTagChanges(second, other)
+
+			history := store.GetTagHistory("file_1")
+			history[0].NewTags[0] = "#changed"
+			assert.Equal(t, []string{"#cat"}, store.GetTagHistory("file_1")[0].NewTags, "getter should return copy")
+
+			if err := store.Close(); err != nil {
+				t.Fatalf("close storage: %s", err)
+			}
+
+			store, err = tt.open(path)
+			if err != nil {
+				t.Fatalf("reopen storage: %s", err)
+			}
+			defer store.Close()
+
+			assert.Equal(t, []*TagChange{first, second}, store.GetTagHistory("file_1"))
+			assert.Equal(t, []*TagChange{other}, store.GetTagHistory("file_2"))
+			assert.Empty(t, store.GetTagHistory("file_3"))
+		})
+	}
+}
+
+func TestFileMetaStorage_tagHistoryJournalReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+
+	store, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+
+	change := &TagChange{FileID: "file_1", ChangedAt: time.Unix(100, 0).UTC(), NewTags: []string{"#cat"}}
+	store.AddTagChanges(change)
+	// файл записан, а журнал не очищен: так бывает, если упасть между записью и очисткой
+	if err := store.Write(); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+	store.journal.close()
+
+	store, err = NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("reopen storage: %s", err)
+	}
+	defer store.Close()
+
+	assert.Equal(t, []*TagChange{change}, store.GetTagHistory("file_1"), "replay should not duplicate changes")
+}
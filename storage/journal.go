@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+)
+
+const (
+	opSetTags                                           = "SetTags"
+	opSetTagsAliases                                    = "SetTagsAliases"
+	opAddSentAnimations                                 = "AddSentAnimations"
+	opDeleteSentAnimations                              = "DeleteSentAnimations"
+	opSetFavChannelLastForwardedMessageIDWithoutCaption = "SetFavChannelLastForwardedMessageIDWithoutCaption"
+	opSetTagHistory                                     = "SetTagHistory"
+	opAddFavChannelAnimations                           = "AddFavChannelAnimations"
+	opSetLastUpdateID                                   = "SetLastUpdateID"
+	opSetHandledUpdateIDs                               = "SetHandledUpdateIDs"
+	opSetUpdateFailures                                 = "SetUpdateFailures"
+	opSetTagsIndex                                      = "SetTagsIndex"
+	opSetUserRoles                                      = "SetUserRoles"
+	opSetPendingTypos                                   = "SetPendingTypos"
+)
+
+// journalEntry одна операция изменения хранилища.
+// Все операции идемпотентны (заменяют значение, а не дописывают), поэтому журнал можно
+// безопасно проиграть поверх снимка, в который эти изменения уже попали
+type journalEntry struct {
+	Op   string
+	Data json.RawMessage
+}
+
+// journalRecord строка журнала, все операции одной транзакции. Применяется целиком или никак
+type journalRecord struct {
+	Ops []journalEntry
+}
+
+// journal журнал изменений FileMetaStorage, в него пишется каждая операция сразу же,
+// а основной файл перезаписывается только при Flush, после чего журнал очищается
+type journal struct {
+	file *os.File
+}
+
+func journalPath(storagePath string) string {
+	return storagePath + ".journal"
+}
+
+func openJournal(path string) (*journal, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+
+	return &journal{file: f}, nil
+}
+
+func (j *journal) append(ops []journalEntry) error {
+	line, err := json.Marshal(journalRecord{Ops: ops})
+	if err != nil {
+		return fmt.Errorf("marshal journal entry: %w", err)
+	}
+
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("sync journal: %w", err)
+	}
+
+	return nil
+}
+
+// truncate очищает журнал, вызывается после того как снимок успешно записан
+func (j *journal) truncate() error {
+	if err := j.file.Truncate(0); err != nil {
+		return fmt.Errorf("truncate journal: %w", err)
+	}
+
+	return j.file.Sync()
+}
+
+func (j *journal) close() error {
+	return j.file.Close()
+}
+
+// replayJournal читает журнал и применяет каждую запись, вернет количество примененных.
+// Недописанная при падении последняя строка пропускается
+func replayJournal(path string, apply func(ops []journalEntry) error) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("open journal: %w", err)
+	}
+	defer f.Close()
+
+	applied := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record journalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			log.Printf("skip broken journal record #%d: %s\n", applied+1, err)
+
+			continue
+		}
+
+		if err := apply(record.Ops); err != nil {
+			return applied, fmt.Errorf("apply journal record #%d: %w", applied+1, err)
+		}
+		applied++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return applied, fmt.Errorf("read journal: %w", err)
+	}
+
+	return applied, nil
+}
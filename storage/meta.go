@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+const (
+	// DriverFile хранит все в одном json файле, который целиком читается при старте
+	DriverFile = "file"
+	// DriverBolt встраиваемая key/value база bbolt, пишет только то, что изменилось
+	DriverBolt = "bolt"
+)
+
+// ErrReadOnly хранилище открыто только для чтения
+var ErrReadOnly = errors.New("storage is opened read only")
+
+// MetaTx операции с хранилищем внутри транзакции
+type MetaTx interface {
+	GetTags() []string
+	SetTags([]string)
+	GetTagsAliases() map[string]string
+	SetTagsAliases(map[string]string)
+	GetSentAnimations() map[string]*SentAnimation
+	// AddSentAnimations adds new sent animations to storage
+	AddSentAnimations(map[string]*SentAnimation)
+	DeleteSentAnimations(fileIDs ...string)
+	SetFavChannelLastForwardedMessageIDWithoutCaption(int64)
+	GetFavChannelLastForwardedMessageIDWithoutCaption() int64
+	// AddTagChanges дописывает изменения в историю тегов гифок
+	AddTagChanges(changes ...*TagChange)
+	// GetTagHistory история изменений тегов гифки, от старых к новым
+	GetTagHistory(fileID string) []*TagChange
+	// GetFavChannelAnimations гифки из избранного для переноса в канал, по fileID
+	GetFavChannelAnimations() map[string]*FavChannelAnimation
+	// AddFavChannelAnimations добавляет или заменяет гифки из избранного
+	AddFavChannelAnimations(map[string]*FavChannelAnimation)
+	// SetLastUpdateID id последнего обработанного обновления бота, со следующего начнется getUpdates
+	SetLastUpdateID(id int)
+	GetLastUpdateID() int
+	// GetHandledUpdateIDs обновления после LastUpdateID, которые уже обработаны, но подтвердить их нельзя,
+	// пока не обработано обновление перед ними. Повторно их обрабатывать не нужно
+	GetHandledUpdateIDs() []int
+	SetHandledUpdateIDs([]int)
+	// GetUpdateFailures сколько раз не удалось обработать обновления после LastUpdateID, по id обновления
+	GetUpdateFailures() map[int]int
+	SetUpdateFailures(map[int]int)
+	// GetTagsIndex сообщения списка тегов в канале по порядку
+	GetTagsIndex() []*TagsIndexMessage
+	SetTagsIndex([]*TagsIndexMessage)
+	// GetUserRoles роли пользователей бота по id в телеге
+	GetUserRoles() map[int]string
+	SetUserRoles(map[int]string)
+	// GetPendingTypos подписи, которые ждут ответа на подсказку про опечатки, по id подсказки
+	GetPendingTypos() map[string]*PendingTypos
+	SetPendingTypos(map[string]*PendingTypos)
+}
+
+// MetaStorage хранилище всего, что знает бот о гифках и тегах.
+// Безопасно для одновременного использования, геттеры возвращают копии, которые можно менять
+type MetaStorage interface {
+	MetaTx
+	// Update выполнит fn в транзакции, изменения применятся только если fn вернет nil
+	Update(fn func(tx MetaTx) error) error
+	// Flush сбрасывает накопленные изменения на диск
+	Flush() error
+	Close() error
+}
+
+// NewMetaStorage открывает хранилище, указанное в конфиге
+func NewMetaStorage(conf config.Config) (MetaStorage, error) {
+	switch conf.StorageDriver {
+	case "", DriverFile:
+		return NewFileMetaStorage(conf.StoragePath)
+	case DriverBolt:
+		return NewBoltMetaStorage(conf.StoragePath)
+	}
+
+	return nil, fmt.Errorf("unknown storage driver '%s'", conf.StorageDriver)
+}
+
+// NewMetaStorageReadOnly открывает хранилище из конфига только для чтения, для команд, которые ничего не меняют.
+// Их можно запускать, пока работает бот: файл, журнал и база не перезаписываются, изменения возвращают ErrReadOnly
+func NewMetaStorageReadOnly(conf config.Config) (MetaStorage, error) {
+	switch conf.StorageDriver {
+	case "", DriverFile:
+		return NewFileMetaStorageReadOnly(conf.StoragePath)
+	case DriverBolt:
+		return NewBoltMetaStorageReadOnly(conf.StoragePath)
+	}
+
+	return nil, fmt.Errorf("unknown storage driver '%s'", conf.StorageDriver)
+}
+
+// NewMetaStorageForWrite как NewMetaStorage, но перед открытием делает бэкап, если он не отключен в конфиге.
+// Для бота и команд, которые меняют базу, тем, кто только читает, бэкап не нужен
+func NewMetaStorageForWrite(conf config.Config) (MetaStorage, error) {
+	if !conf.Backup.Disabled {
+		if _, err := NewBackupManager(conf).Create(); err != nil {
+			// из-за бэкапа бот не должен переставать работать
+			log.Println("storage backup:", err)
+		}
+	}
+
+	return NewMetaStorage(conf)
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMetaStorage одни и те же случаи для всех драйверов, геттеры должны вести себя одинаково
+func TestMetaStorage(t *testing.T) {
+	drivers := []struct {
+		name string
+		open func(path string) (MetaStorage, error)
+	}{
+		{
+			name: "file",
+			open: func(path string) (MetaStorage, error) {
+				return NewFileMetaStorage(path)
+			},
+		},
+		{
+			name: "bolt",
+			open: func(path string) (MetaStorage, error) {
+				return NewBoltMetaStorage(path)
+			},
+		},
+	}
+
+	for _, driver := range drivers {
+		t.Run(driver.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "db")
+
+			store, err := driver.open(path)
+			if err != nil {
+				t.Fatalf("open storage: %s", err)
+			}
+
+			assert.Nil(t, store.GetTags())
+			assert.Nil(t, store.GetHandledUpdateIDs())
+			assert.Nil(t, store.GetUpdateFailures())
+			assert.Nil(t, store.GetTagsIndex())
+			assert.Nil(t, store.GetUserRoles())
+			assert.Nil(t, store.GetPendingTypos())
+			assert.Nil(t, store.GetTagHistory("file_1"))
+
+			store.SetTags([]string{"#tag2", "#tag1"})
+			store.SetTagsAliases(map[string]string{"#kot": "#cat"})
+			store.AddSentAnimations(map[string]*SentAnimation{
+				"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#tag1"}, Description: "описание"},
+			})
+			store.AddSentAnimations(map[string]*SentAnimation{
+				"file_2": {MessageID: 20, FileID: "file_2", Tags: []string{"#tag2"}, ChannelMessages: map[int64]int{-200: 5}},
+			})
+			store.SetFavChannelLastForwardedMessageIDWithoutCaption(100500)
+			store.SetLastUpdateID(700)
+			store.SetHandledUpdateIDs([]int{702, 703})
+			store.SetUpdateFailures(map[int]int{701: 2})
+			store.SetTagsIndex([]*TagsIndexMessage{{MessageID: 1, Text: "#tag1"}, {MessageID: 2, Text: "#tag2"}})
+			store.SetUserRoles(map[int]string{42: "admin", 43: "tagger"})
+			store.SetPendingTypos(testPendingTypos())
+			store.AddTagChanges(&TagChange{FileID: "file_1", NewTags: []string{"#tag1"}})
+			store.AddFavChannelAnimations(map[string]*FavChannelAnimation{
+				"file_3": {FileID: "file_3", Tags: []string{"#tag3"}, SourceMessageID: 30, Status: PublishStatusCollected},
+			})
+
+			if err := store.Close(); err != nil {
+				t.Fatalf("close storage: %s", err)
+			}
+
+			store, err = driver.open(path)
+			if err != nil {
+				t.Fatalf("reopen storage: %s", err)
+			}
+			defer store.Close()
+
+			assert.Equal(t, []string{"#tag2", "#tag1"}, store.GetTags(), "tags should keep their order")
+			assert.Equal(t, map[string]string{"#kot": "#cat"}, store.GetTagsAliases())
+			assert.Equal(t, map[string]*SentAnimation{
+				"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#tag1"}, Description: "описание"},
+				"file_2": {MessageID: 20, FileID: "file_2", Tags: []string{"#tag2"}, ChannelMessages: map[int64]int{-200: 5}},
+			}, store.GetSentAnimations())
+			assert.Equal(t, int64(100500), store.GetFavChannelLastForwardedMessageIDWithoutCaption())
+			assert.Equal(t, 700, store.GetLastUpdateID())
+			assert.Equal(t, []int{702, 703}, store.GetHandledUpdateIDs())
+			assert.Equal(t, map[int]int{701: 2}, store.GetUpdateFailures())
+			assert.Equal(t, []*TagsIndexMessage{{MessageID: 1, Text: "#tag1"}, {MessageID: 2, Text: "#tag2"}}, store.GetTagsIndex())
+			assert.Equal(t, map[int]string{42: "admin", 43: "tagger"}, store.GetUserRoles())
+			assert.Equal(t, testPendingTypos(), store.GetPendingTypos())
+			assert.Equal(t, []*TagChange{{FileID: "file_1", NewTags: []string{"#tag1"}}}, store.GetTagHistory("file_1"))
+			assert.Equal(t, map[string]*FavChannelAnimation{
+				"file_3": {FileID: "file_3", Tags: []string{"#tag3"}, SourceMessageID: 30, Status: PublishStatusCollected},
+			}, store.GetFavChannelAnimations())
+
+			store.DeleteSentAnimations("file_2")
+			assert.Len(t, store.GetSentAnimations(), 1)
+
+			store.SetTags(nil)
+			assert.Nil(t, store.GetTags())
+			store.SetHandledUpdateIDs(nil)
+			assert.Nil(t, store.GetHandledUpdateIDs())
+			store.SetUpdateFailures(map[int]int{})
+			assert.Nil(t, store.GetUpdateFailures())
+
+			store.SetTags([]string{"#tag3"})
+			err = store.Update(func(tx MetaTx) error {
+				tx.SetTags([]string{"#tag4"})
+
+				return errors.New("rollback")
+			})
+			assert.Error(t, err)
+			assert.Equal(t, []string{"#tag3"}, store.GetTags(), "changes should be rolled back")
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// CurrentVersion версия схемы файла хранилища, которую понимает этот код.
+// При изменении metaData нужно поднять версию и добавить миграцию в migrations
+const CurrentVersion = 10
+
+// ErrNewerVersion файл записан более новой версией бота, трогать его нельзя
+var ErrNewerVersion = errors.New("storage file has newer schema version")
+
+// migration переводит документ из версии version-1 в version.
+// Работает с сырым json, а не с metaData, потому что старые файлы могут не ложиться в текущие структуры
+type migration struct {
+	version     int
+	description string
+	up          func(doc map[string]interface{}) error
+}
+
+var migrations = []migration{
+	{
+		version:     1,
+		description: "add schema version, fill missing fields with defaults",
+		up:          migrateToVersion1,
+	},
+	{
+		version:     2,
+		description: "add tag change history",
+		up:          migrateToVersion2,
+	},
+	{
+		version:     3,
+		description: "add fav channel publish state",
+		up:          migrateToVersion3,
+	},
+	{
+		version:     4,
+		description: "add last processed update id",
+		up:          migrateToVersion4,
+	},
+	{
+		version:     5,
+		description: "add tags index messages",
+		up:          migrateToVersion5,
+	},
+	{
+		version:     6,
+		description: "add user roles",
+		up:          migrateToVersion6,
+	},
+	{
+		version:     7,
+		description: "move descriptions out of tags",
+		up:          migrateToVersion7,
+	},
+	{
+		version:     8,
+		description: "add handled update ids",
+		up:          migrateToVersion8,
+	},
+	{
+		version:     9,
+		description: "add pending typo suggestions",
+		up:          migrateToVersion9,
+	},
+	{
+		version:     10,
+		description: "add update failure counters",
+		up:          migrateToVersion10,
+	},
+}
+
+// MigrationReport результат миграции файла
+type MigrationReport struct {
+	FromVersion int
+	ToVersion   int
+	// Applied описания примененных миграций по порядку
+	Applied []string
+	// Diff разница между исходным и новым файлом в формате unified diff
+	Diff string
+}
+
+// MigrateFile поднимает версию файла хранилища до текущей.
+// С dryRun только посчитает разницу, файл не изменится
+func MigrateFile(path string, dryRun bool) (*MigrationReport, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read storage file: %w", err)
+	}
+
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+
+	report, err := migrateDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+
+	report.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: fmt.Sprintf("%s (v%d)", path, report.FromVersion),
+		ToFile:   fmt.Sprintf("%s (v%d)", path, report.ToVersion),
+		Context:  2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("diff: %w", err)
+	}
+
+	if dryRun || len(report.Applied) == 0 {
+		return report, nil
+	}
+
+	if err := writeFileAtomic(path, after, 0666); err != nil {
+		return nil, fmt.Errorf("write migrated file: %w", err)
+	}
+
+	return report, nil
+}
+
+// loadMetaData разбирает содержимое файла хранилища, при необходимости мигрируя его.
+// Вернет true, если документ был мигрирован и его стоит перезаписать
+func loadMetaData(data []byte) (*metaData, bool, error) {
+	meta := &metaData{}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		meta.Version = CurrentVersion
+
+		return meta, false, nil
+	}
+
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	report, err := migrateDocument(doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshal migrated document: %w", err)
+	}
+
+	if err := json.Unmarshal(migrated, meta); err != nil {
+		return nil, false, fmt.Errorf("parse meta data: %w", err)
+	}
+
+	return meta, len(report.Applied) > 0, nil
+}
+
+func decodeDocument(data []byte) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+
+	// числа оставим как есть, id сообщений не должны терять точность через float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse storage file: %w", err)
+	}
+
+	return doc, nil
+}
+
+func migrateDocument(doc map[string]interface{}) (*MigrationReport, error) {
+	version, err := documentVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("%w: %d, supported %d", ErrNewerVersion, version, CurrentVersion)
+	}
+
+	report := &MigrationReport{
+		FromVersion: version,
+		ToVersion:   version,
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+
+		if err := m.up(doc); err != nil {
+			return nil, fmt.Errorf("migrate to version %d: %w", m.version, err)
+		}
+		doc["Version"] = m.version
+
+		report.ToVersion = m.version
+		report.Applied = append(report.Applied, fmt.Sprintf("v%d: %s", m.version, m.description))
+	}
+
+	return report, nil
+}
+
+func documentVersion(doc map[string]interface{}) (int, error) {
+	raw, ok := doc["Version"]
+	if !ok {
+		// файлы до появления версии
+		return 0, nil
+	}
+
+	number, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid schema version: %v", raw)
+	}
+
+	version, err := number.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version: %w", err)
+	}
+
+	return int(version), nil
+}
+
+// migrateToVersion1 в самых старых файлах не было алиасов и id последнего пересланного сообщения,
+// а пустые списки могли быть записаны как null
+func migrateToVersion1(doc map[string]interface{}) error {
+	if doc["Tags"] == nil {
+		doc["Tags"] = []interface{}{}
+	}
+	if doc["TagsAliases"] == nil {
+		doc["TagsAliases"] = map[string]interface{}{}
+	}
+	if doc["Messages"] == nil {
+		doc["Messages"] = map[string]interface{}{}
+	}
+	if _, ok := doc["LastForwardedMessageIDWithoutCaption"]; !ok {
+		doc["LastForwardedMessageIDWithoutCaption"] = 0
+	}
+
+	return nil
+}
+
+// migrateToVersion2 история изменений тегов, для уже отправленных гифок она начинается с пустой
+func migrateToVersion2(doc map[string]interface{}) error {
+	if doc["TagHistory"] == nil {
+		doc["TagHistory"] = map[string]interface{}{}
+	}
+
+	return nil
+}
+
+// migrateToVersion3 состояние переноса гифок из избранного раньше жило в отдельном файле,
+// его переносит publish --import-list
+func migrateToVersion3(doc map[string]interface{}) error {
+	if doc["FavChannelAnimations"] == nil {
+		doc["FavChannelAnimations"] = map[string]interface{}{}
+	}
+
+	return nil
+}
+
+// migrateToVersion4 раньше обновления не подтверждались, начнем с первого, что отдаст телега
+func migrateToVersion4(doc map[string]interface{}) error {
+	if _, ok := doc["LastUpdateID"]; !ok {
+		doc["LastUpdateID"] = 0
+	}
+
+	return nil
+}
+
+// migrateToVersion5 какое закрепленное сообщение было списком тегов, не известно,
+// бот отправит список заново и запомнит свои сообщения
+func migrateToVersion5(doc map[string]interface{}) error {
+	if _, ok := doc["TagsIndex"]; !ok {
+		doc["TagsIndex"] = nil
+	}
+
+	return nil
+}
+
+// migrateToVersion6 раньше доступ был только по именам из конфига, выданных ролей еще нет
+func migrateToVersion6(doc map[string]interface{}) error {
+	if doc["UserRoles"] == nil {
+		doc["UserRoles"] = map[string]interface{}{}
+	}
+
+	return nil
+}
+
+// migrateToVersion7 раньше описание гифки лежало в тегах отдельным элементом, теперь у него свое поле.
+// История изменений не трогается, в старых записях описание остается в тегах
+func migrateToVersion7(doc map[string]interface{}) error {
+	messages, _ := doc["Messages"].(map[string]interface{})
+	for fileID, raw := range messages {
+		msg, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("message %s: unexpected format", fileID)
+		}
+
+		tags, _ := msg["Tags"].([]interface{})
+		hashtags := make([]interface{}, 0, len(tags))
+		var description []string
+		for _, rawTag := range tags {
+			tag, ok := rawTag.(string)
+			if !ok {
+				return fmt.Errorf("message %s: tag is not a string: %v", fileID, rawTag)
+			}
+
+			switch {
+			case strings.HasPrefix(tag, "#"):
+				hashtags = append(hashtags, tag)
+			case tag != "":
+				description = append(description, tag)
+			}
+		}
+
+		if len(description) == 0 {
+			continue
+		}
+
+		msg["Tags"] = hashtags
+		if current, _ := msg["Description"].(string); current != "" {
+			description = append([]string{current}, description...)
+		}
+		msg["Description"] = strings.Join(description, " ")
+	}
+
+	return nil
+}
+
+// migrateToVersion8 раньше обновления подтверждались только все вместе, обработанных сверх LastUpdateID нет
+func migrateToVersion8(doc map[string]interface{}) error {
+	if _, ok := doc["HandledUpdateIDs"]; !ok {
+		doc["HandledUpdateIDs"] = nil
+	}
+
+	return nil
+}
+
+// migrateToVersion9 подсказки про опечатки раньше жили только в памяти бота, сохраненных еще нет
+func migrateToVersion9(doc map[string]interface{}) error {
+	if doc["PendingTypos"] == nil {
+		doc["PendingTypos"] = map[string]interface{}{}
+	}
+
+	return nil
+}
+
+// migrateToVersion10 раньше неудачные попытки обработать обновление не считались, начнем с нуля
+func migrateToVersion10(doc map[string]interface{}) error {
+	if _, ok := doc["UpdateFailures"]; !ok {
+		doc["UpdateFailures"] = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const legacyStorageFile = `{"Tags":["#cat"],"TagsAliases":null,"Messages":{"file_1":{"MessageID":10,"FileID":"file_1","Tags":["#cat","кот упал"]}}}`
+
+func TestMigrateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	if err := ioutil.WriteFile(path, []byte(legacyStorageFile), 0666); err != nil {
+		t.Fatalf("write file: %s", err)
+	}
+
+	report, err := MigrateFile(path, true)
+	if err != nil {
+		t.Fatalf("dry run: %s", err)
+	}
+	assert.Equal(t, 0, report.FromVersion)
+	assert.Equal(t, CurrentVersion, report.ToVersion)
+	assert.Len(t, report.Applied, len(migrations))
+	assert.Contains(t, report.Diff, `+  "Version": 10`)
+	assert.Contains(t, report.Diff, `+  "TagHistory": {}`)
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %s", err)
+	}
+	assert.Equal(t, legacyStorageFile, string(content), "dry run should not change file")
+
+	if _, err := MigrateFile(path, false); err != nil {
+		t.Fatalf("migrate: %s", err)
+	}
+
+	report, err = MigrateFile(path, true)
+	if err != nil {
+		t.Fatalf("dry run after migration: %s", err)
+	}
+	assert.Empty(t, report.Applied)
+}
+
+func TestNewFileMetaStorage_migration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	if err := ioutil.WriteFile(path, []byte(legacyStorageFile), 0666); err != nil {
+		t.Fatalf("write file: %s", err)
+	}
+
+	store, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+
+	assert.Equal(t, CurrentVersion, store.meta.Version)
+	assert.Equal(t, map[string]string{}, store.GetTagsAliases())
+	assert.Equal(t, []string{"#cat"}, store.GetTags())
+	assert.Equal(t, map[string]*SentAnimation{
+		"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#cat"}, Description: "кот упал"},
+	}, store.GetSentAnimations())
+	assert.True(t, store.hasChanges, "migrated file should be rewritten")
+	store.Close()
+
+	if err := ioutil.WriteFile(path, []byte(`{"Version":100}`), 0666); err != nil {
+		t.Fatalf("write file: %s", err)
+	}
+
+	_, err = NewFileMetaStorage(path)
+	assert.True(t, errors.Is(err, ErrNewerVersion), "got %v", err)
+}
@@ -0,0 +1,46 @@
+package storage
+
+import "time"
+
+// PendingTypos подпись с подозрительными тегами, ждет ответа автора на подсказку про опечатки
+type PendingTypos struct {
+	FileID      string
+	Tags        []string
+	Description string  `json:",omitempty"`
+	Channels    []int64 `json:",omitempty"`
+	Typos       []TagTypo
+	// UserID и UserName автор подписи, попадут в историю изменений
+	UserID    int
+	UserName  string
+	ChangedAt time.Time
+	// AskedAt когда отправлена подсказка, устаревшие подсказки выкидываются
+	AskedAt time.Time
+}
+
+// TagTypo новый тег, похожий на уже существующий
+type TagTypo struct {
+	Tag        string
+	Suggestion string
+}
+
+func (p *PendingTypos) clone() *PendingTypos {
+	c := *p
+	c.Tags = append([]string(nil), p.Tags...)
+	c.Channels = append([]int64(nil), p.Channels...)
+	c.Typos = append([]TagTypo(nil), p.Typos...)
+
+	return &c
+}
+
+func clonePendingTypos(pending map[string]*PendingTypos) map[string]*PendingTypos {
+	if pending == nil {
+		return nil
+	}
+
+	c := make(map[string]*PendingTypos, len(pending))
+	for key, p := range pending {
+		c[key] = p.clone()
+	}
+
+	return c
+}
@@ -2,17 +2,24 @@ package storage
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
+	"log"
 	"os"
+	"sync"
 )
 
+// FileMetaStorage хранит все в одном json файле. Каждое изменение сразу дописывается в журнал
+// рядом с файлом, а сам файл атомарно перезаписывается при Flush/Close
 type FileMetaStorage struct {
+	// mu защищает meta, hasChanges и журнал, бот и публикатор могут работать с хранилищем одновременно
+	mu         sync.RWMutex
 	filename   string
 	meta       *metaData
 	hasChanges bool
+	journal    *journal
+	// readOnly открыто только для чтения, журнала у такого хранилища нет, файл оно не трогает
+	readOnly bool
 }
 
 func NewFileMetaStorage(path string) (*FileMetaStorage, error) {
@@ -22,99 +29,476 @@ func NewFileMetaStorage(path string) (*FileMetaStorage, error) {
 	}
 	defer f.Close()
 
-	meta := &metaData{}
-	if err := json.NewDecoder(f).Decode(meta); err != nil {
-		if !errors.Is(err, io.EOF) {
-			return nil, err
-		}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read storage file: %w", err)
+	}
+
+	meta, migrated, err := loadMetaData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &FileMetaStorage{
+		filename: path,
+		meta:     meta,
+		// старая версия файла перезапишется в новой при первом Flush
+		hasChanges: migrated,
+	}
+
+	// изменения, которые не успели попасть в файл до падения
+	replayed, err := replayJournal(journalPath(path), store.applyJournalRecord)
+	if err != nil {
+		return nil, err
+	}
+	if replayed > 0 {
+		log.Printf("restored %d changes from journal\n", replayed)
+	}
+
+	store.journal, err = openJournal(journalPath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// NewFileMetaStorageReadOnly откроет хранилище только для чтения, для команд, которые ничего не меняют.
+// Изменения из журнала подхватываются в память, но файл и журнал не создаются, не перезаписываются
+// и не удаляются, так что бот, который работает в это время с тем же файлом, ничего не заметит
+func NewFileMetaStorageReadOnly(path string) (*FileMetaStorage, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read storage file: %w", err)
 	}
 
-	return &FileMetaStorage{
+	meta, _, err := loadMetaData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &FileMetaStorage{
 		filename: path,
 		meta:     meta,
-	}, nil
+		readOnly: true,
+	}
+
+	if _, err := replayJournal(journalPath(path), store.applyJournalRecord); err != nil {
+		return nil, err
+	}
+
+	return store, nil
 }
 
-func (f *FileMetaStorage) GetTags() []string {
-	return f.meta.Tags
+func (f *FileMetaStorage) GetTags() (tags []string) {
+	f.view(func(tx *fileTx) {
+		tags = tx.GetTags()
+	})
+
+	return tags
 }
 
 func (f *FileMetaStorage) SetTags(tags []string) {
-	f.hasChanges = true
-	f.meta.Tags = tags
+	f.update(func(tx *fileTx) {
+		tx.SetTags(tags)
+	})
 }
 
-func (f *FileMetaStorage) GetTagsAliases() map[string]string {
-	return f.meta.TagsAliases
+func (f *FileMetaStorage) GetTagsAliases() (aliases map[string]string) {
+	f.view(func(tx *fileTx) {
+		aliases = tx.GetTagsAliases()
+	})
+
+	return aliases
 }
 
 func (f *FileMetaStorage) SetTagsAliases(aliases map[string]string) {
-	f.hasChanges = true
-	f.meta.TagsAliases = aliases
+	f.update(func(tx *fileTx) {
+		tx.SetTagsAliases(aliases)
+	})
 }
 
-func (f *FileMetaStorage) GetSentAnimations() map[string]*SentAnimation {
-	return f.meta.Messages
+func (f *FileMetaStorage) GetSentAnimations() (messages map[string]*SentAnimation) {
+	f.view(func(tx *fileTx) {
+		messages = tx.GetSentAnimations()
+	})
+
+	return messages
 }
 
 func (f *FileMetaStorage) AddSentAnimations(messages map[string]*SentAnimation) {
-	f.hasChanges = true
+	f.update(func(tx *fileTx) {
+		tx.AddSentAnimations(messages)
+	})
+}
 
-	if f.meta.Messages == nil {
-		f.meta.Messages = messages
+func (f *FileMetaStorage) DeleteSentAnimations(fileIDs ...string) {
+	f.update(func(tx *fileTx) {
+		tx.DeleteSentAnimations(fileIDs...)
+	})
+}
+
+func (f *FileMetaStorage) SetFavChannelLastForwardedMessageIDWithoutCaption(id int64) {
+	f.update(func(tx *fileTx) {
+		tx.SetFavChannelLastForwardedMessageIDWithoutCaption(id)
+	})
+}
 
-		return
+func (f *FileMetaStorage) GetFavChannelLastForwardedMessageIDWithoutCaption() (id int64) {
+	f.view(func(tx *fileTx) {
+		id = tx.GetFavChannelLastForwardedMessageIDWithoutCaption()
+	})
+
+	return id
+}
+
+func (f *FileMetaStorage) AddTagChanges(changes ...*TagChange) {
+	f.update(func(tx *fileTx) {
+		tx.AddTagChanges(changes...)
+	})
+}
+
+func (f *FileMetaStorage) GetTagHistory(fileID string) (history []*TagChange) {
+	f.view(func(tx *fileTx) {
+		history = tx.GetTagHistory(fileID)
+	})
+
+	return history
+}
+
+func (f *FileMetaStorage) GetFavChannelAnimations() (animations map[string]*FavChannelAnimation) {
+	f.view(func(tx *fileTx) {
+		animations = tx.GetFavChannelAnimations()
+	})
+
+	return animations
+}
+
+func (f *FileMetaStorage) AddFavChannelAnimations(animations map[string]*FavChannelAnimation) {
+	f.update(func(tx *fileTx) {
+		tx.AddFavChannelAnimations(animations)
+	})
+}
+
+// Update выполнит fn в транзакции: изменения применятся все вместе и только если fn вернет nil.
+// Другие читатели и писатели ждут окончания транзакции
+func (f *FileMetaStorage) Update(fn func(tx MetaTx) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.readOnly {
+		return ErrReadOnly
 	}
 
-	for key, msg := range messages {
-		f.meta.Messages[key] = msg
+	tx := newFileTx(f.meta.clone())
+	if err := fn(tx); err != nil {
+		return err
 	}
+
+	return f.commit(tx)
 }
 
-func (f *FileMetaStorage) SetFavChannelLastForwardedMessageIDWithoutCaption(id int64) {
-	if f.meta.LastForwardedMessageIDWithoutCaption != id {
-		f.meta.LastForwardedMessageIDWithoutCaption = id
-		f.hasChanges = true
-	}
+// Flush перезапишет файл, если были изменения, и очистит журнал.
+// Вызывается после каждой пачки обновлений, чтобы журнал не разрастался
+func (f *FileMetaStorage) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.flush()
 }
 
-func (f *FileMetaStorage) GetFavChannelLastForwardedMessageIDWithoutCaption() int64 {
-	return f.meta.LastForwardedMessageIDWithoutCaption
+func (f *FileMetaStorage) flush() error {
+	if f.readOnly || !f.hasChanges {
+		return nil
+	}
+
+	if err := f.write(); err != nil {
+		return err
+	}
+	f.hasChanges = false
+
+	// все из журнала уже в файле
+	return f.journal.truncate()
 }
 
-func (f *FileMetaStorage) Close() {
-	if !f.hasChanges {
-		return
+func (f *FileMetaStorage) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.readOnly {
+		return nil
+	}
+
+	flushed := f.hasChanges
+	flushErr := f.flush()
+
+	if err := f.journal.close(); err != nil && flushErr == nil {
+		return fmt.Errorf("close journal: %w", err)
+	}
+
+	if flushErr != nil {
+		// журнал оставим, при следующем запуске изменения из него восстановятся
+		return flushErr
 	}
 
-	if err := f.Write(); err != nil {
-		panic(err)
+	// удаляем журнал, только если сами только что свернули его в файл
+	if !flushed {
+		return nil
 	}
+
+	if err := os.Remove(journalPath(f.filename)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove journal: %w", err)
+	}
+
+	return nil
 }
 
 func (f *FileMetaStorage) Write() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.readOnly {
+		return ErrReadOnly
+	}
+
+	return f.write()
+}
+
+func (f *FileMetaStorage) write() error {
 	data, err := json.Marshal(f.meta)
 	if err != nil {
 		return fmt.Errorf("marshal meta data: %w", err)
 	}
 
-	if err := ioutil.WriteFile(f.filename, data, 0666); err != nil {
+	if err := writeFileAtomic(f.filename, data, 0666); err != nil {
 		return fmt.Errorf("write meta data file: %w", err)
 	}
 
 	return nil
 }
 
+func (f *FileMetaStorage) view(fn func(tx *fileTx)) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// только чтение, поэтому копия не нужна, геттеры fileTx сами отдают копии
+	fn(newFileTx(f.meta))
+}
+
+// update одиночное изменение без транзакции, ошибок у сеттеров нет, поэтому только залогируем
+func (f *FileMetaStorage) update(fn func(tx *fileTx)) {
+	err := f.Update(func(tx MetaTx) error {
+		fn(tx.(*fileTx))
+
+		return nil
+	})
+	if err != nil {
+		log.Println("storage update:", err)
+	}
+}
+
+// commit пишет операции транзакции в журнал одной записью и подменяет данные
+func (f *FileMetaStorage) commit(tx *fileTx) error {
+	if tx.err != nil {
+		return tx.err
+	}
+
+	if len(tx.ops) == 0 {
+		return nil
+	}
+
+	// если журнал не записался, то изменение все равно попадет в файл при Flush
+	if err := f.journal.append(tx.ops); err != nil {
+		log.Println("storage journal:", err)
+	}
+
+	f.meta = tx.meta
+	f.hasChanges = true
+
+	return nil
+}
+
+func (f *FileMetaStorage) applyJournalRecord(ops []journalEntry) error {
+	tx := newFileTx(f.meta.clone())
+	for _, entry := range ops {
+		if err := tx.apply(entry); err != nil {
+			return err
+		}
+	}
+
+	f.meta = tx.meta
+	f.hasChanges = true
+
+	return nil
+}
+
 type metaData struct {
+	// Version версия схемы, см. CurrentVersion
+	Version     int
 	Tags        []string
 	TagsAliases map[string]string
 	// Messages все отправленные ранее сообщения для редактирования
 	Messages                             map[string]*SentAnimation
 	LastForwardedMessageIDWithoutCaption int64
+	// TagHistory история изменений тегов по fileID
+	TagHistory map[string][]*TagChange
+	// FavChannelAnimations гифки из избранного и их состояние при переносе в канал, по fileID
+	FavChannelAnimations map[string]*FavChannelAnimation
+	// LastUpdateID id последнего обработанного обновления бота
+	LastUpdateID int
+	// HandledUpdateIDs обработанные обновления после LastUpdateID, которые еще нельзя подтвердить
+	HandledUpdateIDs []int
+	// UpdateFailures неудачные попытки обработать обновления после LastUpdateID, по id обновления
+	UpdateFailures map[int]int
+	// TagsIndex сообщения списка тегов в канале
+	TagsIndex []*TagsIndexMessage
+	// UserRoles роли пользователей, выданные из чата, по id в телеге
+	UserRoles map[int]string
+	// PendingTypos подписи, которые ждут ответа на подсказку про опечатки, по id подсказки
+	PendingTypos map[string]*PendingTypos
+}
+
+// clone неглубокая копия для транзакции. SentAnimation внутри считаются неизменяемыми,
+// их всегда заменяют целиком, так что копировать достаточно контейнеры
+func (m *metaData) clone() *metaData {
+	c := *m
+	c.Tags = append([]string(nil), m.Tags...)
+	c.HandledUpdateIDs = append([]int(nil), m.HandledUpdateIDs...)
+
+	c.TagsAliases = make(map[string]string, len(m.TagsAliases))
+	for alias, tag := range m.TagsAliases {
+		c.TagsAliases[alias] = tag
+	}
+
+	c.Messages = make(map[string]*SentAnimation, len(m.Messages))
+	for key, msg := range m.Messages {
+		c.Messages[key] = msg
+	}
+
+	// истории тоже не меняются на месте, транзакция подменяет слайс целиком
+	c.TagHistory = make(map[string][]*TagChange, len(m.TagHistory))
+	for key, history := range m.TagHistory {
+		c.TagHistory[key] = history
+	}
+
+	c.TagsIndex = cloneTagsIndex(m.TagsIndex)
+	c.UserRoles = cloneUserRoles(m.UserRoles)
+	c.UpdateFailures = cloneUpdateFailures(m.UpdateFailures)
+	c.PendingTypos = clonePendingTypos(m.PendingTypos)
+
+	c.FavChannelAnimations = make(map[string]*FavChannelAnimation, len(m.FavChannelAnimations))
+	for key, anim := range m.FavChannelAnimations {
+		c.FavChannelAnimations[key] = anim
+	}
+
+	return &c
 }
 
 type SentAnimation struct {
+	// MessageID сообщение в основном канале, 0 если гифки там нет
 	MessageID int
 	FileID    string
-	Tags      []string
+	// Tags только хештеги
+	Tags []string
+	// Description описание гифки из подписи
+	Description string `json:",omitempty"`
+	// ChannelMessages сообщения в остальных каналах по id канала
+	ChannelMessages map[int64]int `json:",omitempty"`
+}
+
+func (s *SentAnimation) clone() *SentAnimation {
+	c := *s
+	c.Tags = append([]string(nil), s.Tags...)
+	if s.ChannelMessages != nil {
+		c.ChannelMessages = make(map[int64]int, len(s.ChannelMessages))
+		for chatID, messageID := range s.ChannelMessages {
+			c.ChannelMessages[chatID] = messageID
+		}
+	}
+
+	return &c
+}
+
+func (f *FileMetaStorage) SetLastUpdateID(id int) {
+	f.update(func(tx *fileTx) {
+		tx.SetLastUpdateID(id)
+	})
+}
+
+func (f *FileMetaStorage) GetLastUpdateID() (id int) {
+	f.view(func(tx *fileTx) {
+		id = tx.GetLastUpdateID()
+	})
+
+	return id
+}
+
+func (f *FileMetaStorage) GetHandledUpdateIDs() (ids []int) {
+	f.view(func(tx *fileTx) {
+		ids = tx.GetHandledUpdateIDs()
+	})
+
+	return ids
+}
+
+func (f *FileMetaStorage) SetHandledUpdateIDs(ids []int) {
+	f.update(func(tx *fileTx) {
+		tx.SetHandledUpdateIDs(ids)
+	})
+}
+
+func (f *FileMetaStorage) GetUpdateFailures() (failures map[int]int) {
+	f.view(func(tx *fileTx) {
+		failures = tx.GetUpdateFailures()
+	})
+
+	return failures
+}
+
+func (f *FileMetaStorage) SetUpdateFailures(failures map[int]int) {
+	f.update(func(tx *fileTx) {
+		tx.SetUpdateFailures(failures)
+	})
+}
+
+func (f *FileMetaStorage) GetTagsIndex() (index []*TagsIndexMessage) {
+	f.view(func(tx *fileTx) {
+		index = tx.GetTagsIndex()
+	})
+
+	return index
+}
+
+func (f *FileMetaStorage) SetTagsIndex(index []*TagsIndexMessage) {
+	f.update(func(tx *fileTx) {
+		tx.SetTagsIndex(index)
+	})
+}
+
+func (f *FileMetaStorage) GetUserRoles() (roles map[int]string) {
+	f.view(func(tx *fileTx) {
+		roles = tx.GetUserRoles()
+	})
+
+	return roles
+}
+
+func (f *FileMetaStorage) SetUserRoles(roles map[int]string) {
+	f.update(func(tx *fileTx) {
+		tx.SetUserRoles(roles)
+	})
+}
+
+func (f *FileMetaStorage) GetPendingTypos() (pending map[string]*PendingTypos) {
+	f.view(func(tx *fileTx) {
+		pending = tx.GetPendingTypos()
+	})
+
+	return pending
+}
+
+func (f *FileMetaStorage) SetPendingTypos(pending map[string]*PendingTypos) {
+	f.update(func(tx *fileTx) {
+		tx.SetPendingTypos(pending)
+	})
 }
@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMetaStorage_journalReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+
+	store, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+
+	store.SetTags([]string{"#tag1"})
+	store.AddSentAnimations(map[string]*SentAnimation{
+		"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#tag1"}, Description: "описание"},
+	})
+	if err := store.Flush(); err != nil {
+		t.Fatalf("flush: %s", err)
+	}
+
+	store.SetTags([]string{"#tag1", "#tag2"})
+	store.AddSentAnimations(map[string]*SentAnimation{
+		"file_2": {MessageID: 20, FileID: "file_2", Tags: []string{"#tag2"}, ChannelMessages: map[int64]int{-200: 5}},
+	})
+	store.SetFavChannelLastForwardedMessageIDWithoutCaption(100500)
+	store.SetLastUpdateID(700)
+	store.SetHandledUpdateIDs([]int{702, 703})
+	store.SetUpdateFailures(map[int]int{701: 1})
+	store.SetTagsIndex([]*TagsIndexMessage{{MessageID: 1, Text: "#tag1"}, {MessageID: 2, Text: "#tag2"}})
+	store.SetUserRoles(map[int]string{42: "admin", 43: "tagger"})
+	store.SetPendingTypos(testPendingTypos())
+	store.AddFavChannelAnimations(map[string]*FavChannelAnimation{
+		"file_3": {FileID: "file_3", Tags: []string{"#tag3"}, Status: PublishStatusSent, ChannelMessageID: 30},
+	})
+
+	// "падаем" не вызывая Close, последние изменения есть только в журнале
+	store.journal.close()
+
+	// и недописанная строка в конце журнала
+	f, err := os.OpenFile(journalPath(path), os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatalf("open journal: %s", err)
+	}
+	f.WriteString(`{"Op":"SetTags","Data":["#bro`)
+	f.Close()
+
+	store, err = NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("reopen storage: %s", err)
+	}
+
+	assert.Equal(t, []string{"#tag1", "#tag2"}, store.GetTags())
+	assert.Equal(t, map[string]*SentAnimation{
+		"file_1": {MessageID: 10, FileID: "file_1", Tags: []string{"#tag1"}, Description: "описание"},
+		"file_2": {MessageID: 20, FileID: "file_2", Tags: []string{"#tag2"}, ChannelMessages: map[int64]int{-200: 5}},
+	}, store.GetSentAnimations())
+	assert.Equal(t, int64(100500), store.GetFavChannelLastForwardedMessageIDWithoutCaption())
+	assert.Equal(t, 700, store.GetLastUpdateID())
+	assert.Equal(t, []int{702, 703}, store.GetHandledUpdateIDs())
+	assert.Equal(t, map[int]int{701: 1}, store.GetUpdateFailures())
+	assert.Equal(t, []*TagsIndexMessage{{MessageID: 1, Text: "#tag1"}, {MessageID: 2, Text: "#tag2"}}, store.GetTagsIndex())
+	assert.Equal(t, map[int]string{42: "admin", 43: "tagger"}, store.GetUserRoles())
+	assert.Equal(t, testPendingTypos(), store.GetPendingTypos())
+	assert.Equal(t, map[string]*FavChannelAnimation{
+		"file_3": {FileID: "file_3", Tags: []string{"#tag3"}, Status: PublishStatusSent, ChannelMessageID: 30},
+	}, store.GetFavChannelAnimations())
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("close storage: %s", err)
+	}
+
+	_, err = os.Stat(journalPath(path))
+	assert.True(t, os.IsNotExist(err), "journal should be removed after close")
+
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("read dir: %s", err)
+	}
+	assert.Len(t, files, 1, "temp files should not be left")
+}
+
+func TestNewFileMetaStorageReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+
+	// бот работает: часть изменений в файле, часть только в журнале
+	bot, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer bot.Close()
+
+	bot.SetTags([]string{"#tag1"})
+	if err := bot.Flush(); err != nil {
+		t.Fatalf("flush: %s", err)
+	}
+	bot.SetTags([]string{"#tag1", "#tag2"})
+
+	fileBefore, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read storage file: %s", err)
+	}
+	journalBefore, err := ioutil.ReadFile(journalPath(path))
+	if err != nil {
+		t.Fatalf("read journal: %s", err)
+	}
+
+	store, err := NewFileMetaStorageReadOnly(path)
+	if err != nil {
+		t.Fatalf("open storage read only: %s", err)
+	}
+
+	assert.Equal(t, []string{"#tag1", "#tag2"}, store.GetTags(), "changes from journal should be visible")
+	assert.Equal(t, ErrReadOnly, store.Update(func(tx MetaTx) error {
+		tx.SetTags(nil)
+
+		return nil
+	}))
+	store.SetTags(nil)
+	assert.NoError(t, store.Flush())
+	assert.NoError(t, store.Close())
+
+	fileAfter, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(fileBefore), string(fileAfter), "storage file should not be rewritten")
+	journalAfter, err := ioutil.ReadFile(journalPath(path))
+	assert.NoError(t, err, "journal should stay")
+	assert.Equal(t, string(journalBefore), string(journalAfter))
+
+	// бот пишет в тот же журнал, а не в удаленный файл
+	bot.SetTags([]string{"#tag3"})
+	reopened, err := NewFileMetaStorageReadOnly(path)
+	if err != nil {
+		t.Fatalf("reopen storage read only: %s", err)
+	}
+	assert.Equal(t, []string{"#tag3"}, reopened.GetTags())
+
+	_, err = NewFileMetaStorageReadOnly(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NoError(t, err, "missing file is an empty storage")
+}
+
+func TestFileMetaStorage_Close_withoutChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+
+	store, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	store.SetTags([]string{"#tag1"})
+	if err := store.Close(); err != nil {
+		t.Fatalf("close storage: %s", err)
+	}
+	assert.False(t, fileExists(journalPath(path)), "flushed journal should be removed")
+
+	// другой процесс открыл тот же файл, пока этот работает: закрытие без изменений не удаляет чужой журнал
+	running, err := NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer running.Close()
+
+	store, err = NewFileMetaStorage(path)
+	if err != nil {
+		t.Fatalf("reopen storage: %s", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("close storage: %s", err)
+	}
+	assert.True(t, fileExists(journalPath(path)), "journal should stay")
+}
+
+func TestFileMetaStorage_Update(t *testing.T) {
+	store, err := NewFileMetaStorage(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+	defer store.Close()
+
+	store.SetTags([]string{"#tag1"})
+
+	err = store.Update(func(tx MetaTx) error {
+		tx.SetTags(append(tx.GetTags(), "#tag2"))
+
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"#tag1"}, store.GetTags(), "changes should be rolled back")
+
+	tags := store.GetTags()
+	tags[0] = "#changed"
+	assert.Equal(t, []string{"#tag1"}, store.GetTags(), "getter should return a copy")
+
+	// параллельные транзакции не должны терять изменения друг друга
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := store.Update(func(tx MetaTx) error {
+				key := fmt.Sprintf("file_%d", i)
+				tx.AddSentAnimations(map[string]*SentAnimation{key: {FileID: key, MessageID: i}})
+				tx.SetTags(append(tx.GetTags(), fmt.Sprintf("#tag_%d", i)))
+
+				return nil
+			})
+			assert.NoError(t, err)
+
+			_ = store.GetSentAnimations()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, store.GetSentAnimations(), 50)
+	assert.Len(t, store.GetTags(), 51)
+}
+
+func testPendingTypos() map[string]*PendingTypos {
+	return map[string]*PendingTypos{
+		"200:5": {
+			FileID:    "file_1",
+			Tags:      []string{"#kittne"},
+			Channels:  []int64{-200},
+			Typos:     []TagTypo{{Tag: "#kittne", Suggestion: "#kitten"}},
+			UserID:    1,
+			UserName:  "user",
+			ChangedAt: time.Unix(100, 0).UTC(),
+			AskedAt:   time.Unix(110, 0).UTC(),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package storage
+
+// TagsIndexMessage одно сообщение списка тегов в канале. Список может не влезть в одно сообщение,
+// тогда он разбит на несколько, первое из них закреплено
+type TagsIndexMessage struct {
+	MessageID int
+	// Text текст, с которым сообщение было отправлено, чтобы редактировать только изменившиеся части
+	Text string
+}
+
+func cloneTagsIndex(index []*TagsIndexMessage) []*TagsIndexMessage {
+	if index == nil {
+		return nil
+	}
+
+	c := make([]*TagsIndexMessage, 0, len(index))
+	for _, msg := range index {
+		m := *msg
+		c = append(c, &m)
+	}
+
+	return c
+}
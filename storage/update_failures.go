@@ -0,0 +1,14 @@
+package storage
+
+func cloneUpdateFailures(failures map[int]int) map[int]int {
+	if failures == nil {
+		return nil
+	}
+
+	c := make(map[int]int, len(failures))
+	for id, n := range failures {
+		c[id] = n
+	}
+
+	return c
+}
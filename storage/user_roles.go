@@ -0,0 +1,14 @@
+package storage
+
+func cloneUserRoles(roles map[int]string) map[int]string {
+	if roles == nil {
+		return nil
+	}
+
+	c := make(map[int]string, len(roles))
+	for id, role := range roles {
+		c[id] = role
+	}
+
+	return c
+}
@@ -0,0 +1,84 @@
+// Package tagnorm приводит теги к одному виду, чтобы #Ёжик, #ежик! и #ежик в другой форме юникода
+// были одним тегом
+package tagnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+// Normalizer нормализует теги по настройкам из конфига
+type Normalizer struct {
+	foldYo bool
+}
+
+// New создает Normalizer
+func New(conf config.TagNormalization) *Normalizer {
+	return &Normalizer{foldYo: conf.FoldYo}
+}
+
+// Tag приводит тег к виду, в котором его сохраняет бот:
+//   - юникод в NFC, строчные буквы, ё => е если включено в конфиге;
+//   - пробелы и тире заменены на _, повторы _ схлопнуты;
+//   - убраны пунктуация, эмодзи, селекторы вариантов и прочее, что телега не считает частью хештега.
+//
+// Вернет false, если после чистки от тега ничего не осталось
+func (n *Normalizer) Tag(tag string) (string, bool) {
+	tag = strings.ToLower(norm.NFC.String(tag))
+
+	var b strings.Builder
+	// underscore нужно ли поставить _ перед следующей буквой
+	underscore := false
+
+	for _, r := range tag {
+		switch {
+		case isVariationSelector(r):
+			continue
+		case r == '_' || unicode.IsSpace(r) || unicode.Is(unicode.Pd, r):
+			underscore = b.Len() > 0
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if underscore {
+				b.WriteRune('_')
+				underscore = false
+			}
+			if n.foldYo && r == 'ё' {
+				r = 'е'
+			}
+			b.WriteRune(r)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", false
+	}
+
+	return "#" + b.String(), true
+}
+
+// Tags нормализует список тегов: пустые выкидываются, повторы схлопываются, порядок сохраняется
+func (n *Normalizer) Tags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		normalized, ok := n.Tag(tag)
+		if !ok || seen[normalized] {
+			continue
+		}
+
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+
+	return result
+}
+
+// isVariationSelector селекторы вариантов U+FE00..U+FE0F и U+E0100..U+E01EF,
+// из-за них одинаковые на вид эмодзи и символы дают разные теги
+func isVariationSelector(r rune) bool {
+	return (r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0100 && r <= 0xE01EF)
+}
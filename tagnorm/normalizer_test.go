@@ -0,0 +1,55 @@
+package tagnorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyhalothrin/gifkoskladbot/config"
+)
+
+func TestNormalizer_Tag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		conf   config.TagNormalization
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{"already normal", config.TagNormalization{}, "#cat", "#cat", true},
+		{"without hash", config.TagNormalization{}, "cat", "#cat", true},
+		{"spaces and case", config.TagNormalization{}, " #Funny  Cat ", "#funny_cat", true},
+		{"extra hashes", config.TagNormalization{}, "##cat#dog", "#catdog", true},
+		{"trailing punctuation", config.TagNormalization{}, "#кот!,", "#кот", true},
+		{"dash and underscores", config.TagNormalization{}, "#кот-босс__dog_", "#кот_босс_dog", true},
+		{"nfd to nfc", config.TagNormalization{}, "#e\u0301te", "#\u00e9te", true},
+		{"keep yo", config.TagNormalization{}, "#Ёжик", "#ёжик", true},
+		{"fold yo", config.TagNormalization{FoldYo: true}, "#Ёжик", "#ежик", true},
+		{"fold decomposed yo", config.TagNormalization{FoldYo: true}, "#\u0435\u0308жик", "#ежик", true},
+		{"variation selector and emoji", config.TagNormalization{}, "#cat❤️", "#cat", true},
+		{"digits", config.TagNormalization{}, "#2020", "#2020", true},
+		{"only punctuation", config.TagNormalization{}, "#?!", "", false},
+		{"empty", config.TagNormalization{}, "#", "", false},
+		{"only spaces", config.TagNormalization{}, "  ", "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := New(tt.conf).Tag(tt.tag)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestNormalizer_Tags(t *testing.T) {
+	t.Parallel()
+
+	n := New(config.TagNormalization{FoldYo: true})
+
+	assert.Equal(t, []string{"#ежик", "#cat"}, n.Tags([]string{"#ёжик", "#ежик!", "#", "#Cat", "cat"}))
+}